@@ -0,0 +1,59 @@
+package main
+
+import (
+	"backend/config"
+	infrastructure_auth "backend/internal/infrastructure/auth"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	usecase_auth "backend/internal/usecase/auth"
+	"flag"
+)
+
+// 最初の管理者を作成するコマンド
+// 登録済みのユーザーにadminロールを付与する。管理者が既に存在する場合は何もしない。
+// 2人目以降の管理者はAssignRoleで付与すること。
+func main() {
+	email := flag.String("email", "", "管理者にするユーザーのメールアドレス(登録済みであること)")
+	flag.Parse()
+
+	// 環境変数の読み込み
+	appConfig := config.NewAppConfig()
+	appConfig.SetUpEnv()
+
+	// ログ設定
+	logger := pkg_logger.NewAppLogger()
+	logger.SetUpLogger()
+
+	if *email == "" {
+		logger.ErrorLog.Fatal("Usage: bootstrap -email <email>")
+	}
+
+	// Supabaseの接続
+	supabaseClient := pkg_supabase.NewSupabaseClient()
+	err := supabaseClient.InitSupabase(logger)
+	if err != nil {
+		logger.ErrorLog.Fatalf("Failed to initialize Supabase: %v", err)
+	}
+	defer supabaseClient.ClosePool(logger)
+
+	// DI
+	authRepository := infrastructure_auth.NewAuthRepository(logger, supabaseClient)
+	roleRepository := infrastructure_auth.NewRoleRepository(logger, supabaseClient)
+	roleUsecase := usecase_auth.NewRoleUsecase(logger, authRepository, roleRepository)
+
+	// 管理者を作成(usecase層)
+	user, err := roleUsecase.BootstrapAdmin(*email)
+	if err != nil {
+		switch err.Error() {
+		case "admin already exists":
+			logger.InfoLog.Println("Admin already exists. Use AssignRole to add more admins.")
+			return
+		case "user not found":
+			logger.ErrorLog.Fatalf("User not found: %s. Register the user first.", *email)
+		default:
+			logger.ErrorLog.Fatalf("Failed to bootstrap admin: %v", err)
+		}
+	}
+
+	logger.InfoLog.Printf("Granted admin role to %s (%s)", user.Email, user.ID)
+}
@@ -2,15 +2,27 @@ package main
 
 import (
 	"backend/config"
+	domain_auth "backend/internal/domain/auth"
+	domain_todo "backend/internal/domain/todo"
+	domain_user "backend/internal/domain/user"
 	infrastructure_auth "backend/internal/infrastructure/auth"
+	infrastructure_service_account "backend/internal/infrastructure/service_account"
 	infrastructure_todo "backend/internal/infrastructure/todo"
 	infrastructure_user "backend/internal/infrastructure/user"
 	interfaces_auth "backend/internal/interfaces/auth"
+	interfaces_service_account "backend/internal/interfaces/service_account"
 	interfaces_todo "backend/internal/interfaces/todo"
 	interfaces_user "backend/internal/interfaces/user"
+	pkg_keyset "backend/internal/pkg/keyset"
 	pkg_logger "backend/internal/pkg/logger"
+	pkg_mailer "backend/internal/pkg/mailer"
+	pkg_notifier "backend/internal/pkg/notifier"
+	pkg_password "backend/internal/pkg/password"
+	pkg_secretbox "backend/internal/pkg/secretbox"
+	pkg_signedtoken "backend/internal/pkg/signedtoken"
 	pkg_supabase "backend/internal/pkg/supabase"
 	usecase_auth "backend/internal/usecase/auth"
+	usecase_service_account "backend/internal/usecase/service_account"
 	usecase_todo "backend/internal/usecase/todo"
 	usecase_user "backend/internal/usecase/user"
 	pb "backend/proto/github.com/grpc/backend/proto"
@@ -19,13 +31,14 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"google.golang.org/grpc"
 )
 
 // main関数のセットアップ
-func setUp(l *pkg_logger.AppLogger, appConfig *config.AppConfig, sc *pkg_supabase.SupabaseClient) (*grpc.Server, error) {
+func setUp(l *pkg_logger.AppLogger, appConfig *config.AppConfig, sc *pkg_supabase.SupabaseClient, e *echo.Echo) (*grpc.Server, usecase_todo.IReminderScheduler, error) {
 	// Supabaseの接続
 	err := sc.InitSupabase(l)
 	if err != nil {
@@ -37,31 +50,178 @@ func setUp(l *pkg_logger.AppLogger, appConfig *config.AppConfig, sc *pkg_supabas
 		l.ErrorLog.Fatalf("Failed to test query: %v", err)
 	}
 
+	// JWT署名鍵の読み込み
+	keySet := pkg_keyset.NewKeySet(l, appConfig.JWTKeysDir)
+	err = keySet.Load()
+	if err != nil {
+		l.ErrorLog.Fatalf("Failed to load signing keys: %v", err)
+	}
+	keySet.StartAutoReload(appConfig.JWTKeysReloadInterval)
+
 	// DI
 	// repository層
 	userRepository := infrastructure_user.NewUserRepository(l, sc)
 	todoRepository := infrastructure_todo.NewTodoRepository(l, sc)
+	tagRepository := infrastructure_todo.NewTagRepository(l, sc)
 	authRepository := infrastructure_auth.NewAuthRepository(l, sc)
+	tokenRepository := infrastructure_auth.NewTokenRepository(l, sc)
+	loginAttemptRepository := infrastructure_auth.NewLoginAttemptRepository(l, sc)
+	passwordResetRepository := infrastructure_auth.NewPasswordResetRepository(l, sc)
+	totpRepository := infrastructure_auth.NewTOTPRepository(l, sc)
+	sessionRepository := infrastructure_auth.NewSessionRepository(l, sc)
+	roleRepository := infrastructure_auth.NewRoleRepository(l, sc)
+	serviceAccountRepository := infrastructure_service_account.NewServiceAccountRepository(l, sc)
+	// パスワードハッシャー
+	passwordHasher := pkg_password.NewPasswordHasher(appConfig.PasswordHashCost)
+	// TOTPシークレットの暗号化
+	secretBox, err := pkg_secretbox.NewSecretBox(appConfig.MFAEncryptionKey)
+	if err != nil {
+		l.ErrorLog.Fatalf("Failed to initialize MFA encryption: %v", err)
+	}
+	// メール送信
+	var mailer pkg_mailer.IMailer
+	switch appConfig.MailDriver {
+	case "smtp":
+		mailer = pkg_mailer.NewSMTPMailer(appConfig.SMTPHost, appConfig.SMTPPort, appConfig.SMTPUsername, appConfig.SMTPPassword, appConfig.MailFrom)
+	case "outbox":
+		mailer = pkg_mailer.NewOutboxMailer(appConfig.MailOutboxDir, appConfig.MailFrom)
+	default:
+		l.ErrorLog.Fatalf("Unknown mail driver: %s", appConfig.MailDriver)
+	}
+	// ユーザーのパージ時のTodoの扱い
+	userPurgeTodoPolicy, ok := domain_user.ParseTodoCascadePolicy(appConfig.UserPurgeTodoPolicy)
+	if !ok {
+		l.ErrorLog.Fatalf("Unknown user purge todo policy: %s", appConfig.UserPurgeTodoPolicy)
+	}
+	// 新規登録したユーザーに付与するロール
+	if !domain_auth.IsKnownRole(appConfig.UserRole) {
+		l.ErrorLog.Fatalf("Unknown default user role: %s", appConfig.UserRole)
+	}
+	// 「今日が期限」の判定に使用する既定のタイムゾーン
+	todoDefaultLocation, err := time.LoadLocation(appConfig.TodoDefaultTimeZone)
+	if err != nil {
+		l.ErrorLog.Fatalf("Unknown todo default time zone: %s", appConfig.TodoDefaultTimeZone)
+	}
+	// サブタスクの完了に合わせた親のTodoの完了状態の扱い
+	todoParentCompletion, ok := domain_todo.ParseParentCompletionRule(appConfig.TodoParentCompletion)
+	if !ok {
+		l.ErrorLog.Fatalf("Unknown todo parent completion rule: %s", appConfig.TodoParentCompletion)
+	}
+	// リマインダーの通知
+	var notifier pkg_notifier.INotifier
+	switch appConfig.ReminderNotifier {
+	case "webhook":
+		if appConfig.ReminderWebhookURL == "" {
+			l.ErrorLog.Fatal("REMINDER_WEBHOOK_URL is required for the webhook notifier")
+		}
+		notifier = pkg_notifier.NewWebhookNotifier(appConfig.ReminderWebhookURL, appConfig.ReminderWebhookSecret)
+	case "log":
+		notifier = pkg_notifier.NewLogNotifier(l)
+	default:
+		l.ErrorLog.Fatalf("Unknown reminder notifier: %s", appConfig.ReminderNotifier)
+	}
+	if appConfig.ReminderBatchSize <= 0 {
+		l.ErrorLog.Fatalf("Invalid reminder batch size: %d", appConfig.ReminderBatchSize)
+	}
 	// usecase層
-	userUsecase := usecase_user.NewUserUsecase(l, userRepository)
-	todoUsecase := usecase_todo.NewTodoUsecase(l, todoRepository)
-	authUsecase := usecase_auth.NewAuthUsecase(l, authRepository)
+	userUsecase := usecase_user.NewUserUsecase(l, userRepository, userPurgeTodoPolicy, appConfig.UserPurgeReassignTo)
+	todoUsecase := usecase_todo.NewTodoUsecase(l, todoRepository, todoDefaultLocation, todoParentCompletion)
+	tagUsecase := usecase_todo.NewTagUsecase(l, tagRepository)
+	authUsecase := usecase_auth.NewAuthUsecase(
+		l,
+		authRepository,
+		tokenRepository,
+		userRepository,
+		sessionRepository,
+		loginAttemptRepository,
+		passwordHasher,
+		appConfig.RefreshTokenTTL,
+		domain_auth.LoginThrottlePolicy{
+			MaxAttempts:     appConfig.LoginMaxAttempts,
+			BaseDelay:       appConfig.LoginBackoffBase,
+			MaxDelay:        appConfig.LoginBackoffMax,
+			LockoutDuration: appConfig.LoginLockoutDuration,
+		},
+		domain_auth.LoginThrottlePolicy{
+			MaxAttempts:     appConfig.LoginMaxAttemptsPerClient,
+			BaseDelay:       appConfig.LoginBackoffBase,
+			MaxDelay:        appConfig.LoginBackoffMax,
+			LockoutDuration: appConfig.LoginLockoutDuration,
+		},
+		appConfig.RequireVerifiedEmail,
+		appConfig.UserRole,
+	)
+	passwordResetUsecase := usecase_auth.NewPasswordResetUsecase(
+		l,
+		authRepository,
+		passwordResetRepository,
+		passwordHasher,
+		mailer,
+		appConfig.PasswordResetTTL,
+		appConfig.PasswordResetURL,
+	)
+	totpUsecase := usecase_auth.NewTOTPUsecase(
+		l,
+		authRepository,
+		totpRepository,
+		loginAttemptRepository,
+		secretBox,
+		appConfig.TOTPIssuer,
+		domain_auth.LoginThrottlePolicy{
+			MaxAttempts:     appConfig.LoginMaxAttempts,
+			BaseDelay:       appConfig.LoginBackoffBase,
+			MaxDelay:        appConfig.LoginBackoffMax,
+			LockoutDuration: appConfig.LoginLockoutDuration,
+		},
+	)
+	emailVerificationUsecase := usecase_auth.NewEmailVerificationUsecase(
+		l,
+		authRepository,
+		loginAttemptRepository,
+		mailer,
+		pkg_signedtoken.NewSigner(keySet),
+		domain_auth.LoginThrottlePolicy{
+			MaxAttempts:     appConfig.LoginMaxAttempts,
+			BaseDelay:       appConfig.LoginBackoffBase,
+			MaxDelay:        appConfig.LoginBackoffMax,
+			LockoutDuration: appConfig.LoginLockoutDuration,
+		},
+		appConfig.EmailVerificationTTL,
+		appConfig.EmailVerificationURL,
+	)
+	serviceAccountUsecase := usecase_service_account.NewServiceAccountUsecase(l, serviceAccountRepository)
+	roleUsecase := usecase_auth.NewRoleUsecase(l, authRepository, roleRepository)
+	reminderScheduler := usecase_todo.NewReminderScheduler(l, todoRepository, notifier, appConfig.ReminderPollInterval, appConfig.ReminderBatchSize)
 	// handler層
 	userHandler := interfaces_user.NewUserHandler(l, userUsecase)
-	todoHandler := interfaces_todo.NewTodoHandler(l, todoUsecase)
-	authHandler := interfaces_auth.NewAuthHandler(l, appConfig, authUsecase)
+	todoHandler := interfaces_todo.NewTodoHandler(l, todoUsecase, tagUsecase)
+	authHandler := interfaces_auth.NewAuthHandler(l, appConfig, authUsecase, passwordResetUsecase, totpUsecase, serviceAccountUsecase, emailVerificationUsecase, roleUsecase, keySet)
+	serviceAccountHandler := interfaces_service_account.NewServiceAccountHandler(l, serviceAccountUsecase)
 
 	// gRPCサーバーのインスタンス化
 	server := grpc.NewServer(
-		grpc.UnaryInterceptor(authHandler.AuthInterceptor(appConfig.JWTSecret, appConfig.UserRole)),
+		grpc.UnaryInterceptor(authHandler.AuthInterceptor()),
+		grpc.StreamInterceptor(authHandler.AuthStreamInterceptor()),
 	)
 
 	// gRPCサーバーにハンドラーを登録
 	pb.RegisterUserServiceServer(server, userHandler)
 	pb.RegisterTodoServiceServer(server, todoHandler)
 	pb.RegisterAuthServiceServer(server, authHandler)
+	pb.RegisterServiceAccountServiceServer(server, serviceAccountHandler)
 
-	return server, nil
+	// Echoにルートを登録
+	e.GET("/.well-known/jwks.json", authHandler.GetJWKS)
+	e.GET("/verify-email", authHandler.ConfirmEmailVerification)
+	e.POST("/verify-email", authHandler.VerifyEmail)
+
+	// リマインダーの送信を開始
+	if !appConfig.ReminderEnabled {
+		return server, nil, nil
+	}
+	reminderScheduler.Start()
+
+	return server, reminderScheduler, nil
 }
 
 // アプリケーションのメイン関数
@@ -88,7 +248,7 @@ func main() {
 	}
 
 	// セットアップ
-	server, err := setUp(logger, appConfig, supabaseClient)
+	server, reminderScheduler, err := setUp(logger, appConfig, supabaseClient, e)
 	if err != nil {
 		logger.ErrorLog.Fatalf("failed to set up: %v", err)
 		os.Exit(1)
@@ -111,6 +271,11 @@ func main() {
 		// gRPCサーバーのシャットダウン
 		server.GracefulStop()
 
+		// リマインダーの送信を停止
+		if reminderScheduler != nil {
+			reminderScheduler.Stop()
+		}
+
 		// Supabaseコネクションプールのクローズ
 		supabaseClient.ClosePool(logger)
 	}()
@@ -4,16 +4,87 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // アプリケーションの設定
 type AppConfig struct {
-	TestAPI   string
-	UserID    string
-	UserRole  string
-	JWTSecret string
+	TestAPI string
+	// 新規登録したユーザーに付与するロール
+	UserRole string
+	// メールアドレスごとのログイン失敗回数の上限(超えるとロック)
+	LoginMaxAttempts int
+	// クライアントアドレスごとのログイン失敗回数の上限(超えるとロック)
+	LoginMaxAttemptsPerClient int
+	// ログイン失敗時のバックオフの初期待機時間
+	LoginBackoffBase time.Duration
+	// ログイン失敗時のバックオフの最大待機時間
+	LoginBackoffMax time.Duration
+	// ログインのロック期間
+	LoginLockoutDuration time.Duration
+	// JWT署名鍵(PEM)を配置するディレクトリ
+	JWTKeysDir string
+	// JWT署名鍵ディレクトリの再読み込み間隔
+	JWTKeysReloadInterval time.Duration
+	// パスワードハッシュ(bcrypt)のコスト
+	PasswordHashCost int
+	// アクセストークンの有効期間
+	AccessTokenTTL time.Duration
+	// リフレッシュトークンの有効期間
+	RefreshTokenTTL time.Duration
+	// メール送信方式(outbox: ファイル出力, smtp: SMTP送信)
+	MailDriver string
+	// SMTPサーバーのホスト
+	SMTPHost string
+	// SMTPサーバーのポート
+	SMTPPort int
+	// SMTP認証のユーザー名
+	SMTPUsername string
+	// SMTP認証のパスワード
+	SMTPPassword string
+	// 送信元メールアドレス
+	MailFrom string
+	// MailDriverがoutboxの場合のメール出力先ディレクトリ
+	MailOutboxDir string
+	// パスワード再設定トークンの有効期間
+	PasswordResetTTL time.Duration
+	// パスワード再設定画面のURL(メール本文に記載)
+	PasswordResetURL string
+	// 認証アプリに表示するTOTPの発行者名
+	TOTPIssuer string
+	// TOTPのシークレットを暗号化する鍵(Base64でエンコードされた32バイト)
+	MFAEncryptionKey string
+	// 二要素認証のチャレンジトークンの有効期間
+	MFAChallengeTTL time.Duration
+	// メールアドレスが未確認のユーザーのログインを拒否する
+	RequireVerifiedEmail bool
+	// メールアドレス確認リンクの有効期間
+	EmailVerificationTTL time.Duration
+	// メールアドレス確認リンクのURL(GET /verify-email を公開しているURL)
+	EmailVerificationURL string
+	// ユーザーのパージ時のTodoの扱いのデフォルト(delete, reassign, archive)
+	UserPurgeTodoPolicy string
+	// UserPurgeTodoPolicyがreassignの場合のTodoの付け替え先のユーザーID
+	UserPurgeReassignTo string
+	// 「今日が期限」の判定に使用する既定のタイムゾーン(IANAのタイムゾーン名)
+	TodoDefaultTimeZone string
+	// サブタスクの完了に合わせた親のTodoの完了状態の扱い(auto: 全て完了したら親も完了, block: 未完了のサブタスクがあれば親を完了にできない)
+	TodoParentCompletion string
+	// リマインダーの送信を有効にする
+	ReminderEnabled bool
+	// リマインダーの確認間隔
+	ReminderPollInterval time.Duration
+	// リマインダーを1回に送信する最大件数
+	ReminderBatchSize int
+	// リマインダーの通知方式(log: ログ出力, webhook: Webhook送信)
+	ReminderNotifier string
+	// ReminderNotifierがwebhookの場合の送信先URL
+	ReminderWebhookURL string
+	// Webhookの署名に使用するシークレット(空の場合は署名しない)
+	ReminderWebhookSecret string
 }
 
 // アプリケーションの設定のインスタンス化
@@ -63,7 +134,125 @@ func (c *AppConfig) SetUpEnv() {
 	}
 
 	c.TestAPI = os.Getenv("TEST_API")
-	c.UserID = os.Getenv("USER_ID")
 	c.UserRole = os.Getenv("ROLE_USER")
-	c.JWTSecret = os.Getenv("JWT_SECRET")
+	if c.UserRole == "" {
+		c.UserRole = "user"
+	}
+	c.LoginMaxAttempts = getEnvInt("LOGIN_MAX_ATTEMPTS", 5)
+	c.LoginMaxAttemptsPerClient = getEnvInt("LOGIN_MAX_ATTEMPTS_PER_CLIENT", 50)
+	c.LoginBackoffBase = getEnvDuration("LOGIN_BACKOFF_BASE", time.Second)
+	c.LoginBackoffMax = getEnvDuration("LOGIN_BACKOFF_MAX", 30*time.Second)
+	c.LoginLockoutDuration = getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute)
+	c.JWTKeysDir = os.Getenv("JWT_KEYS_DIR")
+	if c.JWTKeysDir == "" {
+		c.JWTKeysDir = "keys"
+	}
+	if !filepath.IsAbs(c.JWTKeysDir) {
+		c.JWTKeysDir = filepath.Join(projectRoot, c.JWTKeysDir)
+	}
+	c.JWTKeysReloadInterval = getEnvDuration("JWT_KEYS_RELOAD_INTERVAL", time.Minute)
+	c.PasswordHashCost = getEnvInt("PASSWORD_HASH_COST", 10)
+	c.AccessTokenTTL = getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+	c.RefreshTokenTTL = getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+	c.MailDriver = os.Getenv("MAIL_DRIVER")
+	if c.MailDriver == "" {
+		c.MailDriver = "outbox"
+	}
+	c.SMTPHost = os.Getenv("SMTP_HOST")
+	c.SMTPPort = getEnvInt("SMTP_PORT", 587)
+	c.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	c.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	c.MailFrom = os.Getenv("MAIL_FROM")
+	if c.MailFrom == "" {
+		c.MailFrom = "no-reply@example.com"
+	}
+	c.MailOutboxDir = os.Getenv("MAIL_OUTBOX_DIR")
+	if c.MailOutboxDir == "" {
+		c.MailOutboxDir = "outbox"
+	}
+	if !filepath.IsAbs(c.MailOutboxDir) {
+		c.MailOutboxDir = filepath.Join(projectRoot, c.MailOutboxDir)
+	}
+	c.PasswordResetTTL = getEnvDuration("PASSWORD_RESET_TTL", time.Hour)
+	c.PasswordResetURL = os.Getenv("PASSWORD_RESET_URL")
+	c.TOTPIssuer = os.Getenv("TOTP_ISSUER")
+	if c.TOTPIssuer == "" {
+		c.TOTPIssuer = "go-echo-grpc-ddd-sample"
+	}
+	c.MFAEncryptionKey = os.Getenv("MFA_ENCRYPTION_KEY")
+	c.MFAChallengeTTL = getEnvDuration("MFA_CHALLENGE_TTL", 5*time.Minute)
+	c.RequireVerifiedEmail = getEnvBool("REQUIRE_VERIFIED_EMAIL", false)
+	c.EmailVerificationTTL = getEnvDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour)
+	c.EmailVerificationURL = os.Getenv("EMAIL_VERIFICATION_URL")
+	if c.EmailVerificationURL == "" {
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		c.EmailVerificationURL = "http://localhost:" + port + "/verify-email"
+	}
+	c.UserPurgeTodoPolicy = os.Getenv("USER_PURGE_TODO_POLICY")
+	if c.UserPurgeTodoPolicy == "" {
+		c.UserPurgeTodoPolicy = "archive"
+	}
+	c.UserPurgeReassignTo = os.Getenv("USER_PURGE_REASSIGN_TO")
+	c.TodoDefaultTimeZone = os.Getenv("TODO_DEFAULT_TIME_ZONE")
+	if c.TodoDefaultTimeZone == "" {
+		c.TodoDefaultTimeZone = "UTC"
+	}
+	c.TodoParentCompletion = os.Getenv("TODO_PARENT_COMPLETION")
+	if c.TodoParentCompletion == "" {
+		c.TodoParentCompletion = "auto"
+	}
+	c.ReminderEnabled = getEnvBool("REMINDER_ENABLED", true)
+	c.ReminderPollInterval = getEnvDuration("REMINDER_POLL_INTERVAL", 30*time.Second)
+	c.ReminderBatchSize = getEnvInt("REMINDER_BATCH_SIZE", 50)
+	c.ReminderNotifier = os.Getenv("REMINDER_NOTIFIER")
+	if c.ReminderNotifier == "" {
+		c.ReminderNotifier = "log"
+	}
+	c.ReminderWebhookURL = os.Getenv("REMINDER_WEBHOOK_URL")
+	c.ReminderWebhookSecret = os.Getenv("REMINDER_WEBHOOK_SECRET")
+}
+
+// 整数の環境変数を取得する。未設定または不正な値の場合はデフォルト値を返す。
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid %s: %v. Using default value %d", key, err, defaultValue)
+		return defaultValue
+	}
+	return i
+}
+
+// 真偽値の環境変数を取得する(true, 1 など)。未設定または不正な値の場合はデフォルト値を返す。
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid %s: %q. Using default value %v", key, value, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+// 期間の環境変数を取得する(例: 15m, 720h)。未設定または不正な値の場合はデフォルト値を返す。
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s: %q. Using default value %v", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
 }
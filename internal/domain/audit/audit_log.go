@@ -0,0 +1,30 @@
+package domain_audit
+
+import "time"
+
+// 監査ログの操作
+const (
+	// ユーザーの完全削除(パージ)
+	ActionUserPurge = "user.purge"
+	// ユーザーへのロールの付与
+	ActionRoleAssign = "role.assign"
+	// ユーザーからのロールの剥奪
+	ActionRoleRevoke = "role.revoke"
+)
+
+// 監査ログの対象の種類
+const (
+	// ユーザー
+	TargetTypeUser = "user"
+)
+
+// 監査ログ
+type AuditLog struct {
+	ID         string                 `json:"id"          db:"id"`          // UUID型
+	ActorID    string                 `json:"actor_id"    db:"actor_id"`    // 操作を行った主体(ユーザーID・サービスアカウントID)
+	Action     string                 `json:"action"      db:"action"`      // 操作
+	TargetType string                 `json:"target_type" db:"target_type"` // 対象の種類
+	TargetID   string                 `json:"target_id"   db:"target_id"`   // 対象のID
+	Details    map[string]interface{} `json:"details"     db:"details"`     // 操作の詳細
+	CreatedAt  time.Time              `json:"created_at"  db:"created_at"`  // タイムスタンプ
+}
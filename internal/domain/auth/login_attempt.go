@@ -0,0 +1,85 @@
+package domain_auth
+
+import (
+	"math"
+	"time"
+)
+
+// ログイン試行の記録
+type LoginAttempt struct {
+	Key          string     `json:"key"            db:"attempt_key"`    // "email:<メールアドレス>"、"ip:<アドレス>" または "mfa:<ユーザーID>"
+	FailureCount int        `json:"failure_count"  db:"failure_count"`  // 連続失敗回数
+	LastFailedAt time.Time  `json:"last_failed_at" db:"last_failed_at"` // 最終失敗日時
+	LockedUntil  *time.Time `json:"locked_until"   db:"locked_until"`   // ロック解除日時
+}
+
+// ロック中かどうか
+// ロック中の場合は解除までの残り時間を返す。
+func (a LoginAttempt) RetryAfter(now time.Time) (time.Duration, bool) {
+	if a.LockedUntil == nil || !now.Before(*a.LockedUntil) {
+		return 0, false
+	}
+	return a.LockedUntil.Sub(now), true
+}
+
+// ログイン試行の制限ポリシー
+type LoginThrottlePolicy struct {
+	// アカウントをロックするまでの失敗回数
+	MaxAttempts int
+	// バックオフの初期待機時間
+	BaseDelay time.Duration
+	// バックオフの最大待機時間
+	MaxDelay time.Duration
+	// ロック期間(最終失敗からこの期間が経過すると失敗回数をリセットする)
+	LockoutDuration time.Duration
+}
+
+// 失敗回数に応じた待機時間を計算
+// 閾値未満の場合は指数バックオフ(BaseDelay * 2^(失敗回数-1))、閾値以上の場合はロック期間を返す。
+func (p LoginThrottlePolicy) LockDuration(failureCount int) time.Duration {
+	if failureCount <= 0 {
+		return 0
+	}
+	if failureCount >= p.MaxAttempts {
+		return p.LockoutDuration
+	}
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(failureCount-1))
+	if delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// メールアドレスのキー
+func EmailAttemptKey(email string) string {
+	return "email:" + email
+}
+
+// クライアントアドレスのキー
+func ClientAttemptKey(addr string) string {
+	return "ip:" + addr
+}
+
+// 二要素認証のキー
+func SecondFactorAttemptKey(userID string) string {
+	return "mfa:" + userID
+}
+
+// パスワード変更(現在のパスワードの確認)のキー
+func PasswordChangeAttemptKey(userID string) string {
+	return "password:" + userID
+}
+
+// 確認メールの再送(メールアドレス)のキー
+func VerificationResendEmailKey(email string) string {
+	return "verify:" + email
+}
+
+// 確認メールの再送(クライアントアドレス)のキー
+func VerificationResendClientKey(addr string) string {
+	return "verify-ip:" + addr
+}
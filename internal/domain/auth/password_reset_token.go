@@ -0,0 +1,13 @@
+package domain_auth
+
+import "time"
+
+// パスワード再設定トークン情報
+type PasswordResetToken struct {
+	ID        string     `json:"id"         db:"id"`         // UUID型
+	UserID    string     `json:"user_id"    db:"user_id"`    // ユーザーID
+	TokenHash string     `json:"-"          db:"token_hash"` // トークンのハッシュ値
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"` // 有効期限
+	UsedAt    *time.Time `json:"used_at"    db:"used_at"`    // 使用日時
+	CreatedAt time.Time  `json:"created_at" db:"created_at"` // タイムスタンプ
+}
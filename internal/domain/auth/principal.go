@@ -0,0 +1,66 @@
+package domain_auth
+
+import (
+	"context"
+	"time"
+)
+
+// 主体の種類
+const (
+	// ユーザー(JWTで認証)
+	PrincipalKindUser = "user"
+	// サービスアカウント(APIキーで認証)
+	PrincipalKindServiceAccount = "service_account"
+)
+
+// 認証済みの主体(リクエストの実行者)
+// 認証インターセプターで生成し、コンテキスト経由でハンドラー・ユースケースに渡す。
+type Principal struct {
+	Kind             string          // 主体の種類
+	UserID           string          // ユーザーID(サービスアカウントの場合は空)
+	ServiceAccountID string          // サービスアカウントID(ユーザーの場合は空)
+	APIKeyID         string          // APIキーのID(サービスアカウントの場合のみ)
+	Roles            []string        // ロール
+	Permissions      map[string]bool // ロール(またはAPIキーのスコープ)から解決した権限
+	TokenID          string          // アクセストークンのID(jti)
+	SessionID        string          // ログインセッションのID(ユーザーの場合のみ)
+	ExpiresAt        time.Time       // アクセストークンの有効期限
+}
+
+// 権限を持っているかどうか
+func (p *Principal) HasPermission(permission string) bool {
+	return p != nil && p.Permissions[permission]
+}
+
+// ロールを持っているかどうか
+func (p *Principal) HasRole(role string) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// 指定したユーザー本人かどうか
+func (p *Principal) IsUser(userID string) bool {
+	return p != nil && p.UserID != "" && p.UserID == userID
+}
+
+// コンテキストのキー(他パッケージのキーと衝突しないよう非公開の型を使う)
+type principalContextKey struct{}
+
+// 認証済みの主体をコンテキストに設定
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// コンテキストから認証済みの主体を取得
+// 認証不要のメソッドなど、設定されていない場合はfalseを返す。
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return p, ok && p != nil
+}
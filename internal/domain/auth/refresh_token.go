@@ -0,0 +1,26 @@
+package domain_auth
+
+import "time"
+
+// リフレッシュトークン情報
+type RefreshToken struct {
+	ID         string     `json:"id"          db:"id"`          // UUID型
+	UserID     string     `json:"user_id"     db:"user_id"`     // ユーザーID
+	FamilyID   string     `json:"family_id"   db:"family_id"`   // トークン系列ID
+	SessionID  string     `json:"session_id"  db:"session_id"`  // セッションID
+	TokenHash  string     `json:"-"           db:"token_hash"`  // トークンのハッシュ値
+	ExpiresAt  time.Time  `json:"expires_at"  db:"expires_at"`  // 有効期限
+	RevokedAt  *time.Time `json:"revoked_at"  db:"revoked_at"`  // 失効日時
+	ReplacedBy *string    `json:"replaced_by" db:"replaced_by"` // ローテーション後のトークンID
+	CreatedAt  time.Time  `json:"created_at"  db:"created_at"`  // タイムスタンプ
+}
+
+// 失効済みかどうか
+func (t RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// 有効期限切れかどうか
+func (t RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
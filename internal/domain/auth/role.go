@@ -0,0 +1,112 @@
+package domain_auth
+
+import (
+	"sort"
+	"time"
+)
+
+// ロール
+const (
+	// 管理者
+	RoleAdmin = "admin"
+	// 一般ユーザー
+	RoleUser = "user"
+)
+
+// 権限
+const (
+	// Todoの参照
+	PermissionTodoRead = "todo:read"
+	// Todoの作成・更新・削除
+	PermissionTodoWrite = "todo:write"
+	// 他のユーザーのTodoの参照・作成・更新・削除
+	PermissionTodoAdmin = "todo:admin"
+	// ユーザー一覧の参照
+	PermissionUserList = "user:list"
+	// アカウントのロック解除
+	PermissionUserUnlock = "user:unlock"
+	// 他のユーザーのプロフィールの更新・削除
+	PermissionUserManage = "user:manage"
+	// サービスアカウント・APIキーの管理
+	PermissionServiceAccountManage = "service_account:manage"
+	// 他のユーザーのセッションの失効
+	PermissionSessionManage = "session:manage"
+	// ユーザーへのロールの付与・剥奪
+	PermissionRoleManage = "role:manage"
+)
+
+// ロールの定義(rolesテーブル)
+type Role struct {
+	Name        string    `json:"name"        db:"name"`        // ロール名
+	Description string    `json:"description" db:"description"` // 説明
+	CreatedAt   time.Time `json:"created_at"  db:"created_at"`  // タイムスタンプ
+}
+
+// ロールごとに付与される権限
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionTodoRead,
+		PermissionTodoWrite,
+		PermissionTodoAdmin,
+		PermissionUserList,
+		PermissionUserUnlock,
+		PermissionUserManage,
+		PermissionServiceAccountManage,
+		PermissionSessionManage,
+		PermissionRoleManage,
+	},
+	RoleUser: {
+		PermissionTodoRead,
+		PermissionTodoWrite,
+	},
+}
+
+// ロールから権限を解決する
+// 未知のロールには権限を付与しない。
+func PermissionsForRoles(roles []string) map[string]bool {
+	permissions := map[string]bool{}
+	for _, role := range roles {
+		for _, permission := range rolePermissions[role] {
+			permissions[permission] = true
+		}
+	}
+	return permissions
+}
+
+// ロールに付与される権限を取得(名前順)
+func PermissionsOfRole(role string) []string {
+	permissions := append([]string{}, rolePermissions[role]...)
+	sort.Strings(permissions)
+	return permissions
+}
+
+// アプリケーションで権限が定義されているロールかどうか
+func IsKnownRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// APIキーのスコープとして付与できる権限
+// サービスアカウントの管理・ユーザーの一覧など、adminロールを必要とする権限は付与できない。
+var apiKeyScopes = map[string]bool{
+	PermissionTodoRead:  true,
+	PermissionTodoWrite: true,
+	PermissionTodoAdmin: true,
+}
+
+// APIキーのスコープとして付与できるか
+func IsValidAPIKeyScope(scope string) bool {
+	return apiKeyScopes[scope]
+}
+
+// スコープから権限を解決する
+// 付与できないスコープは無視する。
+func PermissionsForScopes(scopes []string) map[string]bool {
+	permissions := map[string]bool{}
+	for _, scope := range scopes {
+		if apiKeyScopes[scope] {
+			permissions[scope] = true
+		}
+	}
+	return permissions
+}
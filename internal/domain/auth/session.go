@@ -0,0 +1,19 @@
+package domain_auth
+
+import "time"
+
+// ログインセッション情報
+type Session struct {
+	ID         string     `json:"id"           db:"id"`           // UUID型
+	UserID     string     `json:"user_id"      db:"user_id"`      // ユーザーID
+	UserAgent  string     `json:"user_agent"   db:"user_agent"`   // ログイン時のユーザーエージェント
+	IPAddress  string     `json:"ip_address"   db:"ip_address"`   // ログイン時のクライアントアドレス
+	CreatedAt  time.Time  `json:"created_at"   db:"created_at"`   // タイムスタンプ
+	LastSeenAt time.Time  `json:"last_seen_at" db:"last_seen_at"` // 最終利用日時
+	RevokedAt  *time.Time `json:"revoked_at"   db:"revoked_at"`   // 失効日時
+}
+
+// 失効済みかどうか
+func (s Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
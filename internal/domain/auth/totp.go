@@ -0,0 +1,13 @@
+package domain_auth
+
+import "time"
+
+// TOTPによる二要素認証の設定
+type UserTOTP struct {
+	UserID       string    `json:"user_id"        db:"user_id"`        // ユーザーID
+	Secret       string    `json:"-"              db:"secret"`         // 暗号化されたシークレット
+	Enabled      bool      `json:"enabled"        db:"enabled"`        // 有効化済みかどうか
+	LastUsedStep *int64    `json:"-"              db:"last_used_step"` // 最後に使用したコードの時間ステップ
+	CreatedAt    time.Time `json:"created_at"     db:"created_at"`     // タイムスタンプ
+	UpdatedAt    time.Time `json:"updated_at"     db:"updated_at"`     // タイムスタンプ
+}
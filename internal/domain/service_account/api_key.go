@@ -0,0 +1,40 @@
+package domain_service_account
+
+import "time"
+
+// APIキーの接頭辞(キーの種類を識別しやすくする)
+const APIKeyPrefix = "sk_"
+
+// APIキーの一覧表示に使用する文字数(接頭辞を除く)
+const apiKeyDisplayLength = 8
+
+// APIキー情報
+type APIKey struct {
+	ID               string     `json:"id"                 db:"id"`                 // UUID型
+	ServiceAccountID string     `json:"service_account_id" db:"service_account_id"` // サービスアカウントID
+	Prefix           string     `json:"prefix"             db:"prefix"`             // 識別用の先頭文字列
+	KeyHash          string     `json:"-"                  db:"key_hash"`           // キーのハッシュ値
+	Scopes           []string   `json:"scopes"             db:"scopes"`             // 付与された権限
+	ExpiresAt        *time.Time `json:"expires_at"         db:"expires_at"`         // 有効期限(nilの場合は無期限)
+	LastUsedAt       *time.Time `json:"last_used_at"       db:"last_used_at"`       // 最終使用日時
+	RevokedAt        *time.Time `json:"revoked_at"         db:"revoked_at"`         // 失効日時
+	CreatedAt        time.Time  `json:"created_at"         db:"created_at"`         // タイムスタンプ
+}
+
+// 失効済みかどうか
+func (k APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// 有効期限切れかどうか
+func (k APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// キーから一覧表示用の先頭文字列を取得
+func DisplayPrefix(key string) string {
+	if len(key) < len(APIKeyPrefix)+apiKeyDisplayLength {
+		return key
+	}
+	return key[:len(APIKeyPrefix)+apiKeyDisplayLength]
+}
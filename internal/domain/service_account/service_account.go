@@ -0,0 +1,23 @@
+package domain_service_account
+
+import (
+	"regexp"
+	"time"
+)
+
+// サービスアカウント名の形式(英小文字・数字・アンダースコア・ハイフンの3〜64文字)
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{2,63}$`)
+
+// サービスアカウント情報
+type ServiceAccount struct {
+	ID          string    `json:"id"          db:"id"`          // UUID型
+	Name        string    `json:"name"        db:"name"`        // サービスアカウント名
+	Description string    `json:"description" db:"description"` // 説明
+	CreatedAt   time.Time `json:"created_at"  db:"created_at"`  // タイムスタンプ
+	UpdatedAt   time.Time `json:"updated_at"  db:"updated_at"`  // タイムスタンプ
+}
+
+// サービスアカウント名の形式チェック
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
@@ -0,0 +1,12 @@
+package domain_todo
+
+import "time"
+
+// 指定したタイムゾーンでの日付の範囲を返す(開始を含み、終了を含まない)
+// 夏時間の切り替え日は24時間にならないため、翌日の0時を終了とする。
+func DayRange(t time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := t.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+	return start, end
+}
@@ -0,0 +1,204 @@
+package domain_todo
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Todo一覧の並び替えに使用できる項目
+const (
+	// 作成日時
+	TodoOrderCreatedAt = "created_at"
+	// 更新日時
+	TodoOrderUpdatedAt = "updated_at"
+	// タスクの説明
+	TodoOrderDescription = "description"
+	// 完了状態
+	TodoOrderCompleted = "completed"
+	// 優先度
+	TodoOrderPriority = "priority"
+	// 期限(期限のないTodoはキーセットで比較できないため、期限で絞り込む場合のみ使用する)
+	TodoOrderDueAt = "due_at"
+)
+
+// ParseTodoOrderByで指定できる項目(これ以外の項目名は受け付けない)
+var todoOrderFields = map[string]bool{
+	TodoOrderCreatedAt:   true,
+	TodoOrderUpdatedAt:   true,
+	TodoOrderDescription: true,
+	TodoOrderCompleted:   true,
+	TodoOrderPriority:    true,
+}
+
+// タグの絞り込み方法
+const (
+	// いずれかのタグが付いたTodo
+	TagMatchAny = "any"
+	// 全てのタグが付いたTodo
+	TagMatchAll = "all"
+)
+
+// 並び順の指定が不正な場合のエラー
+var ErrInvalidTodoOrder = errors.New("invalid todo order")
+
+// Todo一覧の並び順(1項目分)
+type TodoOrder struct {
+	Field      string // 項目
+	Descending bool   // 降順
+}
+
+// 既定の並び順(作成日時の昇順)
+var DefaultTodoOrder = []TodoOrder{{Field: TodoOrderCreatedAt}}
+
+// 期限の並び順(期限の昇順)
+var DueTodoOrder = []TodoOrder{{Field: TodoOrderDueAt}}
+
+// Todo一覧の絞り込み条件
+// 未指定(nil・空文字)の項目は絞り込まない。日時の範囲はFromを含み、Toを含まない。
+type TodoFilter struct {
+	UserID      string     // 所有者
+	Completed   *bool      // 完了状態
+	CreatedFrom *time.Time // 作成日時(以降)
+	CreatedTo   *time.Time // 作成日時(より前)
+	UpdatedFrom *time.Time // 更新日時(以降)
+	UpdatedTo   *time.Time // 更新日時(より前)
+	DueFrom     *time.Time // 期限(以降)
+	DueTo       *time.Time // 期限(より前)
+	Description string     // タスクの説明の部分一致
+	Priority    *int32     // 優先度
+	TagIDs      []string   // タグ(TagMatchに従い、いずれか・全てのタグが付いたTodo)
+	TagMatch    string     // タグの絞り込み方法(TagMatchAny・TagMatchAll。空の場合はTagMatchAny)
+}
+
+// 期限で絞り込むか(期限のないTodoは含まない)
+func (f TodoFilter) HasDueRange() bool {
+	return f.DueFrom != nil || f.DueTo != nil
+}
+
+// Todo一覧のページの取得条件
+// OrderByの順(同順位はIDの昇順)に並べ、Afterより後ろの行をLimit件まで取得する。
+type TodoPageQuery struct {
+	OrderBy []TodoOrder // 並び順(空の場合はDefaultTodoOrder)
+	Limit   int         // 取得件数
+	After   *TodoCursor // この位置より後ろを取得する(nilの場合は先頭から)
+}
+
+// Todo一覧のキーセット(ページの最後の行の位置)
+// 作成日時・ID以外は並び替えに使用した項目のみ設定する。
+type TodoCursor struct {
+	CreatedAt   time.Time  `json:"c"`
+	UpdatedAt   *time.Time `json:"u,omitempty"`
+	Description *string    `json:"d,omitempty"`
+	Completed   *bool      `json:"b,omitempty"`
+	DueAt       *time.Time `json:"e,omitempty"`
+	Priority    *int32     `json:"p,omitempty"`
+	ID          string     `json:"i"`
+}
+
+// 並び順の指定を解析する
+// "completed, created_at desc" のようにカンマ区切りで項目と方向(asc/desc)を指定する。
+// 空の場合はDefaultTodoOrderを返す。未知の項目・重複した項目はErrInvalidTodoOrderを返す。
+func ParseTodoOrderBy(orderBy string) ([]TodoOrder, error) {
+	orderBy = strings.TrimSpace(strings.ToLower(orderBy))
+	if orderBy == "" {
+		return DefaultTodoOrder, nil
+	}
+
+	orders := []TodoOrder{}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(orderBy, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, ErrInvalidTodoOrder
+		}
+		order := TodoOrder{Field: words[0]}
+		if !todoOrderFields[order.Field] || seen[order.Field] {
+			return nil, ErrInvalidTodoOrder
+		}
+		if len(words) == 2 {
+			switch words[1] {
+			case "asc":
+			case "desc":
+				order.Descending = true
+			default:
+				return nil, ErrInvalidTodoOrder
+			}
+		}
+		seen[order.Field] = true
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// 並び順の指定を文字列にする(ページトークンの照合に使用)
+func FormatTodoOrderBy(orders []TodoOrder) string {
+	parts := make([]string, len(orders))
+	for i, order := range orders {
+		direction := "asc"
+		if order.Descending {
+			direction = "desc"
+		}
+		parts[i] = order.Field + " " + direction
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Todoの位置からキーセットを作成
+func NewTodoCursor(todo Todo, orders []TodoOrder) TodoCursor {
+	cursor := TodoCursor{CreatedAt: todo.CreatedAt, ID: todo.ID}
+	for _, order := range orders {
+		switch order.Field {
+		case TodoOrderUpdatedAt:
+			updatedAt := todo.UpdatedAt
+			cursor.UpdatedAt = &updatedAt
+		case TodoOrderDescription:
+			description := todo.Description
+			cursor.Description = &description
+		case TodoOrderCompleted:
+			completed := todo.Completed
+			cursor.Completed = &completed
+		case TodoOrderPriority:
+			priority := todo.Priority
+			cursor.Priority = &priority
+		case TodoOrderDueAt:
+			if todo.DueAt != nil {
+				dueAt := *todo.DueAt
+				cursor.DueAt = &dueAt
+			}
+		}
+	}
+	return cursor
+}
+
+// キーセットが並び順の全ての項目の値を持っているか
+func (c TodoCursor) HasValuesFor(orders []TodoOrder) bool {
+	if c.ID == "" {
+		return false
+	}
+	for _, order := range orders {
+		switch order.Field {
+		case TodoOrderUpdatedAt:
+			if c.UpdatedAt == nil {
+				return false
+			}
+		case TodoOrderDescription:
+			if c.Description == nil {
+				return false
+			}
+		case TodoOrderCompleted:
+			if c.Completed == nil {
+				return false
+			}
+		case TodoOrderPriority:
+			if c.Priority == nil {
+				return false
+			}
+		case TodoOrderDueAt:
+			if c.DueAt == nil {
+				return false
+			}
+		}
+	}
+	return true
+}
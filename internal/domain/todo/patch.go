@@ -0,0 +1,118 @@
+package domain_todo
+
+import (
+	"errors"
+	"time"
+)
+
+// 部分更新で変更できる項目(FieldMaskのパス)
+const (
+	// タスクの説明
+	TodoFieldDescription = "description"
+	// 完了状態
+	TodoFieldCompleted = "completed"
+	// 所有者
+	TodoFieldUserID = "user_id"
+	// 期限
+	TodoFieldDueAt = "due_at"
+	// リマインダーの通知日時
+	TodoFieldRemindAt = "remind_at"
+	// 優先度
+	TodoFieldPriority = "priority"
+	// タグ(指定したタグで置き換える。タグのIDのみ使用する)
+	TodoFieldTags = "tags"
+	// 親のTodo(空の場合は最上位のTodoにする)
+	TodoFieldParentID = "parent_id"
+)
+
+// 変更できない項目(FieldMaskに指定した場合はエラー)
+var todoImmutableFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// FieldMaskのパスの別名(protoのフィールド名・JSON名)
+var todoFieldAliases = map[string]string{
+	"userId":    TodoFieldUserID,
+	"dueAt":     TodoFieldDueAt,
+	"remindAt":  TodoFieldRemindAt,
+	"parentId":  TodoFieldParentID,
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// FieldMaskが空の場合のエラー
+var ErrEmptyTodoUpdateMask = errors.New("empty todo update mask")
+
+// FieldMaskに未知の項目がある場合のエラー
+var ErrInvalidTodoUpdateMask = errors.New("invalid todo update mask")
+
+// FieldMaskに変更できない項目がある場合のエラー
+var ErrImmutableTodoField = errors.New("immutable todo field")
+
+// Todoの部分更新の内容
+// nilの項目(期限・リマインダーはUpdateXxxがfalseの項目)は変更しない。更新日時はサーバーで設定し、作成日時は変更しない。
+type TodoPatch struct {
+	Description    *string    // タスクの説明
+	Completed      *bool      // 完了状態
+	UserID         *string    // 所有者
+	UpdateDueAt    bool       // 期限を変更する
+	DueAt          *time.Time // 期限(nilの場合は期限なし)
+	UpdateRemindAt bool       // リマインダーの通知日時を変更する
+	RemindAt       *time.Time // リマインダーの通知日時(nilの場合は通知しない)
+	Priority       *int32     // 優先度
+	UpdateTags     bool       // タグを置き換える
+	TagIDs         []string   // タグ(空の場合は全て外す)
+	UpdateParent   bool       // 親のTodoを変更する
+	ParentID       *string    // 親のTodoのID(nilの場合は最上位のTodoにする)
+}
+
+// FieldMaskのパスと値から部分更新の内容を作成する
+// パスに含まれる項目のみvaluesから取り出す。
+// パスが空の場合はErrEmptyTodoUpdateMask、未知の項目はErrInvalidTodoUpdateMask、
+// 変更できない項目(id・created_at・updated_at)はErrImmutableTodoFieldを返す。
+func NewTodoPatch(paths []string, values Todo) (TodoPatch, error) {
+	if len(paths) == 0 {
+		return TodoPatch{}, ErrEmptyTodoUpdateMask
+	}
+
+	var patch TodoPatch
+	for _, path := range paths {
+		if alias, ok := todoFieldAliases[path]; ok {
+			path = alias
+		}
+		switch path {
+		case TodoFieldDescription:
+			description := values.Description
+			patch.Description = &description
+		case TodoFieldCompleted:
+			completed := values.Completed
+			patch.Completed = &completed
+		case TodoFieldUserID:
+			userID := values.UserId
+			patch.UserID = &userID
+		case TodoFieldDueAt:
+			patch.UpdateDueAt = true
+			patch.DueAt = values.DueAt
+		case TodoFieldRemindAt:
+			patch.UpdateRemindAt = true
+			patch.RemindAt = values.RemindAt
+		case TodoFieldPriority:
+			priority := values.Priority
+			patch.Priority = &priority
+		case TodoFieldTags:
+			patch.UpdateTags = true
+			patch.TagIDs = TagIDs(values.Tags)
+		case TodoFieldParentID:
+			patch.UpdateParent = true
+			patch.ParentID = values.ParentID
+		default:
+			if todoImmutableFields[path] {
+				return TodoPatch{}, ErrImmutableTodoField
+			}
+			return TodoPatch{}, ErrInvalidTodoUpdateMask
+		}
+	}
+	return patch, nil
+}
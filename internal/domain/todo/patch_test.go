@@ -0,0 +1,94 @@
+package domain_todo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTodoPatchInvalidMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr error
+	}{
+		{"空のパス", nil, ErrEmptyTodoUpdateMask},
+		{"空のスライス", []string{}, ErrEmptyTodoUpdateMask},
+		{"未知の項目", []string{"title"}, ErrInvalidTodoUpdateMask},
+		{"大文字小文字が異なる", []string{"Description"}, ErrInvalidTodoUpdateMask},
+		{"空文字の項目", []string{""}, ErrInvalidTodoUpdateMask},
+		{"有効な項目と未知の項目", []string{TodoFieldDescription, "title"}, ErrInvalidTodoUpdateMask},
+		{"id", []string{"id"}, ErrImmutableTodoField},
+		{"created_at", []string{"created_at"}, ErrImmutableTodoField},
+		{"createdAt", []string{"createdAt"}, ErrImmutableTodoField},
+		{"updated_at", []string{"updated_at"}, ErrImmutableTodoField},
+		{"updatedAt", []string{"updatedAt"}, ErrImmutableTodoField},
+		{"有効な項目とcreated_at", []string{TodoFieldCompleted, "created_at"}, ErrImmutableTodoField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := NewTodoPatch(tt.paths, Todo{Description: "buy milk"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewTodoPatch(%v) error = %v, want %v", tt.paths, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(patch, TodoPatch{}) {
+				t.Errorf("NewTodoPatch(%v) = %+v, want empty patch", tt.paths, patch)
+			}
+		})
+	}
+}
+
+func TestNewTodoPatch(t *testing.T) {
+	dueAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	parentID := "parent-1"
+	values := Todo{
+		ID:          "todo-1",
+		Description: "buy milk",
+		Completed:   true,
+		UserId:      "user-1",
+		DueAt:       &dueAt,
+		RemindAt:    &dueAt,
+		Priority:    2,
+		Tags:        []Tag{{ID: "tag-1", Name: "ignored"}, {ID: "tag-2"}},
+		ParentID:    &parentID,
+	}
+	description := values.Description
+	completed := values.Completed
+	userID := values.UserId
+	priority := values.Priority
+
+	tests := []struct {
+		name   string
+		paths  []string
+		values Todo
+		want   TodoPatch
+	}{
+		{"description", []string{TodoFieldDescription}, values, TodoPatch{Description: &description}},
+		{"completed", []string{TodoFieldCompleted}, values, TodoPatch{Completed: &completed}},
+		{"user_id", []string{TodoFieldUserID}, values, TodoPatch{UserID: &userID}},
+		{"userIdの別名", []string{"userId"}, values, TodoPatch{UserID: &userID}},
+		{"priority", []string{TodoFieldPriority}, values, TodoPatch{Priority: &priority}},
+		{"due_at", []string{TodoFieldDueAt}, values, TodoPatch{UpdateDueAt: true, DueAt: &dueAt}},
+		{"dueAtの値を省略すると期限を解除", []string{"dueAt"}, Todo{}, TodoPatch{UpdateDueAt: true}},
+		{"remind_at", []string{TodoFieldRemindAt}, values, TodoPatch{UpdateRemindAt: true, RemindAt: &dueAt}},
+		{"remindAtの値を省略すると通知を解除", []string{"remindAt"}, Todo{}, TodoPatch{UpdateRemindAt: true}},
+		{"tagsはIDのみ使用", []string{TodoFieldTags}, values, TodoPatch{UpdateTags: true, TagIDs: []string{"tag-1", "tag-2"}}},
+		{"tagsの値を省略すると全て外す", []string{TodoFieldTags}, Todo{}, TodoPatch{UpdateTags: true, TagIDs: []string{}}},
+		{"parent_id", []string{TodoFieldParentID}, values, TodoPatch{UpdateParent: true, ParentID: &parentID}},
+		{"parentIdの値を省略すると最上位にする", []string{"parentId"}, Todo{}, TodoPatch{UpdateParent: true}},
+		{"複数の項目", []string{TodoFieldDescription, TodoFieldCompleted}, values, TodoPatch{Description: &description, Completed: &completed}},
+		{"同じ項目の重複", []string{TodoFieldCompleted, TodoFieldCompleted}, values, TodoPatch{Completed: &completed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTodoPatch(tt.paths, tt.values)
+			if err != nil {
+				t.Fatalf("NewTodoPatch(%v) error = %v", tt.paths, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTodoPatch(%v) = %+v, want %+v", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
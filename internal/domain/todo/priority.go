@@ -0,0 +1,18 @@
+package domain_todo
+
+// Todoの優先度
+const (
+	// 未設定
+	PriorityNone int32 = 0
+	// 低
+	PriorityLow int32 = 1
+	// 中
+	PriorityMedium int32 = 2
+	// 高
+	PriorityHigh int32 = 3
+)
+
+// 優先度が定義された値か
+func IsValidPriority(priority int32) bool {
+	return priority >= PriorityNone && priority <= PriorityHigh
+}
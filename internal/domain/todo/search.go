@@ -0,0 +1,170 @@
+package domain_todo
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Todoの検索方式
+const (
+	// 検索文字列から自動で選択する(日本語などを含む場合はトライグラム、それ以外は全文検索)
+	TodoSearchModeAuto = "auto"
+	// 全文検索(tsvector, simple構成)
+	TodoSearchModeFullText = "fulltext"
+	// トライグラム検索(pg_trgm)
+	TodoSearchModeTrigram = "trigram"
+)
+
+// スニペットで一致箇所を囲む文字列
+const (
+	SnippetStartSel = "<mark>"
+	SnippetStopSel  = "</mark>"
+)
+
+// 全文検索(ts_headline)で一致箇所を囲む区切り文字
+// 説明に含まれる "<mark>" と区別するため制御文字を使い、FormatHeadlineでエスケープ後にSnippetStartSel・SnippetStopSelへ置き換える。
+const (
+	HeadlineStartSel = "\x02"
+	HeadlineStopSel  = "\x03"
+)
+
+// トライグラム検索のスニペットの最大文字数
+const trigramSnippetLength = 80
+
+// Todoの検索条件
+type TodoSearchQuery struct {
+	Query  string // 検索文字列
+	Mode   string // 検索方式(TodoSearchModeFullText, TodoSearchModeTrigram)
+	UserID string // 所有者(空の場合は全てのユーザー)
+	Limit  int    // 取得件数
+	Offset int    // 読み飛ばす件数
+}
+
+// Todoの検索結果
+type TodoSearchResult struct {
+	Todo    Todo
+	Rank    float64 // 関連度(大きいほど一致している)
+	Snippet string  // 一致箇所を含む説明の抜粋(HTMLエスケープ済み。一致箇所はSnippetStartSel・SnippetStopSelで囲む)
+}
+
+// 検索方式を決定する
+// autoの場合、単語の区切りがない文字(漢字・ひらがな・カタカナなど)を含めばトライグラム、それ以外は全文検索とする。
+// 不正な指定の場合はfalseを返す。
+func ResolveTodoSearchMode(mode string, query string) (string, bool) {
+	switch mode {
+	case "", TodoSearchModeAuto:
+		for _, r := range query {
+			if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+				return TodoSearchModeTrigram, true
+			}
+		}
+		return TodoSearchModeFullText, true
+	case TodoSearchModeFullText, TodoSearchModeTrigram:
+		return mode, true
+	default:
+		return "", false
+	}
+}
+
+// ts_headlineの結果をHTMLエスケープし、一致箇所の区切り文字をSnippetStartSel・SnippetStopSelに置き換える
+// 対応の取れない区切り文字は無視し、閉じられていない一致箇所は末尾で閉じる。
+func FormatHeadline(headline string) string {
+	var b strings.Builder
+	marked := false
+	for {
+		i := strings.IndexAny(headline, HeadlineStartSel+HeadlineStopSel)
+		if i < 0 {
+			b.WriteString(html.EscapeString(headline))
+			break
+		}
+		b.WriteString(html.EscapeString(headline[:i]))
+		switch {
+		case headline[i:i+1] == HeadlineStartSel && !marked:
+			b.WriteString(SnippetStartSel)
+			marked = true
+		case headline[i:i+1] == HeadlineStopSel && marked:
+			b.WriteString(SnippetStopSel)
+			marked = false
+		}
+		headline = headline[i+1:]
+	}
+	if marked {
+		b.WriteString(SnippetStopSel)
+	}
+	return b.String()
+}
+
+// 説明から検索文字列の一致箇所を含む抜粋を作成する(トライグラム検索用)
+// 大文字・小文字を区別せずに一致箇所を囲み、長い説明は最初の一致箇所の周辺を切り出す。
+// 一致箇所以外の説明はHTMLエスケープする。
+func HighlightSnippet(text string, query string) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	needle := []rune(strings.ToLower(query))
+	// 小文字化で文字数が変わる場合は位置を対応付けられないため、一致箇所を囲まない
+	if len(needle) == 0 || len(lower) != len(runes) {
+		return html.EscapeString(truncateRunes(runes, 0, trigramSnippetLength))
+	}
+
+	first := indexRunes(lower, needle, 0)
+	if first < 0 {
+		return html.EscapeString(truncateRunes(runes, 0, trigramSnippetLength))
+	}
+
+	// 最初の一致箇所が抜粋の前方に来るように切り出す
+	start := first - trigramSnippetLength/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + trigramSnippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := indexRunes(lower[:end], needle, i)
+		if j < 0 {
+			b.WriteString(html.EscapeString(string(runes[i:end])))
+			break
+		}
+		b.WriteString(html.EscapeString(string(runes[i:j])))
+		b.WriteString(SnippetStartSel)
+		b.WriteString(html.EscapeString(string(runes[j : j+len(needle)])))
+		b.WriteString(SnippetStopSel)
+		i = j + len(needle)
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// from以降でneedleが最初に現れる位置を返す(見つからない場合は-1)
+func indexRunes(haystack []rune, needle []rune, from int) int {
+	for i := from; i+len(needle) <= len(haystack); i++ {
+		matched := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return i
+		}
+	}
+	return -1
+}
+
+// startから最大length文字を切り出す(切り詰めた場合は末尾に省略記号を付ける)
+func truncateRunes(runes []rune, start int, length int) string {
+	if len(runes)-start <= length {
+		return string(runes[start:])
+	}
+	return string(runes[start:start+length]) + "…"
+}
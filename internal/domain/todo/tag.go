@@ -0,0 +1,46 @@
+package domain_todo
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// タグ名の最大文字数
+const MaxTagNameLength = 50
+
+// 1つのTodoに付けられるタグの最大数
+const MaxTagsPerTodo = 20
+
+// タグの色の形式(#RRGGBB)
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// タグ情報
+// タグはユーザーごとに管理し、Todoには所有者のタグのみ付けられる。
+type Tag struct {
+	ID        string    `json:"id"         db:"id"`         // UUID型
+	UserID    string    `json:"user_id"    db:"user_id"`    // 所有者のユーザーID
+	Name      string    `json:"name"       db:"name"`       // タグ名(同じユーザーで大文字・小文字を区別せず一意)
+	Color     string    `json:"color"      db:"color"`      // 表示色(#RRGGBB。未設定の場合は空)
+	CreatedAt time.Time `json:"created_at" db:"created_at"` // タイムスタンプ
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"` // タイムスタンプ
+}
+
+// タグ名を正規化する(前後の空白を除去)
+func NormalizeTagName(name string) string {
+	return strings.TrimSpace(name)
+}
+
+// タグの色が正しい形式か(空は未設定として許可する)
+func IsValidTagColor(color string) bool {
+	return color == "" || tagColorPattern.MatchString(color)
+}
+
+// タグのIDの一覧を返す
+func TagIDs(tags []Tag) []string {
+	ids := make([]string, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	return ids
+}
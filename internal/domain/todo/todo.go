@@ -4,10 +4,16 @@ import "time"
 
 // Todo情報
 type Todo struct {
-	ID          string    `json:"id"          db:"id"`          // UUID型
-	Description string    `json:"description" db:"description"` // タスクの説明
-	Completed   bool      `json:"completed"   db:"completed"`   // タスクが完了しているかどうか
-	UserId      string    `json:"user_id"     db:"user_id"`     // ユーザーID
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`   // タイムスタンプ
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`   // タイムスタンプ
+	ID          string     `json:"id"          db:"id"`          // UUID型
+	Description string     `json:"description" db:"description"` // タスクの説明
+	Completed   bool       `json:"completed"   db:"completed"`   // タスクが完了しているかどうか
+	UserId      string     `json:"user_id"     db:"user_id"`     // ユーザーID
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`   // タイムスタンプ
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`   // タイムスタンプ
+	Version     int64      `json:"version"    db:"version"`      // バージョン(更新のたびに1ずつ増える)
+	DueAt       *time.Time `json:"due_at"     db:"due_at"`       // 期限(未設定の場合はnil)
+	RemindAt    *time.Time `json:"remind_at"  db:"remind_at"`    // リマインダーの通知日時(未設定の場合はnil)
+	Priority    int32      `json:"priority"   db:"priority"`     // 優先度(PriorityNone〜PriorityHigh)
+	Tags        []Tag      `json:"tags"       db:"-"`            // タグ(作成・更新時はIDのみ使用する)
+	ParentID    *string    `json:"parent_id"  db:"parent_id"`    // 親のTodoのID(最上位のTodoの場合はnil)
 }
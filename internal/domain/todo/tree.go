@@ -0,0 +1,180 @@
+package domain_todo
+
+// Todoの階層の最大の深さ(最上位のTodoを1とする)
+const MaxTodoDepth = 5
+
+// サブタスクの完了に合わせた親のTodoの完了状態の扱い
+type ParentCompletionRule string
+
+const (
+	// 全てのサブタスクが完了したら親を完了にし、未完了のサブタスクがあれば親を未完了に戻す
+	ParentCompletionAuto ParentCompletionRule = "auto"
+	// 未完了のサブタスクがある親は完了にできない
+	ParentCompletionBlock ParentCompletionRule = "block"
+)
+
+// 文字列から親のTodoの完了状態の扱いを取得
+func ParseParentCompletionRule(s string) (ParentCompletionRule, bool) {
+	switch r := ParentCompletionRule(s); r {
+	case ParentCompletionAuto, ParentCompletionBlock:
+		return r, true
+	default:
+		return "", false
+	}
+}
+
+// Todoの階層上の位置
+type TodoPlacement struct {
+	ID          string   // Todo
+	AncestorIDs []string // 親から順にたどった祖先のID(自身に戻った場合はそこで打ち切る)
+	Height      int      // 自身を1とした子孫の階層の数
+}
+
+// 親に自身・自身の子孫を指定しているか
+func (p TodoPlacement) HasCycle() bool {
+	for _, id := range p.AncestorIDs {
+		if id == p.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// 最上位のTodoを1とした、最も深いサブタスクの深さ
+func (p TodoPlacement) Depth() int {
+	return len(p.AncestorIDs) + p.Height
+}
+
+// 深さがMaxTodoDepthを超えるか
+func (p TodoPlacement) TooDeep() bool {
+	return p.Depth() > MaxTodoDepth
+}
+
+// Todoの階層に関する状態
+type TodoTreeState struct {
+	UserID    string  // 所有者
+	ParentID  *string // 親のTodoのID
+	Completed bool    // 完了状態
+}
+
+// Todoの書き込みによる階層の変更
+type TodoTreeChange struct {
+	Before *TodoTreeState // 変更前(作成の場合はnil)
+	After  TodoTreeState  // 変更後
+}
+
+// 親を変更したか(作成の場合は変更したとみなす)
+func (c TodoTreeChange) ParentChanged() bool {
+	return c.Before == nil || !sameTodoID(c.Before.ParentID, c.After.ParentID)
+}
+
+// 完了状態を変更したか(作成の場合は変更したとみなす)
+func (c TodoTreeChange) CompletedChanged() bool {
+	return c.Before == nil || c.Before.Completed != c.After.Completed
+}
+
+// 循環・深さ・サブタスクの所有者を検証する必要があるか
+// 親・所有者を変更した場合のみ検証する。親のない新しいTodoは検証不要。
+func (c TodoTreeChange) NeedsTreeCheck() bool {
+	if c.Before == nil {
+		return c.After.ParentID != nil
+	}
+	return c.ParentChanged() || c.Before.UserID != c.After.UserID
+}
+
+// 親が完了済みでないことを確認する必要があるか(ParentCompletionBlock)
+// 完了済みの親に未完了のサブタスクを追加・付け替え・再開できない。
+func (r ParentCompletionRule) ChecksCompletedParent(c TodoTreeChange) bool {
+	return r == ParentCompletionBlock && c.After.ParentID != nil && !c.After.Completed &&
+		(c.ParentChanged() || c.CompletedChanged())
+}
+
+// 未完了のサブタスクがないことを確認する必要があるか(ParentCompletionBlock)
+// 未完了のサブタスクがあるTodoは完了にできない。
+func (r ParentCompletionRule) ChecksOpenSubtasks(c TodoTreeChange) bool {
+	return r == ParentCompletionBlock && c.Before != nil && c.After.Completed && c.CompletedChanged()
+}
+
+// 自身の完了状態をサブタスクに合わせる必要があるか(ParentCompletionAuto)
+// サブタスクのあるTodo自身の完了状態を変更した場合は、サブタスクに合わせて戻す。
+func (r ParentCompletionRule) SyncsSelf(c TodoTreeChange) bool {
+	return r == ParentCompletionAuto && c.Before != nil && c.CompletedChanged()
+}
+
+// 完了状態をサブタスクに合わせる親のTodoのID(ParentCompletionAuto)
+// 親(付け替えた場合は元の親も)を返す。親のない場合は含めない。
+func (r ParentCompletionRule) SyncedParents(c TodoTreeChange) []string {
+	if r != ParentCompletionAuto {
+		return nil
+	}
+	var ids []string
+	if c.After.ParentID != nil && (c.ParentChanged() || c.CompletedChanged()) {
+		ids = append(ids, *c.After.ParentID)
+	}
+	if c.Before != nil && c.Before.ParentID != nil && c.ParentChanged() {
+		ids = append(ids, *c.Before.ParentID)
+	}
+	return ids
+}
+
+// サブタスクに合わせた親のTodoの完了状態(ParentCompletionAuto)
+// 全てのサブタスクが完了していれば完了、未完了のサブタスクがあれば未完了。サブタスクがない場合は現在の状態のまま。
+func SyncedCompletion(current bool, children []bool) bool {
+	if len(children) == 0 {
+		return current
+	}
+	return !HasOpenSubtask(children)
+}
+
+// 未完了のサブタスクがあるか
+func HasOpenSubtask(children []bool) bool {
+	for _, completed := range children {
+		if !completed {
+			return true
+		}
+	}
+	return false
+}
+
+// 2つのTodoのIDが同じか(どちらもnilの場合も同じとする)
+func sameTodoID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// Todoとそのサブタスクの階層
+type TodoTree struct {
+	Todo     Todo       // Todo
+	Children []TodoTree // サブタスク(作成日時の昇順)
+}
+
+// Todoの一覧から階層を組み立てる
+// todosにはrootIDのTodoとその子孫が含まれていること(子は一覧の順に並べる)。rootIDのTodoがない場合はfalseを返す。
+func BuildTodoTree(rootID string, todos []Todo) (TodoTree, bool) {
+	children := map[string][]Todo{}
+	var root *Todo
+	for i := range todos {
+		if todos[i].ID == rootID {
+			root = &todos[i]
+			continue
+		}
+		if todos[i].ParentID != nil {
+			children[*todos[i].ParentID] = append(children[*todos[i].ParentID], todos[i])
+		}
+	}
+	if root == nil {
+		return TodoTree{}, false
+	}
+
+	var build func(todo Todo) TodoTree
+	build = func(todo Todo) TodoTree {
+		tree := TodoTree{Todo: todo, Children: []TodoTree{}}
+		for _, child := range children[todo.ID] {
+			tree.Children = append(tree.Children, build(child))
+		}
+		return tree
+	}
+	return build(*root), true
+}
@@ -0,0 +1,206 @@
+package domain_todo
+
+import (
+	"reflect"
+	"testing"
+)
+
+// テスト用のIDのポインタ
+func todoIDPtr(id string) *string {
+	return &id
+}
+
+func TestTodoPlacementDepth(t *testing.T) {
+	tests := []struct {
+		name      string
+		placement TodoPlacement
+		wantDepth int
+		wantDeep  bool
+	}{
+		{"最上位のTodo", TodoPlacement{ID: "a", Height: 1}, 1, false},
+		{"深さ5(祖先4)", TodoPlacement{ID: "e", AncestorIDs: []string{"d", "c", "b", "a"}, Height: 1}, 5, false},
+		{"深さ6(祖先5)", TodoPlacement{ID: "f", AncestorIDs: []string{"e", "d", "c", "b", "a"}, Height: 1}, 6, true},
+		{"子孫を含めて深さ5", TodoPlacement{ID: "c", AncestorIDs: []string{"b", "a"}, Height: 3}, 5, false},
+		{"子孫を含めて深さ6", TodoPlacement{ID: "c", AncestorIDs: []string{"b", "a"}, Height: 4}, 6, true},
+		{"最上位に深さ5の階層を移動", TodoPlacement{ID: "a", Height: 5}, 5, false},
+		{"深さ5の階層を最上位の下に移動", TodoPlacement{ID: "a", AncestorIDs: []string{"root"}, Height: 5}, 6, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.placement.Depth(); got != tt.wantDepth {
+				t.Errorf("Depth() = %d, want %d", got, tt.wantDepth)
+			}
+			if got := tt.placement.TooDeep(); got != tt.wantDeep {
+				t.Errorf("TooDeep() = %v, want %v", got, tt.wantDeep)
+			}
+		})
+	}
+}
+
+func TestTodoPlacementHasCycle(t *testing.T) {
+	tests := []struct {
+		name      string
+		placement TodoPlacement
+		want      bool
+	}{
+		{"親なし", TodoPlacement{ID: "a", Height: 1}, false},
+		{"祖先に自身を含まない", TodoPlacement{ID: "c", AncestorIDs: []string{"b", "a"}, Height: 1}, false},
+		{"自身を親にする", TodoPlacement{ID: "a", AncestorIDs: []string{"a"}, Height: 1}, true},
+		{"子を親にする", TodoPlacement{ID: "a", AncestorIDs: []string{"b", "a"}, Height: 2}, true},
+		{"子孫を親にする", TodoPlacement{ID: "a", AncestorIDs: []string{"d", "c", "b", "a"}, Height: 4}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.placement.HasCycle(); got != tt.want {
+				t.Errorf("HasCycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodoTreeChangeNeedsTreeCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		change TodoTreeChange
+		want   bool
+	}{
+		{"親のない新しいTodo", TodoTreeChange{After: TodoTreeState{UserID: "u1"}}, false},
+		{"サブタスクの作成", TodoTreeChange{After: TodoTreeState{UserID: "u1", ParentID: todoIDPtr("p")}}, true},
+		{"完了状態のみ変更", TodoTreeChange{
+			Before: &TodoTreeState{UserID: "u1", ParentID: todoIDPtr("p")},
+			After:  TodoTreeState{UserID: "u1", ParentID: todoIDPtr("p"), Completed: true},
+		}, false},
+		{"親の付け替え", TodoTreeChange{
+			Before: &TodoTreeState{UserID: "u1", ParentID: todoIDPtr("p")},
+			After:  TodoTreeState{UserID: "u1", ParentID: todoIDPtr("q")},
+		}, true},
+		{"最上位にする", TodoTreeChange{
+			Before: &TodoTreeState{UserID: "u1", ParentID: todoIDPtr("p")},
+			After:  TodoTreeState{UserID: "u1"},
+		}, true},
+		{"所有者の変更", TodoTreeChange{
+			Before: &TodoTreeState{UserID: "u1"},
+			After:  TodoTreeState{UserID: "u2"},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.change.NeedsTreeCheck(); got != tt.want {
+				t.Errorf("NeedsTreeCheck() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParentCompletionRule(t *testing.T) {
+	type want struct {
+		checksCompletedParent bool
+		checksOpenSubtasks    bool
+		syncsSelf             bool
+		syncedParents         []string
+	}
+	tests := []struct {
+		name   string
+		rule   ParentCompletionRule
+		change TodoTreeChange
+		want   want
+	}{
+		{"block: 未完了のサブタスクを作成", ParentCompletionBlock,
+			TodoTreeChange{After: TodoTreeState{ParentID: todoIDPtr("p")}},
+			want{checksCompletedParent: true}},
+		{"auto: 未完了のサブタスクを作成", ParentCompletionAuto,
+			TodoTreeChange{After: TodoTreeState{ParentID: todoIDPtr("p")}},
+			want{syncedParents: []string{"p"}}},
+		{"block: 完了済みのサブタスクを作成", ParentCompletionBlock,
+			TodoTreeChange{After: TodoTreeState{ParentID: todoIDPtr("p"), Completed: true}},
+			want{}},
+		{"block: サブタスクを再開", ParentCompletionBlock,
+			TodoTreeChange{Before: &TodoTreeState{ParentID: todoIDPtr("p"), Completed: true}, After: TodoTreeState{ParentID: todoIDPtr("p")}},
+			want{checksCompletedParent: true}},
+		{"auto: サブタスクを再開", ParentCompletionAuto,
+			TodoTreeChange{Before: &TodoTreeState{ParentID: todoIDPtr("p"), Completed: true}, After: TodoTreeState{ParentID: todoIDPtr("p")}},
+			want{syncsSelf: true, syncedParents: []string{"p"}}},
+		{"block: 未完了のサブタスクの説明のみ変更", ParentCompletionBlock,
+			TodoTreeChange{Before: &TodoTreeState{ParentID: todoIDPtr("p")}, After: TodoTreeState{ParentID: todoIDPtr("p")}},
+			want{}},
+		{"auto: 未完了のサブタスクの説明のみ変更", ParentCompletionAuto,
+			TodoTreeChange{Before: &TodoTreeState{ParentID: todoIDPtr("p")}, After: TodoTreeState{ParentID: todoIDPtr("p")}},
+			want{}},
+		{"block: Todoを完了", ParentCompletionBlock,
+			TodoTreeChange{Before: &TodoTreeState{}, After: TodoTreeState{Completed: true}},
+			want{checksOpenSubtasks: true}},
+		{"auto: Todoを完了", ParentCompletionAuto,
+			TodoTreeChange{Before: &TodoTreeState{}, After: TodoTreeState{Completed: true}},
+			want{syncsSelf: true}},
+		{"block: 未完了のサブタスクを付け替え", ParentCompletionBlock,
+			TodoTreeChange{Before: &TodoTreeState{ParentID: todoIDPtr("p")}, After: TodoTreeState{ParentID: todoIDPtr("q")}},
+			want{checksCompletedParent: true}},
+		{"auto: 未完了のサブタスクを付け替え", ParentCompletionAuto,
+			TodoTreeChange{Before: &TodoTreeState{ParentID: todoIDPtr("p")}, After: TodoTreeState{ParentID: todoIDPtr("q")}},
+			want{syncedParents: []string{"q", "p"}}},
+		{"auto: 最上位にする", ParentCompletionAuto,
+			TodoTreeChange{Before: &TodoTreeState{ParentID: todoIDPtr("p")}, After: TodoTreeState{}},
+			want{syncedParents: []string{"p"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.ChecksCompletedParent(tt.change); got != tt.want.checksCompletedParent {
+				t.Errorf("ChecksCompletedParent() = %v, want %v", got, tt.want.checksCompletedParent)
+			}
+			if got := tt.rule.ChecksOpenSubtasks(tt.change); got != tt.want.checksOpenSubtasks {
+				t.Errorf("ChecksOpenSubtasks() = %v, want %v", got, tt.want.checksOpenSubtasks)
+			}
+			if got := tt.rule.SyncsSelf(tt.change); got != tt.want.syncsSelf {
+				t.Errorf("SyncsSelf() = %v, want %v", got, tt.want.syncsSelf)
+			}
+			if got := tt.rule.SyncedParents(tt.change); !reflect.DeepEqual(got, tt.want.syncedParents) {
+				t.Errorf("SyncedParents() = %v, want %v", got, tt.want.syncedParents)
+			}
+		})
+	}
+}
+
+func TestSyncedCompletion(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  bool
+		children []bool
+		want     bool
+	}{
+		{"サブタスクなし(未完了のまま)", false, nil, false},
+		{"サブタスクなし(完了のまま)", true, nil, true},
+		{"全て完了", false, []bool{true, true}, true},
+		{"未完了あり", true, []bool{true, false}, false},
+		{"全て未完了", true, []bool{false}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SyncedCompletion(tt.current, tt.children); got != tt.want {
+				t.Errorf("SyncedCompletion(%v, %v) = %v, want %v", tt.current, tt.children, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildTodoTree(t *testing.T) {
+	todos := []Todo{
+		{ID: "a"},
+		{ID: "b", ParentID: todoIDPtr("a")},
+		{ID: "c", ParentID: todoIDPtr("b")},
+		{ID: "d", ParentID: todoIDPtr("a")},
+	}
+	tree, ok := BuildTodoTree("a", todos)
+	if !ok {
+		t.Fatal("BuildTodoTree() ok = false, want true")
+	}
+	if len(tree.Children) != 2 || tree.Children[0].Todo.ID != "b" || tree.Children[1].Todo.ID != "d" {
+		t.Fatalf("BuildTodoTree() children = %+v, want b, d", tree.Children)
+	}
+	if len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].Todo.ID != "c" {
+		t.Errorf("BuildTodoTree() grandchildren = %+v, want c", tree.Children[0].Children)
+	}
+
+	if _, ok := BuildTodoTree("missing", todos); ok {
+		t.Error("BuildTodoTree(missing) ok = true, want false")
+	}
+}
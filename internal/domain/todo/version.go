@@ -0,0 +1,12 @@
+package domain_todo
+
+// Todoのバージョンが一致しない場合のエラー
+// 他のクライアントが先に更新・削除の対象を更新した場合に返す。クライアントがマージできるよう、サーバーの現在のTodoを保持する。
+type VersionConflictError struct {
+	Current Todo // サーバーの現在のTodo
+}
+
+// エラーメッセージ(ハンドラーはこの文字列でステータスを判定する)
+func (e *VersionConflictError) Error() string {
+	return "version conflict"
+}
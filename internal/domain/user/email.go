@@ -0,0 +1,19 @@
+package domain_user
+
+import (
+	"regexp"
+	"strings"
+)
+
+// メールアドレスの形式
+var emailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// メールアドレスの形式チェック
+func IsValidEmail(email string) bool {
+	return emailPattern.MatchString(email)
+}
+
+// メールアドレスの正規化(前後の空白除去・小文字化)
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
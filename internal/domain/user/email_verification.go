@@ -0,0 +1,37 @@
+package domain_user
+
+// メールアドレスの確認状態
+// 未確認 → 確認メール送信済み → 確認済み の順に遷移する。
+// メールアドレスを変更した場合は未確認に戻る。
+type EmailVerificationStatus string
+
+const (
+	// 未確認
+	EmailUnverified EmailVerificationStatus = "unverified"
+	// 確認メール送信済み
+	EmailVerificationSent EmailVerificationStatus = "verification_sent"
+	// 確認済み
+	EmailVerified EmailVerificationStatus = "verified"
+)
+
+// 状態ごとの遷移可能な状態
+var emailVerificationTransitions = map[EmailVerificationStatus][]EmailVerificationStatus{
+	EmailUnverified:       {EmailVerificationSent},
+	EmailVerificationSent: {EmailVerificationSent, EmailVerified, EmailUnverified},
+	EmailVerified:         {EmailUnverified},
+}
+
+// 指定した状態に遷移できるか
+func (s EmailVerificationStatus) CanTransitionTo(next EmailVerificationStatus) bool {
+	for _, allowed := range emailVerificationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// 確認済みかどうか
+func (s EmailVerificationStatus) IsVerified() bool {
+	return s == EmailVerified
+}
@@ -0,0 +1,38 @@
+package domain_user
+
+import "time"
+
+// ユーザー一覧の並び順
+const (
+	// 作成日時の昇順
+	UserOrderCreatedAtAsc = "created_at asc"
+	// 作成日時の降順
+	UserOrderCreatedAtDesc = "created_at desc"
+)
+
+// ユーザー一覧の取得条件
+type ListUsersQuery struct {
+	Search     string      // ユーザー名・メールアドレスの部分一致(空の場合は全件)
+	Descending bool        // 作成日時の降順で取得する
+	Limit      int         // 取得件数
+	After      *UserCursor // この位置より後ろを取得する(nilの場合は先頭から)
+}
+
+// ユーザー一覧のキーセット(ページの最後の行の位置)
+type UserCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+// 並び順の指定を正規化する
+// 空の場合は作成日時の降順とし、不正な指定の場合はfalseを返す。
+func NormalizeUserOrder(orderBy string) (string, bool) {
+	switch orderBy {
+	case "", UserOrderCreatedAtDesc:
+		return UserOrderCreatedAtDesc, true
+	case "created_at", UserOrderCreatedAtAsc:
+		return UserOrderCreatedAtAsc, true
+	default:
+		return "", false
+	}
+}
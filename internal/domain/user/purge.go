@@ -0,0 +1,29 @@
+package domain_user
+
+// ユーザーのパージ時のTodoの扱い
+type TodoCascadePolicy string
+
+const (
+	// Todoを削除する
+	TodoCascadeDelete TodoCascadePolicy = "delete"
+	// Todoを別のユーザーに付け替える
+	TodoCascadeReassign TodoCascadePolicy = "reassign"
+	// Todoをarchived_todosに移して保管する
+	TodoCascadeArchive TodoCascadePolicy = "archive"
+)
+
+// 文字列からTodoの扱いを取得
+func ParseTodoCascadePolicy(s string) (TodoCascadePolicy, bool) {
+	switch p := TodoCascadePolicy(s); p {
+	case TodoCascadeDelete, TodoCascadeReassign, TodoCascadeArchive:
+		return p, true
+	default:
+		return "", false
+	}
+}
+
+// パージの結果
+type PurgeResult struct {
+	// 削除・付け替え・保管したTodoの件数
+	TodosAffected int64
+}
@@ -0,0 +1,11 @@
+package domain_user
+
+import "regexp"
+
+// ユーザー名の形式(英数字・アンダースコア・ドット・ハイフンの3〜32文字)
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,32}$`)
+
+// ユーザー名の形式チェック
+func IsValidUsername(username string) bool {
+	return usernamePattern.MatchString(username)
+}
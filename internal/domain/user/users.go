@@ -4,10 +4,19 @@ import "time"
 
 // ユーザー情報
 type Users struct {
-	ID        string    `json:"id"         db:"id"`         // UUID型
-	Username  string    `json:"username"   db:"username"`   // ユーザー名
-	Email     string    `json:"email"      db:"email"`      // メールアドレス
-	Password  string    `json:"password"   db:"password"`   // パスワード
-	CreatedAt time.Time `json:"created_at" db:"created_at"` // タイムスタンプ
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"` // タイムスタンプ
+	ID                      string                  `json:"id"         db:"id"`                                         // UUID型
+	Username                string                  `json:"username"   db:"username"`                                   // ユーザー名
+	Email                   string                  `json:"email"      db:"email"`                                      // メールアドレス
+	Password                string                  `json:"password"   db:"password"`                                   // パスワード
+	CreatedAt               time.Time               `json:"created_at" db:"created_at"`                                 // タイムスタンプ
+	UpdatedAt               time.Time               `json:"updated_at" db:"updated_at"`                                 // タイムスタンプ
+	EmailVerificationStatus EmailVerificationStatus `json:"email_verification_status"  db:"email_verification_status"`  // メールアドレスの確認状態
+	EmailVerificationSentAt *time.Time              `json:"email_verification_sent_at" db:"email_verification_sent_at"` // 確認メールの送信日時
+	EmailVerifiedAt         *time.Time              `json:"email_verified_at"          db:"email_verified_at"`          // 確認日時
+	DeactivatedAt           *time.Time              `json:"deactivated_at"             db:"deactivated_at"`             // 無効化日時
+}
+
+// 無効化(論理削除)されているかどうか
+func (u Users) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
 }
@@ -0,0 +1,30 @@
+package infrastructure_audit
+
+import (
+	domain_audit "backend/internal/domain/audit"
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 監査ログを記録
+// 操作と同じトランザクションで記録し、操作が取り消された場合は監査ログも残らないようにする。
+func WriteAuditLog(ctx context.Context, tx pgx.Tx, log domain_audit.AuditLog) error {
+	query := `
+        INSERT INTO audit_logs (actor_id, action, target_type, target_id, details)
+        VALUES ($1, $2, $3, $4, $5::jsonb)
+    `
+
+	details := log.Details
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, log.ActorID, log.Action, log.TargetType, log.TargetID, string(encoded))
+	return err
+}
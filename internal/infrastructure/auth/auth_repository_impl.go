@@ -5,6 +5,10 @@ import (
 	pkg_logger "backend/internal/pkg/logger"
 	pkg_supabase "backend/internal/pkg/supabase"
 	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
 )
 
 // 認証リポジトリの実装(Impl)
@@ -21,26 +25,204 @@ func NewAuthRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient)
 	}
 }
 
-// ログイン
-func (r *AuthRepositoryImpl) Login(email string, password string) (string, error) {
-	r.Logger.InfoLog.Printf("Logging in with email: %s and password: %s", email, password)
+// メールアドレスからユーザーを取得
+func (r *AuthRepositoryImpl) GetUserByEmail(email string) (domain_user.Users, error) {
+	r.Logger.InfoLog.Printf("Fetching user by email: %s", email)
 
 	query := `
-        SELECT id, username, email
+        SELECT id, username, email, password, email_verification_status, email_verification_sent_at, email_verified_at, deactivated_at
         FROM users
-        WHERE email = $1 AND password = $2
+        WHERE lower(email) = lower($1)
     `
 
 	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	row := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, email, password)
+	row := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, email)
 
 	user := domain_user.Users{}
-	err := row.Scan(&user.ID, &user.Username, &user.Email)
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password,
+		&user.EmailVerificationStatus, &user.EmailVerificationSentAt, &user.EmailVerifiedAt, &user.DeactivatedAt)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Println("User not found")
+			return domain_user.Users{}, repository_auth.ErrUserNotFound
+		}
 		r.Logger.ErrorLog.Printf("Failed to fetch user: %v", err)
-		return "", err
+		return domain_user.Users{}, err
+	}
+
+	r.Logger.InfoLog.Println("Fetched user successfully. 1 user found")
+	return user, nil
+}
+
+// IDからユーザーを取得
+func (r *AuthRepositoryImpl) GetUserByID(id string) (domain_user.Users, error) {
+	r.Logger.InfoLog.Printf("Fetching user by id: %s", id)
+
+	query := `
+        SELECT id, username, email, password, email_verification_status, email_verification_sent_at, email_verified_at, deactivated_at
+        FROM users
+        WHERE id = $1
+    `
+
+	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
+	row := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, id)
+
+	user := domain_user.Users{}
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password,
+		&user.EmailVerificationStatus, &user.EmailVerificationSentAt, &user.EmailVerifiedAt, &user.DeactivatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Println("User not found")
+			return domain_user.Users{}, repository_auth.ErrUserNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch user: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	r.Logger.InfoLog.Println("Fetched user successfully. 1 user found")
+	return user, nil
+}
+
+// パスワードを更新
+func (r *AuthRepositoryImpl) UpdatePassword(id string, hashedPassword string) error {
+	r.Logger.InfoLog.Printf("Updating password for user: %s", id)
+
+	query := `
+        UPDATE users
+        SET password = $1, updated_at = now()
+        WHERE id = $2
+    `
+
+	// Supabaseからクエリを実行し、パスワードを更新
+	_, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, hashedPassword, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to update password: %v", err)
+		return err
+	}
+
+	r.Logger.InfoLog.Println("Password updated successfully")
+	return nil
+}
+
+// パスワードを変更し、現在のセッション以外のセッション・リフレッシュトークンを失効
+func (r *AuthRepositoryImpl) ChangePassword(id string, hashedPassword string, currentSessionID string) error {
+	r.Logger.InfoLog.Printf("Changing password for user: %s", id)
+
+	updatePasswordQuery := `
+        UPDATE users
+        SET password = $1, updated_at = now()
+        WHERE id = $2
+    `
+	revokeSessionsQuery := `
+        UPDATE sessions
+        SET revoked_at = now()
+        WHERE user_id = $1 AND revoked_at IS NULL AND ($2 = '' OR id::text <> $2)
+    `
+	revokeTokensQuery := `
+        UPDATE refresh_tokens
+        SET revoked_at = now()
+        WHERE user_id = $1 AND revoked_at IS NULL AND ($2 = '' OR session_id IS NULL OR session_id::text <> $2)
+    `
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// パスワードを更新
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, updatePasswordQuery, hashedPassword, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to update password: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Println("User not found")
+		err = repository_auth.ErrUserNotFound
+		return err
+	}
+
+	// 他のセッションを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeSessionsQuery, id, currentSessionID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke sessions: %v", err)
+		return err
+	}
+
+	// 他のセッションのリフレッシュトークンを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeTokensQuery, id, currentSessionID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke refresh tokens: %v", err)
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Println("Password changed successfully")
+	return nil
+}
+
+// 確認メールの送信を記録(未確認・送信済みの場合のみ)
+func (r *AuthRepositoryImpl) MarkEmailVerificationSent(id string, sentAt time.Time) error {
+	r.Logger.InfoLog.Printf("Marking email verification sent for user: %s", id)
+
+	query := `
+        UPDATE users
+        SET email_verification_status = 'verification_sent', email_verification_sent_at = $2, updated_at = now()
+        WHERE id = $1 AND email_verification_status IN ('unverified', 'verification_sent')
+    `
+
+	// Supabaseからクエリを実行し、確認状態を更新
+	tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, id, sentAt)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to update email verification status: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Println("User not found or email already verified")
+		return repository_auth.ErrEmailVerificationConflict
+	}
+
+	r.Logger.InfoLog.Println("Email verification sent recorded")
+	return nil
+}
+
+// メールアドレスを確認済みにする(送信済みかつメールアドレスが一致する場合のみ)
+func (r *AuthRepositoryImpl) MarkEmailVerified(id string, email string) error {
+	r.Logger.InfoLog.Printf("Marking email verified for user: %s", id)
+
+	query := `
+        UPDATE users
+        SET email_verification_status = 'verified', email_verified_at = now(), updated_at = now()
+        WHERE id = $1 AND lower(email) = lower($2) AND email_verification_status = 'verification_sent'
+    `
+
+	// Supabaseからクエリを実行し、確認状態を更新
+	tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, id, email)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to update email verification status: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Println("User not found or email verification state changed")
+		return repository_auth.ErrEmailVerificationConflict
 	}
 
-	r.Logger.InfoLog.Println("Login successful. 1 user found")
-	return user.ID, nil
+	r.Logger.InfoLog.Println("Email verified successfully")
+	return nil
 }
@@ -0,0 +1,139 @@
+package infrastructure_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_auth "backend/internal/repository/auth"
+	"time"
+)
+
+// ログイン試行リポジトリの実装(Impl)
+type LoginAttemptRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// ログイン試行リポジトリのインスタンス化
+func NewLoginAttemptRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_auth.ILoginAttemptRepository {
+	return &LoginAttemptRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// キーを指定してログイン試行の記録を取得
+func (r *LoginAttemptRepositoryImpl) GetLoginAttempts(keys []string) ([]domain_auth.LoginAttempt, error) {
+	r.Logger.InfoLog.Println("GetLoginAttempts called")
+
+	query := `
+		SELECT attempt_key, failure_count, last_failed_at, locked_until
+		FROM login_attempts
+		WHERE attempt_key = ANY($1)
+	`
+
+	// Supabaseからクエリを実行し、条件に一致するログイン試行を取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, keys)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch login attempts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []domain_auth.LoginAttempt{}
+	for rows.Next() {
+		var attempt domain_auth.LoginAttempt
+		err = rows.Scan(
+			&attempt.Key,
+			&attempt.FailureCount,
+			&attempt.LastFailedAt,
+			&attempt.LockedUntil,
+		)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan login attempt: %v", err)
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err = rows.Err(); err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch login attempts: %v", err)
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// ログイン失敗を記録
+// 最終失敗からresetAfter以上経過している場合は失敗回数を1からやり直す。
+func (r *LoginAttemptRepositoryImpl) RecordLoginFailure(key string, resetAfter time.Duration) (domain_auth.LoginAttempt, error) {
+	r.Logger.InfoLog.Println("RecordLoginFailure called")
+
+	query := `
+		INSERT INTO login_attempts (attempt_key, failure_count, last_failed_at)
+		VALUES ($1, 1, now())
+		ON CONFLICT (attempt_key) DO UPDATE
+		SET failure_count = CASE
+				WHEN login_attempts.last_failed_at < now() - make_interval(secs => $2) THEN 1
+				ELSE login_attempts.failure_count + 1
+			END,
+			last_failed_at = now()
+		RETURNING attempt_key, failure_count, last_failed_at, locked_until
+	`
+
+	// Supabaseからクエリを実行し、失敗回数を加算
+	var attempt domain_auth.LoginAttempt
+	err := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, key, resetAfter.Seconds()).
+		Scan(&attempt.Key,
+			&attempt.FailureCount,
+			&attempt.LastFailedAt,
+			&attempt.LockedUntil,
+		)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to record login failure: %v", err)
+		return domain_auth.LoginAttempt{}, err
+	}
+
+	r.Logger.InfoLog.Printf("Recorded login failure: %s (%d)", key, attempt.FailureCount)
+	return attempt, nil
+}
+
+// ロック解除日時を設定
+func (r *LoginAttemptRepositoryImpl) LockLoginAttempt(key string, lockedUntil time.Time) error {
+	r.Logger.InfoLog.Println("LockLoginAttempt called")
+
+	query := `
+		UPDATE login_attempts
+		SET locked_until = $1
+		WHERE attempt_key = $2
+	`
+
+	// Supabaseからクエリを実行し、ロック解除日時を更新
+	_, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, lockedUntil, key)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to lock login attempt: %v", err)
+		return err
+	}
+
+	r.Logger.InfoLog.Printf("Locked %s until %v", key, lockedUntil)
+	return nil
+}
+
+// ログイン試行の記録を削除
+func (r *LoginAttemptRepositoryImpl) ResetLoginAttempts(keys []string) error {
+	r.Logger.InfoLog.Println("ResetLoginAttempts called")
+
+	query := `
+		DELETE FROM login_attempts
+		WHERE attempt_key = ANY($1)
+	`
+
+	// Supabaseからクエリを実行し、ログイン試行の記録を削除
+	_, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, keys)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to reset login attempts: %v", err)
+		return err
+	}
+
+	r.Logger.InfoLog.Printf("Reset login attempts: %v", keys)
+	return nil
+}
@@ -0,0 +1,169 @@
+package infrastructure_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// パスワード再設定リポジトリの実装(Impl)
+type PasswordResetRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// パスワード再設定リポジトリのインスタンス化
+func NewPasswordResetRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_auth.IPasswordResetRepository {
+	return &PasswordResetRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// パスワード再設定トークンを作成
+// 同じユーザーの未使用トークンは使用済みにし、最新のトークンのみ有効にする。
+func (r *PasswordResetRepositoryImpl) CreatePasswordResetToken(token domain_auth.PasswordResetToken) error {
+	r.Logger.InfoLog.Println("CreatePasswordResetToken called")
+
+	invalidateQuery := `
+		UPDATE password_reset_tokens
+		SET used_at = now()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+	insertQuery := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// 未使用のトークンを無効化
+	_, err = tx.Exec(r.SupabaseClient.Ctx, invalidateQuery, token.UserID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to invalidate password reset tokens: %v", err)
+		return err
+	}
+
+	// トークンを作成
+	_, err = tx.Exec(r.SupabaseClient.Ctx, insertQuery, token.UserID, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to create password reset token: %v", err)
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Created password reset token for user: %s", token.UserID)
+	return nil
+}
+
+// トークンを使用済みにしてパスワードを更新
+// トークンが無効な場合はErrPasswordResetTokenInvalidを返す。成功時はユーザーIDを返す。
+func (r *PasswordResetRepositoryImpl) ResetPassword(tokenHash string, hashedPassword string) (string, error) {
+	r.Logger.InfoLog.Println("ResetPassword called")
+
+	consumeQuery := `
+		UPDATE password_reset_tokens
+		SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		RETURNING user_id
+	`
+	updatePasswordQuery := `
+		UPDATE users
+		SET password = $1, updated_at = now()
+		WHERE id = $2
+	`
+	revokeTokensQuery := `
+		UPDATE refresh_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	revokeSessionsQuery := `
+		UPDATE sessions
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// トークンを使用済みにする(同時に使用された場合も一方のみ成功する)
+	var userID string
+	err = tx.QueryRow(r.SupabaseClient.Ctx, consumeQuery, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Println("Password reset token invalid")
+			err = repository_auth.ErrPasswordResetTokenInvalid
+			return "", err
+		}
+		r.Logger.ErrorLog.Printf("Failed to consume password reset token: %v", err)
+		return "", err
+	}
+
+	// パスワードを更新
+	_, err = tx.Exec(r.SupabaseClient.Ctx, updatePasswordQuery, hashedPassword, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to update password: %v", err)
+		return "", err
+	}
+
+	// 既存のリフレッシュトークンを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeTokensQuery, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke refresh tokens: %v", err)
+		return "", err
+	}
+
+	// 既存のセッションを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeSessionsQuery, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke sessions: %v", err)
+		return "", err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return "", err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Password reset for user: %s", userID)
+	return userID, nil
+}
@@ -0,0 +1,417 @@
+package infrastructure_auth
+
+import (
+	domain_audit "backend/internal/domain/audit"
+	domain_auth "backend/internal/domain/auth"
+	infrastructure_audit "backend/internal/infrastructure/audit"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_auth "backend/internal/repository/auth"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ロールリポジトリの実装(Impl)
+type RoleRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// ロールリポジトリのインスタンス化
+func NewRoleRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_auth.IRoleRepository {
+	return &RoleRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// 全てのロールを取得
+func (r *RoleRepositoryImpl) GetAllRoles() ([]domain_auth.Role, error) {
+	r.Logger.InfoLog.Println("GetAllRoles called")
+
+	query := `
+		SELECT ` + roleColumns + `
+		FROM roles
+		ORDER BY name
+	`
+
+	// Supabaseからクエリを実行し、ロールを取得
+	roles, err := r.queryRoles(query)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch roles: %v", err)
+		return nil, err
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d roles", len(roles))
+	return roles, nil
+}
+
+// ユーザーに付与されたロールを取得
+// ユーザーが存在しない場合はErrUserNotFoundを返す。
+func (r *RoleRepositoryImpl) GetRolesByUserId(userID string) ([]domain_auth.Role, error) {
+	r.Logger.InfoLog.Println("GetRolesByUserId called")
+
+	query := `
+		SELECT r.name, r.description, r.created_at
+		FROM user_roles ur
+		JOIN roles r ON r.name = ur.role
+		WHERE ur.user_id = $1
+		ORDER BY r.name
+	`
+
+	// Supabaseからクエリを実行し、ユーザーのロールを取得
+	roles, err := r.queryRoles(query, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch user roles: %v", err)
+		return nil, err
+	}
+
+	// ロールがない場合はユーザーの存在を確認
+	if len(roles) == 0 {
+		var exists bool
+		err = r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx,
+			`SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, userID).Scan(&exists)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to check user: %v", err)
+			return nil, err
+		}
+		if !exists {
+			r.Logger.ErrorLog.Printf("User not found: %s", userID)
+			return nil, repository_auth.ErrUserNotFound
+		}
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d roles", len(roles))
+	return roles, nil
+}
+
+// 管理者が存在しない場合のみ、ユーザーにadminロールを付与し、監査ログを記録
+// 有効な管理者が存在する場合はErrAdminAlreadyExistsを返す。同時に実行しても管理者は1人だけ作成される。
+func (r *RoleRepositoryImpl) BootstrapAdmin(userID string, actorID string) error {
+	r.Logger.InfoLog.Println("BootstrapAdmin called")
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// 管理者の変更を直列化し、有効な管理者がいないことを確認
+	err = LockAdmins(r.SupabaseClient.Ctx, tx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to lock admins: %v", err)
+		return err
+	}
+	var admins []string
+	admins, err = activeAdminIDs(r.SupabaseClient.Ctx, tx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch admins: %v", err)
+		return err
+	}
+	if len(admins) > 0 {
+		r.Logger.ErrorLog.Println("Admin already exists")
+		err = repository_auth.ErrAdminAlreadyExists
+		return err
+	}
+
+	// adminロールを付与
+	err = r.assignRole(tx, userID, domain_auth.RoleAdmin, actorID)
+	if err != nil {
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Bootstrapped admin: %s", userID)
+	return nil
+}
+
+// ユーザーにロールを付与し、監査ログを記録
+func (r *RoleRepositoryImpl) AssignRole(userID string, role string, actorID string) error {
+	r.Logger.InfoLog.Println("AssignRole called")
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// ロールを付与
+	err = r.assignRole(tx, userID, role, actorID)
+	if err != nil {
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Assigned role %s to user: %s", role, userID)
+	return nil
+}
+
+// トランザクション内でユーザーにロールを付与し、監査ログを記録
+func (r *RoleRepositoryImpl) assignRole(tx pgx.Tx, userID string, role string, actorID string) error {
+	userExistsQuery := `
+		SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)
+	`
+	roleExistsQuery := `
+		SELECT EXISTS (SELECT 1 FROM roles WHERE name = $1)
+	`
+	assignQuery := `
+		INSERT INTO user_roles (user_id, role, granted_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role) DO NOTHING
+	`
+
+	// ユーザー・ロールの存在を確認
+	var exists bool
+	err := tx.QueryRow(r.SupabaseClient.Ctx, userExistsQuery, userID).Scan(&exists)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check user: %v", err)
+		return err
+	}
+	if !exists {
+		r.Logger.ErrorLog.Printf("User not found: %s", userID)
+		err = repository_auth.ErrUserNotFound
+		return err
+	}
+	err = tx.QueryRow(r.SupabaseClient.Ctx, roleExistsQuery, role).Scan(&exists)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check role: %v", err)
+		return err
+	}
+	if !exists {
+		r.Logger.ErrorLog.Printf("Role not found: %s", role)
+		err = repository_auth.ErrRoleNotFound
+		return err
+	}
+
+	// ロールを付与
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, assignQuery, userID, role, actorID)
+	if err != nil {
+		if pkg_supabase.IsForeignKeyViolation(err) {
+			// 確認後に削除された場合
+			r.Logger.ErrorLog.Printf("User not found: %v", err)
+			err = repository_auth.ErrUserNotFound
+			return err
+		}
+		r.Logger.ErrorLog.Printf("Failed to assign role: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Printf("Role already assigned: %s to %s", role, userID)
+		err = repository_auth.ErrRoleAlreadyAssigned
+		return err
+	}
+
+	// 監査ログを記録
+	err = infrastructure_audit.WriteAuditLog(r.SupabaseClient.Ctx, tx, domain_audit.AuditLog{
+		ActorID:    actorID,
+		Action:     domain_audit.ActionRoleAssign,
+		TargetType: domain_audit.TargetTypeUser,
+		TargetID:   userID,
+		Details:    map[string]interface{}{"role": role},
+	})
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to write audit log: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ユーザーからロールを剥奪し、監査ログを記録
+// 有効な管理者が1人だけの場合、その管理者からadminロールは剥奪できない(ErrLastAdmin)。
+func (r *RoleRepositoryImpl) RevokeRole(userID string, role string, actorID string) error {
+	r.Logger.InfoLog.Println("RevokeRole called")
+
+	revokeQuery := `
+		DELETE FROM user_roles
+		WHERE user_id = $1 AND role = $2
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// 最後の管理者かどうかを確認
+	if role == domain_auth.RoleAdmin {
+		var last bool
+		last, err = IsLastActiveAdmin(r.SupabaseClient.Ctx, tx, userID)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to check admins: %v", err)
+			return err
+		}
+		if last {
+			r.Logger.ErrorLog.Printf("Cannot revoke the last admin: %s", userID)
+			err = repository_auth.ErrLastAdmin
+			return err
+		}
+	}
+
+	// ロールを剥奪
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, revokeQuery, userID, role)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke role: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Printf("Role not assigned: %s to %s", role, userID)
+		err = repository_auth.ErrRoleNotAssigned
+		return err
+	}
+
+	// 監査ログを記録
+	err = infrastructure_audit.WriteAuditLog(r.SupabaseClient.Ctx, tx, domain_audit.AuditLog{
+		ActorID:    actorID,
+		Action:     domain_audit.ActionRoleRevoke,
+		TargetType: domain_audit.TargetTypeUser,
+		TargetID:   userID,
+		Details:    map[string]interface{}{"role": role},
+	})
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to write audit log: %v", err)
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Revoked role %s from user: %s", role, userID)
+	return nil
+}
+
+// 管理者の数に関わる変更を直列化する(トランザクションの終了まで)
+// adminロールの剥奪・ユーザーの無効化・パージ・最初の管理者の作成で使用し、同時に実行して有効な管理者がいなくなる(または複数作成される)ことを防ぐ。
+// 管理者がいない場合もロックできるよう、行ロックではなくアドバイザリロックを使用する。
+func LockAdmins(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('admin_roles'))`)
+	return err
+}
+
+// ユーザーが最後の有効な管理者かどうか
+// LockAdminsでロックしてから数えるため、トランザクション内で他の管理者が同時に減ることはない。
+func IsLastActiveAdmin(ctx context.Context, tx pgx.Tx, userID string) (bool, error) {
+	if err := LockAdmins(ctx, tx); err != nil {
+		return false, err
+	}
+	admins, err := activeAdminIDs(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	return len(admins) == 1 && admins[0] == userID, nil
+}
+
+// 有効な(無効化されていない)管理者のユーザーIDを取得
+func activeAdminIDs(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	query := `
+		SELECT ur.user_id
+		FROM user_roles ur
+		JOIN users u ON u.id = ur.user_id
+		WHERE ur.role = $1 AND u.deactivated_at IS NULL
+	`
+
+	rows, err := tx.Query(ctx, query, domain_auth.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	adminIDs := []string{}
+	for rows.Next() {
+		var adminID string
+		if err := rows.Scan(&adminID); err != nil {
+			return nil, err
+		}
+		adminIDs = append(adminIDs, adminID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return adminIDs, nil
+}
+
+// ロールの一覧を取得するクエリを実行
+func (r *RoleRepositoryImpl) queryRoles(query string, args ...interface{}) ([]domain_auth.Role, error) {
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// ロールのリストを作成
+	roles := []domain_auth.Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// ロールの取得カラム
+const roleColumns = `name, description, created_at`
+
+// ロールの行をスキャン
+func scanRole(row pgx.Row) (domain_auth.Role, error) {
+	var role domain_auth.Role
+	err := row.Scan(
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+	)
+	return role, err
+}
@@ -0,0 +1,257 @@
+package infrastructure_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// セッションリポジトリの実装(Impl)
+type SessionRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// セッションリポジトリのインスタンス化
+func NewSessionRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_auth.ISessionRepository {
+	return &SessionRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// セッションを作成
+func (r *SessionRepositoryImpl) CreateSession(session domain_auth.Session) (domain_auth.Session, error) {
+	r.Logger.InfoLog.Println("CreateSession called")
+
+	query := `
+		INSERT INTO sessions (user_id, user_agent, ip_address)
+		VALUES ($1, $2, $3)
+		RETURNING ` + sessionColumns
+
+	// Supabaseからクエリを実行し、セッションを作成
+	created, err := scanSession(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query,
+		session.UserID, session.UserAgent, session.IPAddress))
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to create session: %v", err)
+		return domain_auth.Session{}, err
+	}
+
+	r.Logger.InfoLog.Printf("Created session: %s", created.ID)
+	return created, nil
+}
+
+// 特定のセッションを取得
+func (r *SessionRepositoryImpl) GetSessionById(id string) (domain_auth.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions
+		WHERE id = $1
+	`
+
+	// Supabaseからクエリを実行し、条件に一致するセッションを取得
+	session, err := scanSession(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain_auth.Session{}, repository_auth.ErrSessionNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch session: %v", err)
+		return domain_auth.Session{}, err
+	}
+
+	return session, nil
+}
+
+// ユーザーの有効なセッションを取得
+func (r *SessionRepositoryImpl) GetActiveSessionsByUserId(userID string, activeSince time.Time) ([]domain_auth.Session, error) {
+	r.Logger.InfoLog.Println("GetActiveSessionsByUserId called")
+
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND last_seen_at >= $2
+		ORDER BY last_seen_at DESC
+	`
+
+	// Supabaseからクエリを実行し、セッションを取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, userID, activeSince)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch sessions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// セッションのリストを作成
+	sessions := []domain_auth.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan session: %v", err)
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d sessions", len(sessions))
+	return sessions, nil
+}
+
+// セッションを失効し、紐づくリフレッシュトークンも失効
+// 他のユーザーのセッション・失効済みのセッションはErrSessionNotFoundを返す。
+func (r *SessionRepositoryImpl) RevokeSession(userID string, id string) error {
+	r.Logger.InfoLog.Println("RevokeSession called")
+
+	revokeSessionQuery := `
+		UPDATE sessions
+		SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+	revokeTokensQuery := `
+		UPDATE refresh_tokens
+		SET revoked_at = now()
+		WHERE session_id = $1 AND revoked_at IS NULL
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// セッションを失効
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, revokeSessionQuery, id, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke session: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Printf("Session not found: %s", id)
+		err = repository_auth.ErrSessionNotFound
+		return err
+	}
+
+	// リフレッシュトークンを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeTokensQuery, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke refresh tokens: %v", err)
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Revoked session: %s", id)
+	return nil
+}
+
+// ユーザーの全セッションを失効し、リフレッシュトークンも全て失効
+// exceptIDが指定された場合は、そのセッションとリフレッシュトークンを残す。
+func (r *SessionRepositoryImpl) RevokeUserSessions(userID string, exceptID string) error {
+	r.Logger.InfoLog.Println("RevokeUserSessions called")
+
+	revokeSessionsQuery := `
+		UPDATE sessions
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL AND ($2 = '' OR id::text <> $2)
+	`
+	revokeTokensQuery := `
+		UPDATE refresh_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL AND ($2 = '' OR session_id IS NULL OR session_id::text <> $2)
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// セッションを失効
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, revokeSessionsQuery, userID, exceptID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke sessions: %v", err)
+		return err
+	}
+
+	// リフレッシュトークンを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeTokensQuery, userID, exceptID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke refresh tokens: %v", err)
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Revoked %d sessions for user: %s", tag.RowsAffected(), userID)
+	return nil
+}
+
+// 最終利用日時を更新
+func (r *SessionRepositoryImpl) TouchSession(id string, seenAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET last_seen_at = $2
+		WHERE id = $1 AND last_seen_at < $2
+	`
+
+	// Supabaseからクエリを実行し、最終利用日時を更新
+	_, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, id, seenAt)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to touch session: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// セッションの取得カラム
+const sessionColumns = `id, user_id, user_agent, ip_address, created_at, last_seen_at, revoked_at`
+
+// セッションの行をスキャン
+func scanSession(row pgx.Row) (domain_auth.Session, error) {
+	var session domain_auth.Session
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.RevokedAt,
+	)
+	return session, err
+}
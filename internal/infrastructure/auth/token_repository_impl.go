@@ -0,0 +1,226 @@
+package infrastructure_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// トークンリポジトリの実装(Impl)
+type TokenRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// トークンリポジトリのインスタンス化
+func NewTokenRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_auth.ITokenRepository {
+	return &TokenRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// リフレッシュトークンを作成
+func (r *TokenRepositoryImpl) CreateRefreshToken(token domain_auth.RefreshToken) (domain_auth.RefreshToken, error) {
+	r.Logger.InfoLog.Println("CreateRefreshToken called")
+
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, session_id, token_hash, expires_at)
+		VALUES ($1, COALESCE(NULLIF($2, '')::uuid, gen_random_uuid()), NULLIF($3, '')::uuid, $4, $5)
+		RETURNING id, user_id, family_id, COALESCE(session_id::text, ''), token_hash, expires_at, revoked_at, replaced_by, created_at
+	`
+
+	// Supabaseからクエリを実行し、リフレッシュトークンを作成
+	created, err := scanRefreshToken(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query,
+		token.UserID, token.FamilyID, token.SessionID, token.TokenHash, token.ExpiresAt))
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to create refresh token: %v", err)
+		return domain_auth.RefreshToken{}, err
+	}
+
+	r.Logger.InfoLog.Printf("Created refresh token: %s", created.ID)
+	return created, nil
+}
+
+// ハッシュ値からリフレッシュトークンを取得
+func (r *TokenRepositoryImpl) GetRefreshTokenByHash(tokenHash string) (domain_auth.RefreshToken, error) {
+	r.Logger.InfoLog.Println("GetRefreshTokenByHash called")
+
+	query := `
+		SELECT id, user_id, family_id, COALESCE(session_id::text, ''), token_hash, expires_at, revoked_at, replaced_by, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	// Supabaseからクエリを実行し、条件に一致するリフレッシュトークンを取得
+	token, err := scanRefreshToken(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Println("Refresh token not found")
+			return domain_auth.RefreshToken{}, repository_auth.ErrRefreshTokenNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch refresh token: %v", err)
+		return domain_auth.RefreshToken{}, err
+	}
+
+	r.Logger.InfoLog.Printf("Fetched refresh token: %s", token.ID)
+	return token, nil
+}
+
+// リフレッシュトークンをローテーション(旧トークンを失効させ、新トークンを作成)
+// 旧トークンが既に失効済みの場合はErrRefreshTokenReusedを返す。
+func (r *TokenRepositoryImpl) RotateRefreshToken(oldID string, newToken domain_auth.RefreshToken) (domain_auth.RefreshToken, error) {
+	r.Logger.InfoLog.Println("RotateRefreshToken called")
+
+	revokeQuery := `
+		UPDATE refresh_tokens
+		SET revoked_at = now()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	insertQuery := `
+		INSERT INTO refresh_tokens (user_id, family_id, session_id, token_hash, expires_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5)
+		RETURNING id, user_id, family_id, COALESCE(session_id::text, ''), token_hash, expires_at, revoked_at, replaced_by, created_at
+	`
+	replaceQuery := `
+		UPDATE refresh_tokens
+		SET replaced_by = $1
+		WHERE id = $2
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_auth.RefreshToken{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// 旧トークンを失効(同時リクエストでも一方のみ成功する)
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, revokeQuery, oldID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke refresh token: %v", err)
+		return domain_auth.RefreshToken{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		err = repository_auth.ErrRefreshTokenReused
+		r.Logger.ErrorLog.Printf("Refresh token already revoked: %s", oldID)
+		return domain_auth.RefreshToken{}, err
+	}
+
+	// 新トークンを作成
+	created, err := scanRefreshToken(tx.QueryRow(r.SupabaseClient.Ctx, insertQuery,
+		newToken.UserID, newToken.FamilyID, newToken.SessionID, newToken.TokenHash, newToken.ExpiresAt))
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to create refresh token: %v", err)
+		return domain_auth.RefreshToken{}, err
+	}
+
+	// 旧トークンに後継トークンを記録
+	_, err = tx.Exec(r.SupabaseClient.Ctx, replaceQuery, created.ID, oldID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to update refresh token: %v", err)
+		return domain_auth.RefreshToken{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_auth.RefreshToken{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Rotated refresh token: %s -> %s", oldID, created.ID)
+	return created, nil
+}
+
+// トークン系列を全て失効
+func (r *TokenRepositoryImpl) RevokeTokenFamily(familyID string) error {
+	r.Logger.InfoLog.Println("RevokeTokenFamily called")
+
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = now()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+
+	// Supabaseからクエリを実行し、系列内の有効なトークンを失効
+	tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, familyID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke token family: %v", err)
+		return err
+	}
+
+	r.Logger.InfoLog.Printf("Revoked %d refresh tokens in family: %s", tag.RowsAffected(), familyID)
+	return nil
+}
+
+// アクセストークンを失効
+func (r *TokenRepositoryImpl) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	r.Logger.InfoLog.Println("RevokeAccessToken called")
+
+	query := `
+		INSERT INTO revoked_access_tokens (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	// Supabaseからクエリを実行し、jtiを失効リストに追加
+	_, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, jti, expiresAt)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke access token: %v", err)
+		return err
+	}
+
+	r.Logger.InfoLog.Printf("Revoked access token: %s", jti)
+	return nil
+}
+
+// アクセストークンが失効済みか確認
+func (r *TokenRepositoryImpl) IsAccessTokenRevoked(jti string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM revoked_access_tokens WHERE jti = $1
+		)
+	`
+
+	// Supabaseからクエリを実行し、失効リストに含まれるか確認
+	var revoked bool
+	err := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, jti).Scan(&revoked)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check revoked access token: %v", err)
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// リフレッシュトークンの行をスキャン
+func scanRefreshToken(row pgx.Row) (domain_auth.RefreshToken, error) {
+	var token domain_auth.RefreshToken
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.SessionID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.ReplacedBy,
+		&token.CreatedAt,
+	)
+	return token, err
+}
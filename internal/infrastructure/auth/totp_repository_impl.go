@@ -0,0 +1,247 @@
+package infrastructure_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// TOTPリポジトリの実装(Impl)
+type TOTPRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// TOTPリポジトリのインスタンス化
+func NewTOTPRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_auth.ITOTPRepository {
+	return &TOTPRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// ユーザーのTOTPの設定を取得
+func (r *TOTPRepositoryImpl) GetTOTP(userID string) (domain_auth.UserTOTP, error) {
+	r.Logger.InfoLog.Println("GetTOTP called")
+
+	query := `
+		SELECT user_id, secret, enabled, last_used_step, created_at, updated_at
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	// Supabaseからクエリを実行し、条件に一致する設定を取得
+	var totp domain_auth.UserTOTP
+	err := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, userID).
+		Scan(&totp.UserID,
+			&totp.Secret,
+			&totp.Enabled,
+			&totp.LastUsedStep,
+			&totp.CreatedAt,
+			&totp.UpdatedAt,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain_auth.UserTOTP{}, repository_auth.ErrTOTPNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch totp: %v", err)
+		return domain_auth.UserTOTP{}, err
+	}
+
+	r.Logger.InfoLog.Println("Fetched totp successfully")
+	return totp, nil
+}
+
+// シークレットを保存
+// 有効化済みの設定は上書きしない(無効化してから登録し直すこと)。
+func (r *TOTPRepositoryImpl) SaveTOTPSecret(userID string, encryptedSecret string) error {
+	r.Logger.InfoLog.Println("SaveTOTPSecret called")
+
+	query := `
+		INSERT INTO user_totp (user_id, secret, enabled)
+		VALUES ($1, $2, false)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = NULL, updated_at = now()
+		WHERE user_totp.enabled = false
+	`
+
+	// Supabaseからクエリを実行し、シークレットを保存
+	_, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, userID, encryptedSecret)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to save totp secret: %v", err)
+		return err
+	}
+
+	r.Logger.InfoLog.Println("Saved totp secret successfully")
+	return nil
+}
+
+// TOTPを有効化し、リカバリーコードを登録
+func (r *TOTPRepositoryImpl) EnableTOTP(userID string, step int64, recoveryCodeHashes []string) error {
+	r.Logger.InfoLog.Println("EnableTOTP called")
+
+	enableQuery := `
+		UPDATE user_totp
+		SET enabled = true, last_used_step = $2, updated_at = now()
+		WHERE user_id = $1 AND enabled = false
+	`
+	deleteCodesQuery := `
+		DELETE FROM user_recovery_codes
+		WHERE user_id = $1
+	`
+	insertCodeQuery := `
+		INSERT INTO user_recovery_codes (user_id, code_hash)
+		VALUES ($1, $2)
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// TOTPを有効化
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, enableQuery, userID, step)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to enable totp: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Println("Totp not found or already enabled")
+		err = repository_auth.ErrTOTPNotFound
+		return err
+	}
+
+	// リカバリーコードを登録し直す
+	_, err = tx.Exec(r.SupabaseClient.Ctx, deleteCodesQuery, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to delete recovery codes: %v", err)
+		return err
+	}
+	for _, codeHash := range recoveryCodeHashes {
+		_, err = tx.Exec(r.SupabaseClient.Ctx, insertCodeQuery, userID, codeHash)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to insert recovery code: %v", err)
+			return err
+		}
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Println("Enabled totp successfully")
+	return nil
+}
+
+// TOTPを無効化し、リカバリーコードを削除
+func (r *TOTPRepositoryImpl) DisableTOTP(userID string) error {
+	r.Logger.InfoLog.Println("DisableTOTP called")
+
+	deleteTOTPQuery := `
+		DELETE FROM user_totp
+		WHERE user_id = $1
+	`
+	deleteCodesQuery := `
+		DELETE FROM user_recovery_codes
+		WHERE user_id = $1
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// TOTPの設定を削除
+	_, err = tx.Exec(r.SupabaseClient.Ctx, deleteTOTPQuery, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to delete totp: %v", err)
+		return err
+	}
+
+	// リカバリーコードを削除
+	_, err = tx.Exec(r.SupabaseClient.Ctx, deleteCodesQuery, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to delete recovery codes: %v", err)
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Println("Disabled totp successfully")
+	return nil
+}
+
+// 使用したコードの時間ステップを記録
+// 同時に同じコードが使用された場合も一方のみ成功する。
+func (r *TOTPRepositoryImpl) UseTOTPStep(userID string, step int64) (bool, error) {
+	r.Logger.InfoLog.Println("UseTOTPStep called")
+
+	query := `
+		UPDATE user_totp
+		SET last_used_step = $2, updated_at = now()
+		WHERE user_id = $1 AND enabled = true AND (last_used_step IS NULL OR last_used_step < $2)
+	`
+
+	// Supabaseからクエリを実行し、時間ステップを記録
+	tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, userID, step)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to use totp step: %v", err)
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// リカバリーコードを使用済みにする
+func (r *TOTPRepositoryImpl) UseRecoveryCode(userID string, codeHash string) (bool, error) {
+	r.Logger.InfoLog.Println("UseRecoveryCode called")
+
+	query := `
+		UPDATE user_recovery_codes
+		SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	// Supabaseからクエリを実行し、リカバリーコードを使用済みにする
+	tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, userID, codeHash)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to use recovery code: %v", err)
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,316 @@
+package infrastructure_service_account
+
+import (
+	domain_service_account "backend/internal/domain/service_account"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_service_account "backend/internal/repository/service_account"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// サービスアカウントリポジトリ(Impl)
+type ServiceAccountRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// サービスアカウントリポジトリのインスタンス化
+func NewServiceAccountRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_service_account.IServiceAccountRepository {
+	return &ServiceAccountRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// サービスアカウントを作成
+func (r *ServiceAccountRepositoryImpl) CreateServiceAccount(serviceAccount domain_service_account.ServiceAccount) (domain_service_account.ServiceAccount, error) {
+	r.Logger.InfoLog.Println("CreateServiceAccount called")
+
+	query := `
+		INSERT INTO service_accounts (name, description)
+		VALUES ($1, $2)
+		RETURNING id, name, description, created_at, updated_at
+	`
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_service_account.ServiceAccount{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// Supabaseからクエリを実行し、サービスアカウントを作成
+	var created domain_service_account.ServiceAccount
+	err = tx.QueryRow(r.SupabaseClient.Ctx, query, serviceAccount.Name, serviceAccount.Description).
+		Scan(&created.ID,
+			&created.Name,
+			&created.Description,
+			&created.CreatedAt,
+			&created.UpdatedAt,
+		)
+	if err != nil {
+		if pkg_supabase.IsUniqueViolation(err) {
+			r.Logger.ErrorLog.Printf("Service account already exists: %v", err)
+			err = repository_service_account.ErrServiceAccountAlreadyExists
+			return domain_service_account.ServiceAccount{}, err
+		}
+		r.Logger.ErrorLog.Printf("Failed to create service account: %v", err)
+		return domain_service_account.ServiceAccount{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_service_account.ServiceAccount{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Created service account: %s", created.ID)
+	return created, nil
+}
+
+// 全てのサービスアカウントを取得
+func (r *ServiceAccountRepositoryImpl) GetAllServiceAccounts() ([]domain_service_account.ServiceAccount, error) {
+	r.Logger.InfoLog.Println("GetAllServiceAccounts called")
+
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM service_accounts
+		ORDER BY name
+	`
+
+	// Supabaseからクエリを実行し、サービスアカウントを取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch service accounts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// サービスアカウントのリストを作成
+	serviceAccounts := []domain_service_account.ServiceAccount{}
+	for rows.Next() {
+		var serviceAccount domain_service_account.ServiceAccount
+		err = rows.Scan(
+			&serviceAccount.ID,
+			&serviceAccount.Name,
+			&serviceAccount.Description,
+			&serviceAccount.CreatedAt,
+			&serviceAccount.UpdatedAt,
+		)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan service account: %v", err)
+			return nil, err
+		}
+		serviceAccounts = append(serviceAccounts, serviceAccount)
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d service accounts", len(serviceAccounts))
+	return serviceAccounts, nil
+}
+
+// 特定のサービスアカウントを取得
+func (r *ServiceAccountRepositoryImpl) GetServiceAccountById(id string) (domain_service_account.ServiceAccount, error) {
+	r.Logger.InfoLog.Println("GetServiceAccountById called")
+
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM service_accounts
+		WHERE id = $1
+	`
+
+	// Supabaseからクエリを実行し、条件に一致するサービスアカウントを取得
+	var serviceAccount domain_service_account.ServiceAccount
+	err := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, id).
+		Scan(&serviceAccount.ID,
+			&serviceAccount.Name,
+			&serviceAccount.Description,
+			&serviceAccount.CreatedAt,
+			&serviceAccount.UpdatedAt,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Printf("Service account not found: %s", id)
+			return domain_service_account.ServiceAccount{}, repository_service_account.ErrServiceAccountNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch service account: %v", err)
+		return domain_service_account.ServiceAccount{}, err
+	}
+
+	r.Logger.InfoLog.Printf("Fetched service account: %s", serviceAccount.ID)
+	return serviceAccount, nil
+}
+
+// APIキーを作成
+func (r *ServiceAccountRepositoryImpl) CreateAPIKey(key domain_service_account.APIKey) (domain_service_account.APIKey, error) {
+	r.Logger.InfoLog.Println("CreateAPIKey called")
+
+	query := `
+		INSERT INTO api_keys (service_account_id, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + apiKeyColumns
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_service_account.APIKey{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// Supabaseからクエリを実行し、APIキーを作成
+	created, err := scanAPIKey(tx.QueryRow(r.SupabaseClient.Ctx, query,
+		key.ServiceAccountID, key.Prefix, key.KeyHash, key.Scopes, key.ExpiresAt))
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to create api key: %v", err)
+		return domain_service_account.APIKey{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_service_account.APIKey{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Created api key: %s", created.ID)
+	return created, nil
+}
+
+// サービスアカウントのAPIキーを取得
+func (r *ServiceAccountRepositoryImpl) GetAPIKeysByServiceAccountId(serviceAccountId string) ([]domain_service_account.APIKey, error) {
+	r.Logger.InfoLog.Println("GetAPIKeysByServiceAccountId called")
+
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE service_account_id = $1
+		ORDER BY created_at
+	`
+
+	// Supabaseからクエリを実行し、APIキーを取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, serviceAccountId)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch api keys: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// APIキーのリストを作成
+	keys := []domain_service_account.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan api key: %v", err)
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d api keys", len(keys))
+	return keys, nil
+}
+
+// ハッシュ値からAPIキーを取得
+func (r *ServiceAccountRepositoryImpl) GetAPIKeyByHash(keyHash string) (domain_service_account.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE key_hash = $1
+	`
+
+	// Supabaseからクエリを実行し、条件に一致するAPIキーを取得
+	key, err := scanAPIKey(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain_service_account.APIKey{}, repository_service_account.ErrAPIKeyNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch api key: %v", err)
+		return domain_service_account.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// APIキーを失効
+func (r *ServiceAccountRepositoryImpl) RevokeAPIKey(id string) error {
+	r.Logger.InfoLog.Println("RevokeAPIKey called")
+
+	query := `
+		UPDATE api_keys
+		SET revoked_at = now()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	// Supabaseからクエリを実行し、APIキーを失効
+	tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke api key: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Printf("Api key not found or already revoked: %s", id)
+		return repository_service_account.ErrAPIKeyNotFound
+	}
+
+	r.Logger.InfoLog.Printf("Revoked api key: %s", id)
+	return nil
+}
+
+// APIキーの最終使用日時を更新
+func (r *ServiceAccountRepositoryImpl) UpdateAPIKeyLastUsedAt(id string, usedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2)
+	`
+
+	// Supabaseからクエリを実行し、最終使用日時を更新
+	_, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, id, usedAt)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to update api key last used at: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// APIキーの取得カラム
+const apiKeyColumns = `id, service_account_id, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at`
+
+// APIキーの行をスキャン
+func scanAPIKey(row pgx.Row) (domain_service_account.APIKey, error) {
+	var key domain_service_account.APIKey
+	err := row.Scan(
+		&key.ID,
+		&key.ServiceAccountID,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	return key, err
+}
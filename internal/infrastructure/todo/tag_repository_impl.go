@@ -0,0 +1,258 @@
+package infrastructure_todo
+
+import (
+	domain_todo "backend/internal/domain/todo"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_supabase "backend/internal/pkg/supabase"
+	repository_todo "backend/internal/repository/todo"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// タグリポジトリ(Impl)
+type TagRepositoryImpl struct {
+	Logger         *pkg_logger.AppLogger
+	SupabaseClient *pkg_supabase.SupabaseClient
+}
+
+// タグリポジトリのインスタンス化
+func NewTagRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient) repository_todo.ITagRepository {
+	return &TagRepositoryImpl{
+		Logger:         l,
+		SupabaseClient: sc,
+	}
+}
+
+// 特定のユーザーのタグを名前順に取得
+func (r *TagRepositoryImpl) GetTagsByUserId(userID string) ([]domain_todo.Tag, error) {
+	r.Logger.InfoLog.Println("GetTagsByUserId called")
+
+	query := `
+		SELECT ` + tagColumns + `
+		FROM tags
+		WHERE user_id = $1
+		ORDER BY lower(name), id
+	`
+
+	// Supabaseからクエリを実行し、ユーザーのタグを取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, userID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch tags: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// タグのリストを作成
+	tags := []domain_todo.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan tag: %v", err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch tags: %v", err)
+		return nil, err
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d tags", len(tags))
+	return tags, nil
+}
+
+// 特定のタグを取得
+func (r *TagRepositoryImpl) GetTagById(id string) (domain_todo.Tag, error) {
+	r.Logger.InfoLog.Println("GetTagById called")
+
+	query := `
+		SELECT ` + tagColumns + `
+		FROM tags
+		WHERE id = $1
+	`
+
+	// Supabaseからクエリを実行し、タグを取得
+	tag, err := scanTag(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || pkg_supabase.IsInvalidTextRepresentation(err) {
+			r.Logger.ErrorLog.Printf("Tag not found: %s", id)
+			return domain_todo.Tag{}, repository_todo.ErrTagNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch tag: %v", err)
+		return domain_todo.Tag{}, err
+	}
+
+	r.Logger.InfoLog.Printf("Fetched tag: %v", tag)
+	return tag, nil
+}
+
+// 新しいタグを作成
+func (r *TagRepositoryImpl) CreateTag(tag domain_todo.Tag) (domain_todo.Tag, error) {
+	r.Logger.InfoLog.Println("CreateTag called")
+
+	query := `
+		INSERT INTO tags (user_id, name, color)
+		VALUES ($1, $2, $3)
+		RETURNING ` + tagColumns
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_todo.Tag{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// Supabaseからクエリを実行し、タグを作成
+	created, err := scanTag(tx.QueryRow(r.SupabaseClient.Ctx, query, tag.UserID, tag.Name, tag.Color))
+	if err != nil {
+		switch {
+		case pkg_supabase.IsUniqueViolation(err):
+			r.Logger.ErrorLog.Printf("Tag already exists: %s", tag.Name)
+			err = repository_todo.ErrTagAlreadyExists
+		case pkg_supabase.IsForeignKeyViolation(err), pkg_supabase.IsInvalidTextRepresentation(err):
+			r.Logger.ErrorLog.Printf("Tag owner not found: %s", tag.UserID)
+			err = repository_todo.ErrTagOwnerNotFound
+		default:
+			r.Logger.ErrorLog.Printf("Failed to create tag: %v", err)
+		}
+		return domain_todo.Tag{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_todo.Tag{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Created tag: %v", created)
+	return created, nil
+}
+
+// 特定のタグの名前・色を更新
+func (r *TagRepositoryImpl) UpdateTag(tag domain_todo.Tag) (domain_todo.Tag, error) {
+	r.Logger.InfoLog.Println("UpdateTag called")
+
+	query := `
+		UPDATE tags
+		SET name = $1, color = $2, updated_at = now()
+		WHERE id = $3
+		RETURNING ` + tagColumns
+
+	// トランザクションを開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_todo.Tag{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// Supabaseからクエリを実行し、タグを更新
+	updated, err := scanTag(tx.QueryRow(r.SupabaseClient.Ctx, query, tag.Name, tag.Color, tag.ID))
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			r.Logger.ErrorLog.Printf("Tag not found: %s", tag.ID)
+			err = repository_todo.ErrTagNotFound
+		case pkg_supabase.IsUniqueViolation(err):
+			r.Logger.ErrorLog.Printf("Tag already exists: %s", tag.Name)
+			err = repository_todo.ErrTagAlreadyExists
+		default:
+			r.Logger.ErrorLog.Printf("Failed to update tag: %v", err)
+		}
+		return domain_todo.Tag{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_todo.Tag{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Updated tag: %v", updated)
+	return updated, nil
+}
+
+// 特定のタグを削除
+func (r *TagRepositoryImpl) DeleteTag(id string) error {
+	r.Logger.InfoLog.Println("DeleteTag called")
+
+	query := `
+		DELETE FROM tags
+		WHERE id = $1
+	`
+
+	// トランザクションを開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// Supabaseからクエリを実行し、タグを削除(todo_tagsはカスケードで削除される)
+	commandTag, err := tx.Exec(r.SupabaseClient.Ctx, query, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to delete tag: %v", err)
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		r.Logger.ErrorLog.Printf("Tag not found: %s", id)
+		err = repository_todo.ErrTagNotFound
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Deleted tag: %v", id)
+	return nil
+}
+
+// タグの取得カラム
+const tagColumns = `id, user_id, name, color, created_at, updated_at`
+
+// タグの行をスキャン
+func scanTag(row pgx.Row) (domain_todo.Tag, error) {
+	var tag domain_todo.Tag
+	err := row.Scan(
+		&tag.ID,
+		&tag.UserID,
+		&tag.Name,
+		&tag.Color,
+		&tag.CreatedAt,
+		&tag.UpdatedAt,
+	)
+	return tag, err
+}
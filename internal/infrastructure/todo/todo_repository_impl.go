@@ -5,6 +5,14 @@ import (
 	pkg_logger "backend/internal/pkg/logger"
 	pkg_supabase "backend/internal/pkg/supabase"
 	repository_todo "backend/internal/repository/todo"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
 )
 
 // Todoリポジトリ(Impl)
@@ -21,41 +29,17 @@ func NewTodoRepository(l *pkg_logger.AppLogger, sc *pkg_supabase.SupabaseClient)
 	}
 }
 
-// 全てのTodoを取得
-func (r *TodoRepositoryImpl) GetAllTodos() ([]domain_todo.Todo, error) {
+// 全てのTodoをページ単位で取得
+func (r *TodoRepositoryImpl) GetAllTodos(page domain_todo.TodoPageQuery) ([]domain_todo.Todo, error) {
 	r.Logger.InfoLog.Println("GetAllTodos called")
 
-	query := `
-		SELECT id, description, completed, user_id, created_at, updated_at
-		FROM todos
-	`
-
-	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query)
+	// Supabaseからクエリを実行し、Todoを取得
+	todos, err := r.listTodos(domain_todo.TodoFilter{}, page)
 	if err != nil {
 		r.Logger.ErrorLog.Printf("Failed to fetch todos: %v", err)
 		return nil, err
 	}
 
-	// Todosのリストを作成
-	todos := []domain_todo.Todo{}
-	for rows.Next() {
-		var todo domain_todo.Todo
-		err = rows.Scan(
-			&todo.ID,
-			&todo.Description,
-			&todo.Completed,
-			&todo.UserId,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
-		if err != nil {
-			r.Logger.ErrorLog.Printf("Failed to scan todo: %v", err)
-			return nil, err
-		}
-		todos = append(todos, todo)
-	}
-
 	r.Logger.InfoLog.Printf("Fetched %d todos", len(todos))
 	return todos, nil
 }
@@ -65,79 +49,249 @@ func (r *TodoRepositoryImpl) GetTodoById(id string) (domain_todo.Todo, error) {
 	r.Logger.InfoLog.Println("GetTodoById called")
 
 	query := `
-		SELECT id, description, completed, user_id, created_at, updated_at
+		SELECT ` + todoColumns + `
 		FROM todos
 		WHERE id = $1
 	`
 
 	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	var todo domain_todo.Todo
-	err := r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, id).
-		Scan(&todo.ID,
-			&todo.Description,
-			&todo.Completed,
-			&todo.UserId,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
+	todo, err := scanTodo(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, id))
 	if err != nil {
+		// 存在しない、またはidの形式が不正(uuidでない)
+		if errors.Is(err, pgx.ErrNoRows) || pkg_supabase.IsInvalidTextRepresentation(err) {
+			r.Logger.ErrorLog.Printf("Todo not found: %s", id)
+			return domain_todo.Todo{}, repository_todo.ErrTodoNotFound
+		}
 		r.Logger.ErrorLog.Printf("Failed to fetch todo: %v", err)
 		return domain_todo.Todo{}, err
 	}
 
+	// タグを取得
+	tags, err := r.loadTags(r.SupabaseClient.Pool, []string{todo.ID})
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todo tags: %v", err)
+		return domain_todo.Todo{}, err
+	}
+	todo.Tags = tags[todo.ID]
+
 	r.Logger.InfoLog.Printf("Fetched todo: %v", todo)
 	return todo, nil
 }
 
-// 特定のユーザーのTodoを取得
-func (r *TodoRepositoryImpl) GetTodoByUserId(userId string) ([]domain_todo.Todo, error) {
+// 特定のユーザーのTodoをページ単位で取得
+func (r *TodoRepositoryImpl) GetTodoByUserId(userId string, page domain_todo.TodoPageQuery) ([]domain_todo.Todo, error) {
 	r.Logger.InfoLog.Println("GetTodoByUserId called")
 
+	// Supabaseからクエリを実行し、条件に一致するTodoを取得
+	todos, err := r.listTodos(domain_todo.TodoFilter{UserID: userId}, page)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todos: %v", err)
+		return nil, err
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d todos", len(todos))
+	return todos, nil
+}
+
+// 特定のTodoとその子孫を階層の浅い順に取得
+// 同じ階層のTodoは作成日時の昇順に並べる。Todoが存在しない場合はErrTodoNotFoundを返す。
+func (r *TodoRepositoryImpl) GetTodoTree(id string) ([]domain_todo.Todo, error) {
+	r.Logger.InfoLog.Println("GetTodoTree called")
+
+	// 深さはMaxTodoDepthまでに制限し、万一循環していても終了させる
 	query := `
-		SELECT id, description, completed, user_id, created_at, updated_at
-		FROM todos
-		WHERE user_id = $1
+		WITH RECURSIVE tree (id, depth) AS (
+			SELECT id, 1 FROM todos WHERE id = $1
+			UNION ALL
+			SELECT t.id, tree.depth + 1
+			FROM todos t
+			JOIN tree ON t.parent_id = tree.id
+			WHERE tree.depth < $2
+		)
+		SELECT ` + todoColumns + `
+		FROM tree
+		JOIN todos USING (id)
+		ORDER BY tree.depth, created_at, id
 	`
 
-	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, userId)
+	// Supabaseからクエリを実行し、Todoとその子孫を取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, id, domain_todo.MaxTodoDepth)
 	if err != nil {
-		r.Logger.ErrorLog.Printf("Failed to fetch todos: %v", err)
+		r.Logger.ErrorLog.Printf("Failed to fetch todo tree: %v", err)
 		return nil, err
 	}
+	defer rows.Close()
 
-	// Todosのリストを作成
 	todos := []domain_todo.Todo{}
 	for rows.Next() {
-		var todo domain_todo.Todo
-		err = rows.Scan(
-			&todo.ID,
-			&todo.Description,
-			&todo.Completed,
-			&todo.UserId,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
+		todo, err := scanTodo(rows)
 		if err != nil {
 			r.Logger.ErrorLog.Printf("Failed to scan todo: %v", err)
 			return nil, err
 		}
 		todos = append(todos, todo)
 	}
+	if err := rows.Err(); err != nil {
+		if pkg_supabase.IsInvalidTextRepresentation(err) {
+			r.Logger.ErrorLog.Printf("Todo not found: %s", id)
+			return nil, repository_todo.ErrTodoNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch todo tree: %v", err)
+		return nil, err
+	}
+	if len(todos) == 0 {
+		r.Logger.ErrorLog.Printf("Todo not found: %s", id)
+		return nil, repository_todo.ErrTodoNotFound
+	}
+
+	// タグをまとめて取得
+	ids := make([]string, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	tags, err := r.loadTags(r.SupabaseClient.Pool, ids)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todo tags: %v", err)
+		return nil, err
+	}
+	for i := range todos {
+		todos[i].Tags = tags[todos[i].ID]
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d todos in tree", len(todos))
+	return todos, nil
+}
+
+// 条件に一致するTodoをページ単位で取得
+func (r *TodoRepositoryImpl) ListTodos(filter domain_todo.TodoFilter, page domain_todo.TodoPageQuery) ([]domain_todo.Todo, error) {
+	r.Logger.InfoLog.Println("ListTodos called")
+
+	// Supabaseからクエリを実行し、条件に一致するTodoを取得
+	todos, err := r.listTodos(filter, page)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todos: %v", err)
+		return nil, err
+	}
 
 	r.Logger.InfoLog.Printf("Fetched %d todos", len(todos))
 	return todos, nil
 }
 
+// 説明を検索し、関連度の高い順に取得
+// 全文検索はdescription_tsv、トライグラム検索はpg_trgmのインデックスを使用する。同じ関連度の場合は作成日時の新しい順に並べる。
+func (r *TodoRepositoryImpl) SearchTodos(query domain_todo.TodoSearchQuery) ([]domain_todo.TodoSearchResult, error) {
+	r.Logger.InfoLog.Println("SearchTodos called")
+
+	args := []interface{}{}
+	// 値をargsに追加し、プレースホルダーを返す
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	var from, rank, snippet string
+	var conditions []string
+	switch query.Mode {
+	case domain_todo.TodoSearchModeFullText:
+		// websearch_to_tsqueryは不正な構文でもエラーにならないため、入力をそのまま渡せる
+		from = `todos, websearch_to_tsquery('simple', ` + arg(query.Query) + `) AS q`
+		rank = `ts_rank_cd(description_tsv, q)::float8`
+		snippet = `ts_headline('simple', description, q, ` + arg(todoHeadlineOptions) + `)`
+		conditions = []string{`description_tsv @@ q`}
+	case domain_todo.TodoSearchModeTrigram:
+		// 部分一致を優先し、表記揺れは単語類似度(pg_trgm.word_similarity_threshold以上)で拾う
+		q := arg(query.Query)
+		pattern := arg("%" + pkg_supabase.EscapeLike(query.Query) + "%")
+		from = `todos`
+		rank = `((CASE WHEN description ILIKE ` + pattern + ` THEN 1 ELSE 0 END) + word_similarity(` + q + `, description))::float8`
+		snippet = `''`
+		conditions = []string{`(description ILIKE ` + pattern + ` OR ` + q + ` <% description)`}
+	default:
+		return nil, fmt.Errorf("unknown todo search mode: %s", query.Mode)
+	}
+	if query.UserID != "" {
+		conditions = append(conditions, `user_id = `+arg(query.UserID)+`::uuid`)
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT %s, %s AS rank, %s AS snippet
+		FROM %s
+		WHERE %s
+		ORDER BY rank DESC, created_at DESC, id
+		LIMIT %s OFFSET %s
+	`, todoColumns, rank, snippet, from, strings.Join(conditions, " AND "), arg(query.Limit), arg(query.Offset))
+
+	// Supabaseからクエリを実行し、条件に一致するTodoを取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, sql, args...)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to search todos: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// 検索結果のリストを作成
+	results := []domain_todo.TodoSearchResult{}
+	for rows.Next() {
+		var result domain_todo.TodoSearchResult
+		err := rows.Scan(
+			&result.Todo.ID,
+			&result.Todo.Description,
+			&result.Todo.Completed,
+			&result.Todo.UserId,
+			&result.Todo.CreatedAt,
+			&result.Todo.UpdatedAt,
+			&result.Todo.Version,
+			&result.Todo.DueAt,
+			&result.Todo.RemindAt,
+			&result.Todo.Priority,
+			&result.Todo.ParentID,
+			&result.Rank,
+			&result.Snippet,
+		)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan todo: %v", err)
+			return nil, err
+		}
+		// トライグラム検索はts_headlineを使えないため、一致箇所を囲んだ抜粋を作成する
+		// 全文検索はts_headlineの結果をエスケープし、区切り文字を置き換える
+		if query.Mode == domain_todo.TodoSearchModeTrigram {
+			result.Snippet = domain_todo.HighlightSnippet(result.Todo.Description, query.Query)
+		} else {
+			result.Snippet = domain_todo.FormatHeadline(result.Snippet)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		r.Logger.ErrorLog.Printf("Failed to search todos: %v", err)
+		return nil, err
+	}
+
+	// タグをまとめて取得
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.Todo.ID
+	}
+	tags, err := r.loadTags(r.SupabaseClient.Pool, ids)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todo tags: %v", err)
+		return nil, err
+	}
+	for i := range results {
+		results[i].Todo.Tags = tags[results[i].Todo.ID]
+	}
+
+	r.Logger.InfoLog.Printf("Found %d todos", len(results))
+	return results, nil
+}
+
 // 新しいTodoを作成
-func (r *TodoRepositoryImpl) CreateTodo(todo domain_todo.Todo) (domain_todo.Todo, error) {
+func (r *TodoRepositoryImpl) CreateTodo(todo domain_todo.Todo, rule domain_todo.ParentCompletionRule) (domain_todo.Todo, error) {
 	r.Logger.InfoLog.Println("CreateTodo called")
 
 	query := `
-		INSERT INTO todos (description, completed, user_id)
-		VALUES ($1, $2, $3)
-		RETURNING id, description, completed, user_id, created_at, updated_at
-	`
+		INSERT INTO todos (description, completed, user_id, due_at, remind_at, priority, parent_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::uuid)
+		RETURNING ` + todoColumns
 
 	// トランザクション開始
 	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
@@ -152,20 +306,44 @@ func (r *TodoRepositoryImpl) CreateTodo(todo domain_todo.Todo) (domain_todo.Todo
 		}
 	}()
 
+	// 親のTodoを確認
+	err = r.lockTodoTrees(tx, todo.UserId)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to lock todo tree: %v", err)
+		return domain_todo.Todo{}, err
+	}
+	if todo.ParentID != nil {
+		err = r.checkParent(tx, *todo.ParentID, todo.UserId)
+		if err != nil {
+			return domain_todo.Todo{}, err
+		}
+	}
+
 	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	err = tx.QueryRow(r.SupabaseClient.Ctx, query, todo.Description, todo.Completed, todo.UserId).
-		Scan(&todo.ID,
-			&todo.Description,
-			&todo.Completed,
-			&todo.UserId,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
+	created, err := scanTodo(tx.QueryRow(r.SupabaseClient.Ctx, query, todo.Description, todo.Completed, todo.UserId, todo.DueAt, todo.RemindAt, todo.Priority, todo.ParentID))
 	if err != nil {
+		if pkg_supabase.IsForeignKeyViolation(err) {
+			r.Logger.ErrorLog.Printf("Todo owner not found: %s", todo.UserId)
+			err = repository_todo.ErrTodoOwnerNotFound
+			return domain_todo.Todo{}, err
+		}
 		r.Logger.ErrorLog.Printf("Failed to create todo: %v", err)
 		return domain_todo.Todo{}, err
 	}
 
+	// 階層を検証し、親のTodoの完了状態を更新
+	err = r.applyTodoTree(tx, nil, &created, rule)
+	if err != nil {
+		return domain_todo.Todo{}, err
+	}
+
+	// タグを付ける
+	created.Tags, err = r.replaceTags(tx, created.ID, created.UserId, domain_todo.TagIDs(todo.Tags))
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to set todo tags: %v", err)
+		return domain_todo.Todo{}, err
+	}
+
 	// トランザクションをコミット
 	err = tx.Commit(r.SupabaseClient.Ctx)
 	if err != nil {
@@ -176,20 +354,22 @@ func (r *TodoRepositoryImpl) CreateTodo(todo domain_todo.Todo) (domain_todo.Todo
 	// 正常系にし、ロールバックを防ぐ
 	err = nil
 
-	r.Logger.InfoLog.Printf("Created todo: %v", todo)
-	return todo, nil
+	r.Logger.InfoLog.Printf("Created todo: %v", created)
+	return created, nil
 }
 
 // 特定のTodoを更新
-func (r *TodoRepositoryImpl) UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo, error) {
+// todo.Versionが現在のバージョンと一致する場合のみ更新し、バージョンを1増やす(一致しない場合はErrTodoVersionConflict)。
+// 作成日時は変更せず、更新日時は現在日時にする。
+func (r *TodoRepositoryImpl) UpdateTodo(todo domain_todo.Todo, rule domain_todo.ParentCompletionRule) (domain_todo.Todo, error) {
 	r.Logger.InfoLog.Println("UpdateTodo called")
 
 	query := `
 		UPDATE todos
-		SET description = $1, completed = $2, user_id = $3, created_at = $4, updated_at = $5
-		WHERE id = $6
-		RETURNING id, description, completed, user_id, created_at, updated_at
-	`
+		SET description = $1, completed = $2, user_id = $3, due_at = $4, ` + todoRemindAtSet("$5") + `,
+			priority = $6, parent_id = $7::uuid, updated_at = now(), version = version + 1
+		WHERE id = $8 AND version = $9
+		RETURNING ` + todoColumns
 
 	// トランザクションを開始
 	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
@@ -204,20 +384,191 @@ func (r *TodoRepositoryImpl) UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo
 		}
 	}()
 
+	// 変更前の階層を取得し、親のTodoを確認
+	before, err := r.treeState(tx, todo.ID)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todo: %v", err)
+		return domain_todo.Todo{}, err
+	}
+	if before != nil {
+		err = r.lockTodoTrees(tx, before.UserID, todo.UserId)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to lock todo tree: %v", err)
+			return domain_todo.Todo{}, err
+		}
+		if todo.ParentID != nil {
+			err = r.checkParent(tx, *todo.ParentID, todo.UserId)
+			if err != nil {
+				return domain_todo.Todo{}, err
+			}
+		}
+	}
+
 	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	err = tx.QueryRow(r.SupabaseClient.Ctx, query, todo.Description, todo.Completed, todo.UserId, todo.CreatedAt, todo.UpdatedAt, todo.ID).
-		Scan(&todo.ID,
-			&todo.Description,
-			&todo.Completed,
-			&todo.UserId,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
+	updatedTodo, err := scanTodo(tx.QueryRow(r.SupabaseClient.Ctx, query, todo.Description, todo.Completed, todo.UserId, todo.DueAt, todo.RemindAt, todo.Priority, todo.ParentID, todo.ID, todo.Version))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// 存在しないか、他のクライアントに更新された
+			err = r.versionMismatch(tx, todo.ID)
+			return domain_todo.Todo{}, err
+		}
+		if pkg_supabase.IsForeignKeyViolation(err) {
+			r.Logger.ErrorLog.Printf("Todo owner not found: %s", todo.UserId)
+			err = repository_todo.ErrTodoOwnerNotFound
+			return domain_todo.Todo{}, err
+		}
 		r.Logger.ErrorLog.Printf("Failed to update todo: %v", err)
 		return domain_todo.Todo{}, err
 	}
 
+	// 階層を検証し、親のTodoの完了状態を更新
+	err = r.applyTodoTree(tx, before, &updatedTodo, rule)
+	if err != nil {
+		return domain_todo.Todo{}, err
+	}
+
+	// タグを置き換える
+	updatedTodo.Tags, err = r.replaceTags(tx, updatedTodo.ID, updatedTodo.UserId, domain_todo.TagIDs(todo.Tags))
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to set todo tags: %v", err)
+		return domain_todo.Todo{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_todo.Todo{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Updated todo: %v", updatedTodo)
+	return updatedTodo, nil
+}
+
+// 特定のTodoの指定した項目のみを更新
+// versionが現在のバージョンと一致する場合のみ更新し、バージョンを1増やす(一致しない場合はErrTodoVersionConflict)。
+// 作成日時は変更せず、更新日時は現在日時にする。
+func (r *TodoRepositoryImpl) PatchTodo(id string, version int64, patch domain_todo.TodoPatch, rule domain_todo.ParentCompletionRule) (domain_todo.Todo, error) {
+	r.Logger.InfoLog.Println("PatchTodo called")
+
+	sets := []string{}
+	args := []interface{}{}
+	// 値をargsに追加し、プレースホルダーを返す
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if patch.Description != nil {
+		sets = append(sets, `description = `+arg(*patch.Description))
+	}
+	if patch.Completed != nil {
+		sets = append(sets, `completed = `+arg(*patch.Completed))
+	}
+	if patch.UserID != nil {
+		sets = append(sets, `user_id = `+arg(*patch.UserID)+`::uuid`)
+	}
+	if patch.UpdateDueAt {
+		sets = append(sets, `due_at = `+arg(patch.DueAt))
+	}
+	if patch.UpdateRemindAt {
+		sets = append(sets, todoRemindAtSet(arg(patch.RemindAt)))
+	}
+	if patch.Priority != nil {
+		sets = append(sets, `priority = `+arg(*patch.Priority))
+	}
+	if patch.UpdateParent {
+		sets = append(sets, `parent_id = `+arg(patch.ParentID)+`::uuid`)
+	}
+	sets = append(sets, `updated_at = now()`, `version = version + 1`)
+
+	query := fmt.Sprintf(`
+		UPDATE todos
+		SET %s
+		WHERE id = %s AND version = %s
+		RETURNING %s
+	`, strings.Join(sets, ", "), arg(id), arg(version), todoColumns)
+
+	// トランザクションを開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_todo.Todo{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// 変更前の階層を取得し、親のTodoを確認
+	before, err := r.treeState(tx, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todo: %v", err)
+		return domain_todo.Todo{}, err
+	}
+	if before != nil {
+		userID, parentID := before.UserID, before.ParentID
+		if patch.UserID != nil {
+			userID = *patch.UserID
+		}
+		if patch.UpdateParent {
+			parentID = patch.ParentID
+		}
+		err = r.lockTodoTrees(tx, before.UserID, userID)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to lock todo tree: %v", err)
+			return domain_todo.Todo{}, err
+		}
+		if parentID != nil && (patch.UpdateParent || patch.UserID != nil) {
+			err = r.checkParent(tx, *parentID, userID)
+			if err != nil {
+				return domain_todo.Todo{}, err
+			}
+		}
+	}
+
+	// Supabaseからクエリを実行し、指定した項目を更新
+	todo, err := scanTodo(tx.QueryRow(r.SupabaseClient.Ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// 存在しないか、他のクライアントに更新された
+			err = r.versionMismatch(tx, id)
+			return domain_todo.Todo{}, err
+		}
+		if pkg_supabase.IsForeignKeyViolation(err) {
+			r.Logger.ErrorLog.Printf("Todo owner not found: %s", id)
+			err = repository_todo.ErrTodoOwnerNotFound
+			return domain_todo.Todo{}, err
+		}
+		r.Logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+		return domain_todo.Todo{}, err
+	}
+
+	// 階層を検証し、親のTodoの完了状態を更新
+	err = r.applyTodoTree(tx, before, &todo, rule)
+	if err != nil {
+		return domain_todo.Todo{}, err
+	}
+
+	// タグを置き換える(所有者を変更した場合は、新しい所有者のタグ以外を外す)
+	tagIDs := patch.TagIDs
+	if !patch.UpdateTags {
+		tagIDs, err = r.ownedTagIDs(tx, todo.ID, todo.UserId)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to fetch todo tags: %v", err)
+			return domain_todo.Todo{}, err
+		}
+	}
+	todo.Tags, err = r.replaceTags(tx, todo.ID, todo.UserId, tagIDs)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to set todo tags: %v", err)
+		return domain_todo.Todo{}, err
+	}
+
 	// トランザクションをコミット
 	err = tx.Commit(r.SupabaseClient.Ctx)
 	if err != nil {
@@ -228,17 +579,18 @@ func (r *TodoRepositoryImpl) UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo
 	// 正常系にし、ロールバックを防ぐ
 	err = nil
 
-	r.Logger.InfoLog.Printf("Updated todo: %v", todo)
+	r.Logger.InfoLog.Printf("Patched todo: %v", todo)
 	return todo, nil
 }
 
 // 特定のTodoを削除
-func (r *TodoRepositoryImpl) DeleteTodo(id string) error {
+// versionが現在のバージョンと一致する場合のみ削除する(一致しない場合はErrTodoVersionConflict)。
+func (r *TodoRepositoryImpl) DeleteTodo(id string, version int64, rule domain_todo.ParentCompletionRule) error {
 	r.Logger.InfoLog.Println("DeleteTodo called")
 
 	query := `
 		DELETE FROM todos
-		WHERE id = $1
+		WHERE id = $1 AND version = $2
 	`
 
 	// トランザクションを開始
@@ -254,12 +606,40 @@ func (r *TodoRepositoryImpl) DeleteTodo(id string) error {
 		}
 	}()
 
-	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	_, err = tx.Exec(r.SupabaseClient.Ctx, query, id)
+	// 変更前の階層を取得
+	before, err := r.treeState(tx, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch todo: %v", err)
+		return err
+	}
+	if before != nil {
+		err = r.lockTodoTrees(tx, before.UserID)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to lock todo tree: %v", err)
+			return err
+		}
+	}
+
+	// Supabaseからクエリを実行し、条件に一致するユーザーを取得(サブタスクも削除される)
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, query, id, version)
 	if err != nil {
 		r.Logger.ErrorLog.Printf("Failed to delete todo: %v", err)
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		// 存在しないか、他のクライアントに更新された
+		err = r.versionMismatch(tx, id)
+		return err
+	}
+
+	// 親のTodoの完了状態を更新
+	if rule == domain_todo.ParentCompletionAuto && before != nil {
+		err = r.syncParentCompletion(tx, before.ParentID)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to update parent todo: %v", err)
+			return err
+		}
+	}
 
 	// トランザクションをコミット
 	err = tx.Commit(r.SupabaseClient.Ctx)
@@ -274,3 +654,689 @@ func (r *TodoRepositoryImpl) DeleteTodo(id string) error {
 	r.Logger.InfoLog.Printf("Deleted todo: %v", id)
 	return nil
 }
+
+// 通知日時を過ぎた未送信のリマインダーを処理
+// 対象の行はreminder_claimed_atに取得日時を記録して確保(FOR UPDATE SKIP LOCKEDで選択)し、すぐにコミットする。
+// 通知はトランザクションの外で行うため、送信中もTodoの更新・削除をブロックしない。
+// 確保した行はleaseの間は他のサーバーから取得されず、結果を記録できずに期限が切れた行は再度取得される。
+// dispatchが成功した行は送信済みにし、失敗した行は失敗回数を増やす(maxAttempts回失敗した行は以降対象としない)。
+func (r *TodoRepositoryImpl) ProcessDueReminders(limit int, maxAttempts int, lease time.Duration, dispatch func(todo domain_todo.Todo) error) (int, int, error) {
+	r.Logger.InfoLog.Println("ProcessDueReminders called")
+
+	claimQuery := `
+		UPDATE todos
+		SET reminder_claimed_at = $3
+		WHERE id IN (
+			SELECT id
+			FROM todos
+			WHERE remind_at <= now()
+				AND reminded_at IS NULL
+				AND completed = false
+				AND reminder_attempts < $1
+				AND (reminder_claimed_at IS NULL OR reminder_claimed_at < $4)
+			ORDER BY remind_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + todoColumns + `
+	`
+	// 確保した後に通知日時が変更された(reminder_claimed_atが戻された)行には記録しない
+	sentQuery := `
+		UPDATE todos
+		SET reminded_at = now(), reminder_claimed_at = NULL
+		WHERE id = $1 AND reminder_claimed_at = $2
+	`
+	failedQuery := `
+		UPDATE todos
+		SET reminder_attempts = reminder_attempts + 1, reminder_claimed_at = NULL
+		WHERE id = $1 AND reminder_claimed_at = $2
+	`
+
+	// 送信するリマインダーを確保(1文で実行し、ロックはすぐに解放する)
+	// 記録時に自分が確保した行か判定できるよう、確保日時はマイクロ秒(timestamptzの精度)に丸める
+	claimedAt := time.Now().UTC().Truncate(time.Microsecond)
+	todos, err := r.queryTodos(r.SupabaseClient.Pool, claimQuery, maxAttempts, limit, claimedAt, claimedAt.Add(-lease))
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to claim due reminders: %v", err)
+		return 0, 0, err
+	}
+
+	// リマインダーを送信し、結果を1件ずつ記録
+	// 記録に失敗した行は確保の期限が切れた後に再度送信される
+	sent, failed := 0, 0
+	for _, todo := range todos {
+		query := sentQuery
+		if dispatchErr := dispatch(todo); dispatchErr != nil {
+			r.Logger.ErrorLog.Printf("Failed to dispatch reminder for todo %s: %v", todo.ID, dispatchErr)
+			query = failedQuery
+			failed++
+		} else {
+			sent++
+		}
+		tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, todo.ID, claimedAt)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to record reminder result for todo %s: %v", todo.ID, err)
+			continue
+		}
+		if tag.RowsAffected() == 0 {
+			r.Logger.InfoLog.Printf("Reminder for todo %s was changed while dispatching", todo.ID)
+		}
+	}
+
+	if len(todos) > 0 {
+		r.Logger.InfoLog.Printf("Processed reminders: %d sent, %d failed", sent, failed)
+	}
+	return sent, failed, nil
+}
+
+// Todoの一覧を取得するクエリを実行(コネクションプール・トランザクション共通)
+func (r *TodoRepositoryImpl) queryTodos(q todoQuerier, query string, args ...interface{}) ([]domain_todo.Todo, error) {
+	rows, err := q.Query(r.SupabaseClient.Ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	todos := []domain_todo.Todo{}
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, todo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+// バージョンを指定した更新・削除で対象の行がなかった理由を返す
+// Todoが存在すればErrTodoVersionConflict、存在しなければErrTodoNotFoundを返す。
+func (r *TodoRepositoryImpl) versionMismatch(tx pgx.Tx, id string) error {
+	var exists bool
+	err := tx.QueryRow(r.SupabaseClient.Ctx, `SELECT EXISTS (SELECT 1 FROM todos WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check todo: %v", err)
+		return err
+	}
+	if !exists {
+		r.Logger.ErrorLog.Printf("Todo not found: %s", id)
+		return repository_todo.ErrTodoNotFound
+	}
+	r.Logger.ErrorLog.Printf("Todo version conflict: %s", id)
+	return repository_todo.ErrTodoVersionConflict
+}
+
+// 条件に一致するTodoをキーセットで取得
+// page.OrderByの順(同順位はIDの昇順)に並べ、page.Afterより後ろの行をpage.Limit件まで返す。
+// 値は全てプレースホルダーで渡し、並び替えの列名はtodoOrderColumnsに定義されたもののみ使用する。
+func (r *TodoRepositoryImpl) listTodos(filter domain_todo.TodoFilter, page domain_todo.TodoPageQuery) ([]domain_todo.Todo, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	// 値をargsに追加し、プレースホルダーを返す
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// 絞り込み条件
+	if filter.UserID != "" {
+		conditions = append(conditions, `user_id = `+arg(filter.UserID)+`::uuid`)
+	}
+	if filter.Completed != nil {
+		conditions = append(conditions, `completed = `+arg(*filter.Completed))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, `created_at >= `+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, `created_at < `+arg(*filter.CreatedTo))
+	}
+	if filter.UpdatedFrom != nil {
+		conditions = append(conditions, `updated_at >= `+arg(*filter.UpdatedFrom))
+	}
+	if filter.UpdatedTo != nil {
+		conditions = append(conditions, `updated_at < `+arg(*filter.UpdatedTo))
+	}
+	if filter.DueFrom != nil {
+		conditions = append(conditions, `due_at >= `+arg(*filter.DueFrom))
+	}
+	if filter.DueTo != nil {
+		conditions = append(conditions, `due_at < `+arg(*filter.DueTo))
+	}
+	if filter.Description != "" {
+		conditions = append(conditions, `description ILIKE `+arg("%"+pkg_supabase.EscapeLike(filter.Description)+"%"))
+	}
+	if filter.Priority != nil {
+		conditions = append(conditions, `priority = `+arg(*filter.Priority))
+	}
+	if len(filter.TagIDs) > 0 {
+		tagIDs := arg(filter.TagIDs) + `::uuid[]`
+		if filter.TagMatch == domain_todo.TagMatchAll {
+			// 指定した全てのタグが付いている(TagIDsは重複していないこと)
+			conditions = append(conditions, `(
+				SELECT count(*) FROM todo_tags tt WHERE tt.todo_id = todos.id AND tt.tag_id = ANY(`+tagIDs+`)
+			) = `+arg(len(filter.TagIDs)))
+		} else {
+			// 指定したいずれかのタグが付いている
+			conditions = append(conditions, `EXISTS (
+				SELECT 1 FROM todo_tags tt WHERE tt.todo_id = todos.id AND tt.tag_id = ANY(`+tagIDs+`)
+			)`)
+		}
+	}
+
+	// 並び順(同順位はIDの昇順)
+	orders := page.OrderBy
+	if len(orders) == 0 {
+		orders = domain_todo.DefaultTodoOrder
+	}
+	orderBy := make([]string, 0, len(orders)+1)
+	for _, order := range orders {
+		column, ok := todoOrderColumns[order.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown todo order field: %s", order.Field)
+		}
+		if order.Descending {
+			column += " DESC"
+		}
+		orderBy = append(orderBy, column)
+	}
+	orderBy = append(orderBy, "id")
+
+	// キーセット(前ページの最後の行より後ろ)
+	// 並び順の項目を(a, b, id)とすると a > $1 OR (a = $1 AND b > $2) OR (a = $1 AND b = $2 AND id > $3) となる。
+	if page.After != nil {
+		keys := append(append([]domain_todo.TodoOrder{}, orders...), domain_todo.TodoOrder{})
+		alternatives := make([]string, 0, len(keys))
+		for i, key := range keys {
+			terms := make([]string, 0, i+1)
+			for _, prev := range keys[:i] {
+				column, placeholder, err := todoCursorTerm(prev.Field, page.After, arg)
+				if err != nil {
+					return nil, err
+				}
+				terms = append(terms, column+" = "+placeholder)
+			}
+			column, placeholder, err := todoCursorTerm(key.Field, page.After, arg)
+			if err != nil {
+				return nil, err
+			}
+			comparison := " > "
+			if key.Descending {
+				comparison = " < "
+			}
+			terms = append(terms, column+comparison+placeholder)
+			alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM todos
+		%s
+		ORDER BY %s
+		LIMIT %s
+	`, todoColumns, where, strings.Join(orderBy, ", "), arg(page.Limit))
+
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Todosのリストを作成
+	todos := []domain_todo.Todo{}
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan todo: %v", err)
+			return nil, err
+		}
+		todos = append(todos, todo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// タグをまとめて取得
+	ids := make([]string, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	tags, err := r.loadTags(r.SupabaseClient.Pool, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range todos {
+		todos[i].Tags = tags[todos[i].ID]
+	}
+
+	return todos, nil
+}
+
+// Todo・タグの取得に使用するクエリの実行(コネクションプール・トランザクション共通)
+type todoQuerier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// Todoに付いたタグをまとめて取得(TodoのIDごとに名前順)
+// 一覧のTodoごとにクエリを実行しないよう、1回のクエリで取得する。
+func (r *TodoRepositoryImpl) loadTags(q todoQuerier, todoIDs []string) (map[string][]domain_todo.Tag, error) {
+	tags := map[string][]domain_todo.Tag{}
+	if len(todoIDs) == 0 {
+		return tags, nil
+	}
+
+	query := `
+		SELECT tt.todo_id, t.id, t.user_id, t.name, t.color, t.created_at, t.updated_at
+		FROM todo_tags tt
+		JOIN tags t ON t.id = tt.tag_id
+		WHERE tt.todo_id = ANY($1::uuid[])
+		ORDER BY lower(t.name), t.id
+	`
+	rows, err := q.Query(r.SupabaseClient.Ctx, query, todoIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var todoID string
+		var tag domain_todo.Tag
+		err := rows.Scan(&todoID, &tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		tags[todoID] = append(tags[todoID], tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// Todoのタグを置き換え、置き換え後のタグを返す
+// タグはTodoの所有者のもののみ付けられる(存在しない・他のユーザーのタグはErrTagNotFound)。tagIDsは重複していないこと。
+func (r *TodoRepositoryImpl) replaceTags(tx pgx.Tx, todoID string, userID string, tagIDs []string) ([]domain_todo.Tag, error) {
+	countQuery := `
+		SELECT count(*)
+		FROM tags
+		WHERE id = ANY($1::uuid[]) AND user_id = $2
+	`
+	deleteQuery := `
+		DELETE FROM todo_tags
+		WHERE todo_id = $1
+	`
+	insertQuery := `
+		INSERT INTO todo_tags (todo_id, tag_id)
+		SELECT $1::uuid, unnest($2::uuid[])
+	`
+
+	// タグの所有者を確認
+	if len(tagIDs) > 0 {
+		var count int
+		err := tx.QueryRow(r.SupabaseClient.Ctx, countQuery, tagIDs, userID).Scan(&count)
+		if err != nil {
+			if pkg_supabase.IsInvalidTextRepresentation(err) {
+				return nil, repository_todo.ErrTagNotFound
+			}
+			return nil, err
+		}
+		if count != len(tagIDs) {
+			return nil, repository_todo.ErrTagNotFound
+		}
+	}
+
+	// タグを置き換える
+	if _, err := tx.Exec(r.SupabaseClient.Ctx, deleteQuery, todoID); err != nil {
+		return nil, err
+	}
+	if len(tagIDs) > 0 {
+		if _, err := tx.Exec(r.SupabaseClient.Ctx, insertQuery, todoID, tagIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := r.loadTags(tx, []string{todoID})
+	if err != nil {
+		return nil, err
+	}
+	return tags[todoID], nil
+}
+
+// Todoに付いたタグのうち、指定したユーザーのタグのIDを取得
+func (r *TodoRepositoryImpl) ownedTagIDs(tx pgx.Tx, todoID string, userID string) ([]string, error) {
+	query := `
+		SELECT tt.tag_id
+		FROM todo_tags tt
+		JOIN tags t ON t.id = tt.tag_id
+		WHERE tt.todo_id = $1 AND t.user_id = $2
+	`
+	rows, err := tx.Query(r.SupabaseClient.Ctx, query, todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tagIDs := []string{}
+	for rows.Next() {
+		var tagID string
+		if err := rows.Scan(&tagID); err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, tagID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tagIDs, nil
+}
+
+// Todoの変更前の状態を取得(存在しない場合はnil)
+func (r *TodoRepositoryImpl) treeState(tx pgx.Tx, id string) (*domain_todo.TodoTreeState, error) {
+	var state domain_todo.TodoTreeState
+	err := tx.QueryRow(r.SupabaseClient.Ctx, `SELECT user_id, parent_id, completed FROM todos WHERE id = $1`, id).
+		Scan(&state.UserID, &state.ParentID, &state.Completed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+// 所有者ごとにTodoの階層の変更を直列化する(トランザクションの終了まで)
+// 同時に親を付け替えて循環することや、親の完了状態の判定が競合することを防ぐ。デッドロックを防ぐため、ユーザーIDの順にロックする。
+func (r *TodoRepositoryImpl) lockTodoTrees(tx pgx.Tx, userIDs ...string) error {
+	sorted := append([]string{}, userIDs...)
+	sort.Strings(sorted)
+	for i, userID := range sorted {
+		if userID == "" || (i > 0 && sorted[i-1] == userID) {
+			continue
+		}
+		if _, err := tx.Exec(r.SupabaseClient.Ctx, `SELECT pg_advisory_xact_lock(hashtext('todo_tree:' || $1))`, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 親のTodoが存在し、同じ所有者であることを確認
+// 他のユーザーのTodoは存在を推測されないよう、存在しない場合と同じErrTodoParentNotFoundを返す。
+func (r *TodoRepositoryImpl) checkParent(tx pgx.Tx, parentID string, userID string) error {
+	var ownerID string
+	err := tx.QueryRow(r.SupabaseClient.Ctx, `SELECT user_id FROM todos WHERE id = $1`, parentID).Scan(&ownerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || pkg_supabase.IsInvalidTextRepresentation(err) {
+			r.Logger.ErrorLog.Printf("Parent todo not found: %s", parentID)
+			return repository_todo.ErrTodoParentNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch parent todo: %v", err)
+		return err
+	}
+	if ownerID != userID {
+		r.Logger.ErrorLog.Printf("Parent todo %s is not owned by user: %s", parentID, userID)
+		return repository_todo.ErrTodoParentNotFound
+	}
+	return nil
+}
+
+// 書き込み後のTodoの階層を検証し、ruleに従って親のTodoの完了状態を扱う
+// beforeは変更前の状態(作成の場合はnil)。lockTodoTreesで所有者をロックしてから呼び出すこと。
+// ParentCompletionAutoでafter自身の完了状態をサブタスクに合わせた場合は、afterの完了状態・更新日時・バージョンを更新する。
+func (r *TodoRepositoryImpl) applyTodoTree(tx pgx.Tx, before *domain_todo.TodoTreeState, after *domain_todo.Todo, rule domain_todo.ParentCompletionRule) error {
+	change := domain_todo.TodoTreeChange{
+		Before: before,
+		After:  domain_todo.TodoTreeState{UserID: after.UserId, ParentID: after.ParentID, Completed: after.Completed},
+	}
+
+	// 付け替えた場合は、循環・深さ・サブタスクの所有者を検証
+	if change.NeedsTreeCheck() {
+		if err := r.checkTodoTree(tx, *after); err != nil {
+			return err
+		}
+	}
+
+	// 完了済みの親に未完了のサブタスクを追加・付け替え・再開できない
+	if rule.ChecksCompletedParent(change) {
+		var parentCompleted bool
+		err := tx.QueryRow(r.SupabaseClient.Ctx, `SELECT completed FROM todos WHERE id = $1`, *after.ParentID).Scan(&parentCompleted)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to check parent todo: %v", err)
+			return err
+		}
+		if parentCompleted {
+			r.Logger.ErrorLog.Printf("Parent todo is completed: %s", *after.ParentID)
+			return repository_todo.ErrTodoParentCompleted
+		}
+	}
+	// 未完了のサブタスクがあれば完了にできない
+	if rule.ChecksOpenSubtasks(change) {
+		children, err := r.childCompletions(tx, after.ID)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to check subtasks: %v", err)
+			return err
+		}
+		if domain_todo.HasOpenSubtask(children) {
+			r.Logger.ErrorLog.Printf("Todo has open subtasks: %s", after.ID)
+			return repository_todo.ErrTodoHasOpenSubtasks
+		}
+	}
+
+	// サブタスクのあるTodo自身の完了状態を変更した場合は、サブタスクに合わせて戻す
+	if rule.SyncsSelf(change) {
+		if err := r.syncParentCompletion(tx, &after.ID); err != nil {
+			r.Logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			return err
+		}
+		err := tx.QueryRow(r.SupabaseClient.Ctx, `SELECT completed, updated_at, version FROM todos WHERE id = $1`, after.ID).
+			Scan(&after.Completed, &after.UpdatedAt, &after.Version)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to fetch todo: %v", err)
+			return err
+		}
+	}
+	// 親(付け替えた場合は元の親も)の完了状態をサブタスクに合わせる
+	for _, parentID := range rule.SyncedParents(change) {
+		parentID := parentID
+		if err := r.syncParentCompletion(tx, &parentID); err != nil {
+			r.Logger.ErrorLog.Printf("Failed to update parent todo: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Todoの階層を検証
+// 親に自身・自身の子孫を指定していないこと、階層の深さがMaxTodoDepth以下であること、サブタスクが同じ所有者であることを確認する。
+func (r *TodoRepositoryImpl) checkTodoTree(tx pgx.Tx, todo domain_todo.Todo) error {
+	// 祖先は自身に戻るか、最大の深さを超えるまでたどる。高さは自身を1とした子孫の階層の数。
+	query := `
+		WITH RECURSIVE ancestors (id, parent_id, depth) AS (
+			SELECT id, parent_id, 1 FROM todos WHERE id = $1
+			UNION ALL
+			SELECT t.id, t.parent_id, a.depth + 1
+			FROM todos t
+			JOIN ancestors a ON t.id = a.parent_id
+			WHERE a.depth <= $2 AND a.parent_id <> $1::uuid
+		), descendants (id, height) AS (
+			SELECT id, 1 FROM todos WHERE id = $1
+			UNION ALL
+			SELECT t.id, d.height + 1
+			FROM todos t
+			JOIN descendants d ON t.parent_id = d.id
+			WHERE d.height <= $2
+		)
+		SELECT
+			COALESCE((SELECT array_agg(parent_id::text ORDER BY depth) FROM ancestors WHERE parent_id IS NOT NULL), '{}'),
+			(SELECT max(height) FROM descendants),
+			EXISTS (SELECT 1 FROM todos WHERE parent_id = $1::uuid AND user_id <> $3::uuid)
+	`
+
+	placement := domain_todo.TodoPlacement{ID: todo.ID}
+	var ownerMismatch bool
+	err := tx.QueryRow(r.SupabaseClient.Ctx, query, todo.ID, domain_todo.MaxTodoDepth, todo.UserId).
+		Scan(&placement.AncestorIDs, &placement.Height, &ownerMismatch)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check todo tree: %v", err)
+		return err
+	}
+	switch {
+	case placement.HasCycle():
+		r.Logger.ErrorLog.Printf("Todo parent cycle: %s", todo.ID)
+		return repository_todo.ErrTodoParentCycle
+	case placement.TooDeep():
+		r.Logger.ErrorLog.Printf("Todo tree too deep: %s", todo.ID)
+		return repository_todo.ErrTodoTreeTooDeep
+	case ownerMismatch:
+		r.Logger.ErrorLog.Printf("Todo %s has subtasks of another user", todo.ID)
+		return repository_todo.ErrTodoSubtaskOwnerMismatch
+	}
+	return nil
+}
+
+// サブタスクの完了状態を取得
+func (r *TodoRepositoryImpl) childCompletions(tx pgx.Tx, parentID string) ([]bool, error) {
+	rows, err := tx.Query(r.SupabaseClient.Ctx, `SELECT completed FROM todos WHERE parent_id = $1`, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	completions := []bool{}
+	for rows.Next() {
+		var completed bool
+		if err := rows.Scan(&completed); err != nil {
+			return nil, err
+		}
+		completions = append(completions, completed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return completions, nil
+}
+
+// 親のTodoの完了状態をサブタスクに合わせる(ParentCompletionAuto)
+// domain_todo.SyncedCompletionに従って更新し、変更した場合は更に上の親に伝える。
+func (r *TodoRepositoryImpl) syncParentCompletion(tx pgx.Tx, parentID *string) error {
+	for depth := 0; parentID != nil && depth < domain_todo.MaxTodoDepth; depth++ {
+		var current bool
+		var next *string
+		err := tx.QueryRow(r.SupabaseClient.Ctx, `SELECT completed, parent_id FROM todos WHERE id = $1`, *parentID).
+			Scan(&current, &next)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		children, err := r.childCompletions(tx, *parentID)
+		if err != nil {
+			return err
+		}
+		completed := domain_todo.SyncedCompletion(current, children)
+		if completed == current {
+			// 変更がなければ上の親も変わらない
+			return nil
+		}
+		_, err = tx.Exec(r.SupabaseClient.Ctx,
+			`UPDATE todos SET completed = $2, updated_at = now(), version = version + 1 WHERE id = $1`, *parentID, completed)
+		if err != nil {
+			return err
+		}
+		parentID = next
+	}
+	return nil
+}
+
+// リマインダーの通知日時を更新するSET句
+// 通知日時を変更した場合は、送信済み・失敗回数・送信中の確保を戻して新しい日時に再度通知する。
+// SET句の右辺の列は更新前の値を参照する。
+func todoRemindAtSet(placeholder string) string {
+	return `remind_at = ` + placeholder + `,
+			reminded_at = CASE WHEN remind_at IS DISTINCT FROM ` + placeholder + ` THEN NULL ELSE reminded_at END,
+			reminder_attempts = CASE WHEN remind_at IS DISTINCT FROM ` + placeholder + ` THEN 0 ELSE reminder_attempts END,
+			reminder_claimed_at = CASE WHEN remind_at IS DISTINCT FROM ` + placeholder + ` THEN NULL ELSE reminder_claimed_at END`
+}
+
+// 並び替えの項目に対応する列
+var todoOrderColumns = map[string]string{
+	domain_todo.TodoOrderCreatedAt:   "created_at",
+	domain_todo.TodoOrderUpdatedAt:   "updated_at",
+	domain_todo.TodoOrderDescription: "description",
+	domain_todo.TodoOrderCompleted:   "completed",
+	domain_todo.TodoOrderPriority:    "priority",
+	domain_todo.TodoOrderDueAt:       "due_at",
+}
+
+// キーセットの比較に使用する列とプレースホルダーを返す
+// fieldが空の場合はIDの列を返す。キーセットに値がない場合はエラーを返す。
+func todoCursorTerm(field string, cursor *domain_todo.TodoCursor, arg func(interface{}) string) (string, string, error) {
+	switch field {
+	case "":
+		return "id", arg(cursor.ID) + "::uuid", nil
+	case domain_todo.TodoOrderCreatedAt:
+		return "created_at", arg(cursor.CreatedAt), nil
+	case domain_todo.TodoOrderUpdatedAt:
+		if cursor.UpdatedAt != nil {
+			return "updated_at", arg(*cursor.UpdatedAt), nil
+		}
+	case domain_todo.TodoOrderDescription:
+		if cursor.Description != nil {
+			return "description", arg(*cursor.Description), nil
+		}
+	case domain_todo.TodoOrderCompleted:
+		if cursor.Completed != nil {
+			return "completed", arg(*cursor.Completed), nil
+		}
+	case domain_todo.TodoOrderPriority:
+		if cursor.Priority != nil {
+			return "priority", arg(*cursor.Priority), nil
+		}
+	case domain_todo.TodoOrderDueAt:
+		if cursor.DueAt != nil {
+			return "due_at", arg(*cursor.DueAt), nil
+		}
+	}
+	return "", "", fmt.Errorf("todo cursor has no value for: %s", field)
+}
+
+// 全文検索のスニペットの作成オプション(ts_headline)
+// ts_headlineはHTMLエスケープしないため、一致箇所は制御文字で囲み、取得後にdomain_todo.FormatHeadlineで整形する。
+var todoHeadlineOptions = fmt.Sprintf(
+	"StartSel=\"%s\", StopSel=\"%s\", MaxWords=20, MinWords=5, MaxFragments=2, FragmentDelimiter=\" … \"",
+	domain_todo.HeadlineStartSel, domain_todo.HeadlineStopSel,
+)
+
+// Todoの取得カラム
+const todoColumns = `id, description, completed, user_id, created_at, updated_at, version, due_at, remind_at, priority, parent_id`
+
+// Todoの行をスキャン
+func scanTodo(row pgx.Row) (domain_todo.Todo, error) {
+	var todo domain_todo.Todo
+	err := row.Scan(
+		&todo.ID,
+		&todo.Description,
+		&todo.Completed,
+		&todo.UserId,
+		&todo.CreatedAt,
+		&todo.UpdatedAt,
+		&todo.Version,
+		&todo.DueAt,
+		&todo.RemindAt,
+		&todo.Priority,
+		&todo.ParentID,
+	)
+	return todo, err
+}
@@ -1,10 +1,20 @@
 package infrastructure_user
 
 import (
+	domain_audit "backend/internal/domain/audit"
 	domain_user "backend/internal/domain/user"
+	infrastructure_audit "backend/internal/infrastructure/audit"
+	infrastructure_auth "backend/internal/infrastructure/auth"
 	pkg_logger "backend/internal/pkg/logger"
 	pkg_supabase "backend/internal/pkg/supabase"
 	repository_user "backend/internal/repository/user"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
 )
 
 // ユーザーリポジトリ(Impl)
@@ -59,3 +69,598 @@ func (r *UserRepositoryImpl) GetAllUsers() ([]domain_user.Users, error) {
 	r.Logger.InfoLog.Printf("Fetched %d users successfully.", len(users))
 	return users, nil
 }
+
+// 条件に一致するユーザーをキーセットで取得
+// 作成日時とIDの組で並べ、query.Afterより後ろの行をquery.Limit件まで返す。
+func (r *UserRepositoryImpl) ListUsers(query domain_user.ListUsersQuery) ([]domain_user.Users, error) {
+	r.Logger.InfoLog.Println("ListUsers called")
+
+	conditions := []string{}
+	args := []interface{}{}
+
+	// ユーザー名・メールアドレスの部分一致
+	if query.Search != "" {
+		args = append(args, "%"+pkg_supabase.EscapeLike(query.Search)+"%")
+		n := len(args)
+		conditions = append(conditions, fmt.Sprintf(`(username ILIKE $%d OR email ILIKE $%d)`, n, n))
+	}
+
+	// キーセット(前ページの最後の行より後ろ)
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) %s ($%d, $%d::uuid)`, comparison, len(args)-1, len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, query.Limit)
+	sql := fmt.Sprintf(`
+        SELECT %s
+        FROM users
+        %s
+        ORDER BY created_at %s, id %s
+        LIMIT $%d
+    `, userColumns, where, direction, direction, len(args))
+
+	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
+	rows, err := r.SupabaseClient.Pool.Query(r.SupabaseClient.Ctx, sql, args...)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to fetch users: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// ユーザーのリストを作成
+	users := []domain_user.Users{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to scan user: %v", err)
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	r.Logger.InfoLog.Printf("Fetched %d users successfully.", len(users))
+	return users, nil
+}
+
+// ユーザーを作成し、ロールを付与
+// ユーザー名またはメールアドレスが重複する場合はErrUserAlreadyExistsを返す。
+func (r *UserRepositoryImpl) CreateUser(user domain_user.Users, role string) (domain_user.Users, error) {
+	r.Logger.InfoLog.Println("CreateUser called")
+
+	query := `
+        INSERT INTO users (username, email, password)
+        VALUES ($1, $2, $3)
+        RETURNING id, username, email, created_at, updated_at
+    `
+	assignRoleQuery := `
+        INSERT INTO user_roles (user_id, role, granted_by)
+        VALUES ($1, $2, 'register')
+    `
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_user.Users{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// Supabaseからクエリを実行し、ユーザーを作成
+	var created domain_user.Users
+	err = tx.QueryRow(r.SupabaseClient.Ctx, query, user.Username, user.Email, user.Password).
+		Scan(&created.ID,
+			&created.Username,
+			&created.Email,
+			&created.CreatedAt,
+			&created.UpdatedAt,
+		)
+	if err != nil {
+		if pkg_supabase.IsUniqueViolation(err) {
+			r.Logger.ErrorLog.Printf("User already exists: %v", err)
+			err = repository_user.ErrUserAlreadyExists
+			return domain_user.Users{}, err
+		}
+		r.Logger.ErrorLog.Printf("Failed to create user: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	// ロールを付与
+	_, err = tx.Exec(r.SupabaseClient.Ctx, assignRoleQuery, created.ID, role)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to assign role: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Created user: %s", created.ID)
+	return created, nil
+}
+
+// 特定のユーザーを取得
+func (r *UserRepositoryImpl) GetUserById(id string) (domain_user.Users, error) {
+	r.Logger.InfoLog.Printf("Fetching user by id: %s", id)
+
+	query := `
+        SELECT ` + userColumns + `
+        FROM users
+        WHERE id = $1
+    `
+
+	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
+	user, err := scanUser(r.SupabaseClient.Pool.QueryRow(r.SupabaseClient.Ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Printf("User not found: %s", id)
+			return domain_user.Users{}, repository_user.ErrUserNotFound
+		}
+		r.Logger.ErrorLog.Printf("Failed to fetch user: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	r.Logger.InfoLog.Println("Fetched user successfully. 1 user found")
+	return user, nil
+}
+
+// ユーザー名・メールアドレスを更新
+// メールアドレスが変わった場合は確認状態を未確認に戻す。
+// ユーザー名またはメールアドレスが重複する場合はErrUserAlreadyExistsを返す。
+func (r *UserRepositoryImpl) UpdateUser(user domain_user.Users) (domain_user.Users, error) {
+	r.Logger.InfoLog.Println("UpdateUser called")
+
+	query := `
+        UPDATE users
+        SET username = $2,
+            email = $3,
+            email_verification_status = CASE WHEN lower(email) = lower($3) THEN email_verification_status ELSE 'unverified' END,
+            email_verification_sent_at = CASE WHEN lower(email) = lower($3) THEN email_verification_sent_at ELSE NULL END,
+            email_verified_at = CASE WHEN lower(email) = lower($3) THEN email_verified_at ELSE NULL END,
+            updated_at = now()
+        WHERE id = $1
+        RETURNING ` + userColumns
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_user.Users{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// Supabaseからクエリを実行し、ユーザーを更新
+	updated, err := scanUser(tx.QueryRow(r.SupabaseClient.Ctx, query, user.ID, user.Username, user.Email))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Printf("User not found: %s", user.ID)
+			err = repository_user.ErrUserNotFound
+			return domain_user.Users{}, err
+		}
+		if pkg_supabase.IsUniqueViolation(err) {
+			r.Logger.ErrorLog.Printf("User already exists: %v", err)
+			err = repository_user.ErrUserAlreadyExists
+			return domain_user.Users{}, err
+		}
+		r.Logger.ErrorLog.Printf("Failed to update user: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Updated user: %s", updated.ID)
+	return updated, nil
+}
+
+// ユーザーを無効化
+// 無効化と同時にセッション・リフレッシュトークンを失効させ、発行済みのトークンを使用できなくする。
+// 最後の有効な管理者は無効化できない(ErrLastAdmin)。
+func (r *UserRepositoryImpl) DeactivateUser(id string) error {
+	r.Logger.InfoLog.Println("DeactivateUser called")
+
+	deactivateQuery := `
+        UPDATE users
+        SET deactivated_at = now(), updated_at = now()
+        WHERE id = $1 AND deactivated_at IS NULL
+    `
+	revokeSessionsQuery := `
+        UPDATE sessions
+        SET revoked_at = now()
+        WHERE user_id = $1 AND revoked_at IS NULL
+    `
+	revokeTokensQuery := `
+        UPDATE refresh_tokens
+        SET revoked_at = now()
+        WHERE user_id = $1 AND revoked_at IS NULL
+    `
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// 最後の管理者かどうかを確認(管理者の変更を直列化してから数える)
+	last, err := infrastructure_auth.IsLastActiveAdmin(r.SupabaseClient.Ctx, tx, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check admins: %v", err)
+		return err
+	}
+	if last {
+		r.Logger.ErrorLog.Printf("Cannot deactivate the last admin: %s", id)
+		err = repository_user.ErrLastAdmin
+		return err
+	}
+
+	// ユーザーを無効化
+	tag, err := tx.Exec(r.SupabaseClient.Ctx, deactivateQuery, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to deactivate user: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		err = r.userStateError(tx, id, repository_user.ErrUserAlreadyDeactivated)
+		return err
+	}
+
+	// セッションを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeSessionsQuery, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke sessions: %v", err)
+		return err
+	}
+
+	// リフレッシュトークンを失効
+	_, err = tx.Exec(r.SupabaseClient.Ctx, revokeTokensQuery, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to revoke refresh tokens: %v", err)
+		return err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Deactivated user: %s", id)
+	return nil
+}
+
+// 無効化したユーザーを再有効化
+func (r *UserRepositoryImpl) ReactivateUser(id string) error {
+	r.Logger.InfoLog.Println("ReactivateUser called")
+
+	query := `
+        UPDATE users
+        SET deactivated_at = NULL, updated_at = now()
+        WHERE id = $1 AND deactivated_at IS NOT NULL
+    `
+
+	// Supabaseからクエリを実行し、ユーザーを再有効化
+	tag, err := r.SupabaseClient.Pool.Exec(r.SupabaseClient.Ctx, query, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to reactivate user: %v", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.userStateError(r.SupabaseClient.Pool, id, repository_user.ErrUserNotDeactivated)
+	}
+
+	r.Logger.InfoLog.Printf("Reactivated user: %s", id)
+	return nil
+}
+
+// 無効化済みのユーザーを完全に削除
+// ユーザーのTodoはpolicyに従って削除・付け替え・保管し、同じトランザクションで監査ログを記録する。
+// トークン・セッションなどの認証情報は外部キーのON DELETE CASCADEで削除される。
+// 最後の有効な管理者は削除できない(ErrLastAdmin)。
+func (r *UserRepositoryImpl) PurgeUser(id string, policy domain_user.TodoCascadePolicy, reassignTo string, actorID string) (domain_user.PurgeResult, error) {
+	r.Logger.InfoLog.Println("PurgeUser called")
+
+	lockUserQuery := `
+        SELECT deactivated_at IS NOT NULL
+        FROM users
+        WHERE id = $1
+        FOR UPDATE
+    `
+	// Todoリポジトリと同じキーで、ユーザーのTodoの階層の変更を直列化する
+	lockTodoTreeQuery := `
+        SELECT pg_advisory_xact_lock(hashtext('todo_tree:' || $1))
+    `
+	lockReassignTargetQuery := `
+        SELECT 1
+        FROM users
+        WHERE id = $1 AND deactivated_at IS NULL
+        FOR SHARE
+    `
+	deleteTodosQuery := `
+        DELETE FROM todos
+        WHERE user_id = $1
+    `
+	reassignTodosQuery := `
+        UPDATE todos
+        SET user_id = $2, updated_at = now(), version = version + 1
+        WHERE user_id = $1
+    `
+	// ユーザーのタグはユーザーと共に削除されるため、付け替え先のユーザーに同じ名前(大文字・小文字を区別しない)のタグを用意する
+	copyTagsQuery := `
+        INSERT INTO tags (user_id, name, color)
+        SELECT $2, name, color
+        FROM tags
+        WHERE user_id = $1
+        ON CONFLICT (user_id, lower(name)) DO NOTHING
+    `
+	repointTodoTagsQuery := `
+        UPDATE todo_tags tt
+        SET tag_id = dst.id
+        FROM tags src
+        JOIN tags dst ON dst.user_id = $2 AND lower(dst.name) = lower(src.name)
+        WHERE tt.tag_id = src.id AND src.user_id = $1
+    `
+	// タグはユーザーと共に削除されるため、名前を配列にして保管する
+	archiveTodosQuery := `
+        INSERT INTO archived_todos (id, description, completed, user_id, created_at, updated_at, version, due_at, remind_at, priority, parent_id, tag_names)
+        SELECT t.id, t.description, t.completed, t.user_id, t.created_at, t.updated_at, t.version, t.due_at, t.remind_at, t.priority, t.parent_id,
+            COALESCE((
+                SELECT array_agg(g.name ORDER BY lower(g.name))
+                FROM todo_tags tt
+                JOIN tags g ON g.id = tt.tag_id
+                WHERE tt.todo_id = t.id
+            ), '{}')
+        FROM todos t
+        WHERE t.user_id = $1
+        ON CONFLICT (id) DO NOTHING
+    `
+	deleteUserQuery := `
+        DELETE FROM users
+        WHERE id = $1
+    `
+
+	// トランザクション開始
+	tx, err := r.SupabaseClient.Pool.Begin(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return domain_user.PurgeResult{}, err
+	}
+	defer func() {
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to rollback transaction: %v", err)
+			tx.Rollback(r.SupabaseClient.Ctx)
+		}
+	}()
+
+	// 付け替える場合は両方のユーザーのTodoの階層をロック
+	// Todoの書き込み(階層のロック → ユーザーの行の参照)とのデッドロックを防ぐため、ユーザーの行より先にロックする。
+	// 順序はTodoリポジトリと同じくユーザーIDの順にする。
+	if policy == domain_user.TodoCascadeReassign {
+		userIDs := []string{id, reassignTo}
+		sort.Strings(userIDs)
+		for _, userID := range userIDs {
+			_, err = tx.Exec(r.SupabaseClient.Ctx, lockTodoTreeQuery, userID)
+			if err != nil {
+				r.Logger.ErrorLog.Printf("Failed to lock todo tree: %v", err)
+				return domain_user.PurgeResult{}, err
+			}
+		}
+	}
+
+	// 最後の管理者かどうかを確認(管理者の変更を直列化してから数える)
+	last, err := infrastructure_auth.IsLastActiveAdmin(r.SupabaseClient.Ctx, tx, id)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check admins: %v", err)
+		return domain_user.PurgeResult{}, err
+	}
+	if last {
+		r.Logger.ErrorLog.Printf("Cannot purge the last admin: %s", id)
+		err = repository_user.ErrLastAdmin
+		return domain_user.PurgeResult{}, err
+	}
+
+	// ユーザーをロックし、無効化済みか確認
+	var deactivated bool
+	err = tx.QueryRow(r.SupabaseClient.Ctx, lockUserQuery, id).Scan(&deactivated)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.Logger.ErrorLog.Printf("User not found: %s", id)
+			err = repository_user.ErrUserNotFound
+			return domain_user.PurgeResult{}, err
+		}
+		r.Logger.ErrorLog.Printf("Failed to lock user: %v", err)
+		return domain_user.PurgeResult{}, err
+	}
+	if !deactivated {
+		r.Logger.ErrorLog.Printf("User is not deactivated: %s", id)
+		err = repository_user.ErrUserNotDeactivated
+		return domain_user.PurgeResult{}, err
+	}
+
+	// Todoを処理
+	var result domain_user.PurgeResult
+	switch policy {
+	case domain_user.TodoCascadeDelete:
+		tag, execErr := tx.Exec(r.SupabaseClient.Ctx, deleteTodosQuery, id)
+		if err = execErr; err != nil {
+			r.Logger.ErrorLog.Printf("Failed to delete todos: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+		result.TodosAffected = tag.RowsAffected()
+	case domain_user.TodoCascadeReassign:
+		// 付け替え先のユーザーをロックし、有効なユーザーか確認
+		var exists int
+		err = tx.QueryRow(r.SupabaseClient.Ctx, lockReassignTargetQuery, reassignTo).Scan(&exists)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				r.Logger.ErrorLog.Printf("Reassign target not found: %s", reassignTo)
+				err = repository_user.ErrReassignTargetNotFound
+				return domain_user.PurgeResult{}, err
+			}
+			r.Logger.ErrorLog.Printf("Failed to lock reassign target: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+		tag, execErr := tx.Exec(r.SupabaseClient.Ctx, reassignTodosQuery, id, reassignTo)
+		if err = execErr; err != nil {
+			r.Logger.ErrorLog.Printf("Failed to reassign todos: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+		result.TodosAffected = tag.RowsAffected()
+		// タグを付け替え先のユーザーのタグに統合し、Todoに付いたタグを付け替える
+		_, err = tx.Exec(r.SupabaseClient.Ctx, copyTagsQuery, id, reassignTo)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to copy tags: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+		_, err = tx.Exec(r.SupabaseClient.Ctx, repointTodoTagsQuery, id, reassignTo)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to reassign todo tags: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+	case domain_user.TodoCascadeArchive:
+		tag, execErr := tx.Exec(r.SupabaseClient.Ctx, archiveTodosQuery, id)
+		if err = execErr; err != nil {
+			r.Logger.ErrorLog.Printf("Failed to archive todos: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+		result.TodosAffected = tag.RowsAffected()
+		_, err = tx.Exec(r.SupabaseClient.Ctx, deleteTodosQuery, id)
+		if err != nil {
+			r.Logger.ErrorLog.Printf("Failed to delete archived todos: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+	default:
+		err = fmt.Errorf("unknown todo cascade policy: %s", policy)
+		r.Logger.ErrorLog.Printf("Failed to purge user: %v", err)
+		return domain_user.PurgeResult{}, err
+	}
+
+	// ユーザーを削除
+	_, err = tx.Exec(r.SupabaseClient.Ctx, deleteUserQuery, id)
+	if err != nil {
+		if pkg_supabase.IsForeignKeyViolation(err) {
+			r.Logger.ErrorLog.Printf("User is referenced by other records: %v", err)
+			err = repository_user.ErrUserInUse
+			return domain_user.PurgeResult{}, err
+		}
+		r.Logger.ErrorLog.Printf("Failed to delete user: %v", err)
+		return domain_user.PurgeResult{}, err
+	}
+
+	// 監査ログを記録
+	audit := domain_audit.AuditLog{
+		ActorID:    actorID,
+		Action:     domain_audit.ActionUserPurge,
+		TargetType: domain_audit.TargetTypeUser,
+		TargetID:   id,
+		Details: map[string]interface{}{
+			"todo_policy":    string(policy),
+			"todos_affected": result.TodosAffected,
+		},
+	}
+	if policy == domain_user.TodoCascadeReassign {
+		audit.Details["reassign_to"] = reassignTo
+	}
+	err = infrastructure_audit.WriteAuditLog(r.SupabaseClient.Ctx, tx, audit)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to write audit log: %v", err)
+		return domain_user.PurgeResult{}, err
+	}
+
+	// トランザクションをコミット
+	err = tx.Commit(r.SupabaseClient.Ctx)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return domain_user.PurgeResult{}, err
+	}
+
+	// 正常系にし、ロールバックを防ぐ
+	err = nil
+
+	r.Logger.InfoLog.Printf("Purged user: %s (todo policy: %s, todos: %d)", id, policy, result.TodosAffected)
+	return result, nil
+}
+
+// 状態の更新で対象行がなかった場合のエラーを判定
+// ユーザーが存在しない場合はErrUserNotFound、存在する場合はstateErrを返す。
+func (r *UserRepositoryImpl) userStateError(q queryRower, id string, stateErr error) error {
+	var exists bool
+	err := q.QueryRow(r.SupabaseClient.Ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		r.Logger.ErrorLog.Printf("Failed to check user: %v", err)
+		return err
+	}
+	if !exists {
+		r.Logger.ErrorLog.Printf("User not found: %s", id)
+		return repository_user.ErrUserNotFound
+	}
+	r.Logger.ErrorLog.Printf("Invalid user state: %s: %v", id, stateErr)
+	return stateErr
+}
+
+// 1行を取得するクエリの実行元(プール・トランザクション)
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// ユーザーの取得カラム(パスワードは含めない)
+const userColumns = `id, username, email, email_verification_status, email_verification_sent_at, email_verified_at, deactivated_at, created_at, updated_at`
+
+// ユーザーの行をスキャン
+func scanUser(row pgx.Row) (domain_user.Users, error) {
+	var user domain_user.Users
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.EmailVerificationStatus,
+		&user.EmailVerificationSentAt,
+		&user.EmailVerifiedAt,
+		&user.DeactivatedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	return user, err
+}
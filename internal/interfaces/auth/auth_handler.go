@@ -2,20 +2,37 @@ package interfaces_auth
 
 import (
 	"backend/config"
+	domain_auth "backend/internal/domain/auth"
+	pkg_keyset "backend/internal/pkg/keyset"
 	pkg_logger "backend/internal/pkg/logger"
 	pkg_timer "backend/internal/pkg/timer"
+	pkg_token "backend/internal/pkg/token"
 	usecase_auth "backend/internal/usecase/auth"
+	usecase_service_account "backend/internal/usecase/service_account"
 	pb "backend/proto/github.com/grpc/backend/proto"
 	"context"
-	"strings"
+	"errors"
+	"math"
+	"net"
+	"strconv"
 	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/golang-jwt/jwt"
-	"google.golang.org/grpc"
+)
+
+// JWTの種類(typクレーム)
+const (
+	// アクセストークン
+	tokenTypeAccess = "access"
+	// 二要素認証のチャレンジトークン
+	tokenTypeMFAChallenge = "mfa_challenge"
 )
 
 // 認証ハンドラー層
@@ -24,12 +41,18 @@ type AuthHandler struct {
 	timer     *pkg_timer.TimerPkg
 	AppConfig *config.AppConfig
 	pb.UnimplementedAuthServiceServer
-	authUsecase usecase_auth.IAuthUsecase
+	authUsecase              usecase_auth.IAuthUsecase
+	passwordResetUsecase     usecase_auth.IPasswordResetUsecase
+	totpUsecase              usecase_auth.ITOTPUsecase
+	serviceAccountUsecase    usecase_service_account.IServiceAccountUsecase
+	emailVerificationUsecase usecase_auth.IEmailVerificationUsecase
+	roleUsecase              usecase_auth.IRoleUsecase
+	keySet                   *pkg_keyset.KeySet
 }
 
 // 認証ハンドラー層のインスタンス化
-func NewAuthHandler(l *pkg_logger.AppLogger, ac *config.AppConfig, authUsecase usecase_auth.IAuthUsecase) *AuthHandler {
-	return &AuthHandler{logger: l, AppConfig: ac, authUsecase: authUsecase, timer: pkg_timer.NewTimerPkg()}
+func NewAuthHandler(l *pkg_logger.AppLogger, ac *config.AppConfig, authUsecase usecase_auth.IAuthUsecase, passwordResetUsecase usecase_auth.IPasswordResetUsecase, totpUsecase usecase_auth.ITOTPUsecase, serviceAccountUsecase usecase_service_account.IServiceAccountUsecase, emailVerificationUsecase usecase_auth.IEmailVerificationUsecase, roleUsecase usecase_auth.IRoleUsecase, keySet *pkg_keyset.KeySet) *AuthHandler {
+	return &AuthHandler{logger: l, AppConfig: ac, authUsecase: authUsecase, passwordResetUsecase: passwordResetUsecase, totpUsecase: totpUsecase, serviceAccountUsecase: serviceAccountUsecase, emailVerificationUsecase: emailVerificationUsecase, roleUsecase: roleUsecase, keySet: keySet, timer: pkg_timer.NewTimerPkg()}
 }
 
 // ログイン
@@ -38,8 +61,20 @@ func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 	h.timer.Start()
 
 	// ログイン(usecase層)
-	token, err := h.authUsecase.Login(req.Email, req.Password)
+	token, err := h.authUsecase.Login(req.Email, req.Password, clientAddrFromContext(ctx))
 	if err != nil {
+		// ログイン試行の制限中
+		var lockedErr *usecase_auth.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			retryAfter := int64(math.Ceil(lockedErr.RetryAfter.Seconds()))
+			if err := grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(retryAfter, 10))); err != nil {
+				h.logger.ErrorLog.Printf("Failed to set retry-after header: %v", err)
+			}
+			h.logger.ErrorLog.Printf("Login failed: %v", err)
+			h.logger.PrintDuration("Login", h.timer.GetDuration())
+			return nil, status.Errorf(codes.ResourceExhausted, "too many login attempts. retry after %d seconds", retryAfter)
+		}
+
 		switch err.Error() {
 		case "invalid email or password":
 			h.logger.ErrorLog.Printf("Login failed: %v", err)
@@ -49,6 +84,14 @@ func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 			h.logger.ErrorLog.Printf("Login failed: %v", err)
 			h.logger.PrintDuration("Login", h.timer.GetDuration())
 			return nil, status.Errorf(codes.InvalidArgument, "invalid email format")
+		case "email not verified":
+			h.logger.ErrorLog.Printf("Login failed: %v", err)
+			h.logger.PrintDuration("Login", h.timer.GetDuration())
+			return nil, status.Errorf(codes.FailedPrecondition, "email not verified")
+		case "account deactivated":
+			h.logger.ErrorLog.Printf("Login failed: %v", err)
+			h.logger.PrintDuration("Login", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "account deactivated")
 		default:
 			h.logger.ErrorLog.Printf("Login failed: %v", err)
 			h.logger.PrintDuration("Login", h.timer.GetDuration())
@@ -56,112 +99,399 @@ func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 		}
 	}
 
-	// トークンを生成
-	tokenString, err := h.GenerateToken(token)
+	// 二要素認証が有効な場合はチャレンジトークンを返す
+	mfaEnabled, err := h.totpUsecase.IsTOTPEnabled(token)
 	if err != nil {
-		h.logger.ErrorLog.Printf("Failed to generate token: %v", err)
+		h.logger.ErrorLog.Printf("Failed to check totp: %v", err)
 		h.logger.PrintDuration("Login", h.timer.GetDuration())
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "failed to login")
+	}
+	if mfaEnabled {
+		challengeToken, err := h.generateChallengeToken(token)
+		if err != nil {
+			h.logger.ErrorLog.Printf("Failed to generate challenge token: %v", err)
+			h.logger.PrintDuration("Login", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to login")
+		}
+
+		h.logger.InfoLog.Println("Login requires second factor")
+		h.logger.PrintDuration("Login", h.timer.GetDuration())
+		return &pb.LoginResponse{
+			MfaRequired:    true,
+			ChallengeToken: challengeToken,
+			ExpiresIn:      int64(h.AppConfig.MFAChallengeTTL.Seconds()),
+		}, nil
+	}
+
+	// アクセストークン・リフレッシュトークンを発行
+	tokenString, refreshToken, err := h.issueTokens(ctx, token)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to issue tokens: %v", err)
+		h.logger.PrintDuration("Login", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Internal, "failed to login")
 	}
 
 	h.logger.InfoLog.Println("Login successful")
 	h.logger.PrintDuration("Login", h.timer.GetDuration())
-	return &pb.LoginResponse{Token: tokenString}, nil
+	return &pb.LoginResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(h.AppConfig.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
-// JWTトークンを生成
-func (h *AuthHandler) GenerateToken(id string) (string, error) {
-	h.logger.InfoLog.Println("Generating token...")
+// ユーザー登録
+func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	h.logger.InfoLog.Println("Register called")
 	h.timer.Start()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":   id,
-		"role": h.AppConfig.UserRole,
-		"exp":  time.Now().Add(time.Hour * 24).Unix(),
-	})
+	// ユーザー登録(usecase層)
+	user, err := h.authUsecase.Register(req.Username, req.Email, req.Password)
+	if err != nil {
+		switch err.Error() {
+		case "username is empty", "invalid username format", "email is empty", "invalid email format",
+			"password is too short", "password is too long":
+			h.logger.ErrorLog.Printf("Register failed: %v", err)
+			h.logger.PrintDuration("Register", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "user already exists":
+			h.logger.ErrorLog.Printf("Register failed: %v", err)
+			h.logger.PrintDuration("Register", h.timer.GetDuration())
+			return nil, status.Errorf(codes.AlreadyExists, "username or email already exists")
+		default:
+			h.logger.ErrorLog.Printf("Register failed: %v", err)
+			h.logger.PrintDuration("Register", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to register")
+		}
+	}
 
-	// JWTトークンをシグネーション
-	tokenString, err := token.SignedString([]byte(h.AppConfig.JWTSecret))
+	res := &pb.RegisterResponse{
+		Id:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+	}
+
+	// 確認メールを送信(usecase層)
+	// 登録自体は完了しているため、失敗しても ResendVerificationEmail(未ログイン)・SendVerificationEmail で再送できる。
+	if err := h.emailVerificationUsecase.SendVerificationEmail(user.ID); err != nil {
+		h.logger.ErrorLog.Printf("Failed to send verification email: %v", err)
+	}
+
+	// 自動ログイン(メールアドレスの確認が必須の場合は行わない)
+	if req.AutoLogin && !h.AppConfig.RequireVerifiedEmail {
+		tokenString, refreshToken, err := h.issueTokens(ctx, user.ID)
+		if err != nil {
+			// 登録自体は完了しているため、トークンなしで返却する
+			h.logger.ErrorLog.Printf("Failed to issue tokens: %v", err)
+		} else {
+			res.Token = tokenString
+			res.RefreshToken = refreshToken
+			res.ExpiresIn = int64(h.AppConfig.AccessTokenTTL.Seconds())
+		}
+	}
+
+	h.logger.InfoLog.Println("Register successful")
+	h.logger.PrintDuration("Register", h.timer.GetDuration())
+	return res, nil
+}
+
+// トークンをリフレッシュ
+func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	h.logger.InfoLog.Println("RefreshToken called")
+	h.timer.Start()
+
+	// リフレッシュトークンをローテーション(usecase層)
+	userID, sessionID, refreshToken, err := h.authUsecase.RefreshToken(req.RefreshToken)
 	if err != nil {
-		h.logger.ErrorLog.Printf("Failed to sign token: %v", err)
-		h.logger.PrintDuration("GenerateToken", h.timer.GetDuration())
-		return "", err
+		switch err.Error() {
+		case "refresh_token is empty":
+			h.logger.ErrorLog.Printf("RefreshToken failed: %v", err)
+			h.logger.PrintDuration("RefreshToken", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "refresh_token is empty")
+		case "invalid refresh token", "refresh token reused", "refresh token expired", "refresh token has no session":
+			h.logger.ErrorLog.Printf("RefreshToken failed: %v", err)
+			h.logger.PrintDuration("RefreshToken", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token")
+		default:
+			h.logger.ErrorLog.Printf("RefreshToken failed: %v", err)
+			h.logger.PrintDuration("RefreshToken", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to refresh token")
+		}
 	}
 
-	h.logger.InfoLog.Println("Token generated successfully")
-	h.logger.PrintDuration("GenerateToken", h.timer.GetDuration())
-	return tokenString, nil
+	// トークンを生成(ローテーション前と同じセッションを引き継ぐ)
+	tokenString, err := h.GenerateToken(userID, sessionID)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to generate token: %v", err)
+		h.logger.PrintDuration("RefreshToken", h.timer.GetDuration())
+		return nil, err
+	}
+
+	h.logger.InfoLog.Println("RefreshToken successful")
+	h.logger.PrintDuration("RefreshToken", h.timer.GetDuration())
+	return &pb.RefreshTokenResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(h.AppConfig.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
-// 認証インターセプター
-func (h *AuthHandler) AuthInterceptor(jwtSecret string, requiredRole string) grpc.UnaryServerInterceptor {
-	return func(
-		ctx context.Context,
-		req interface{},
-		info *grpc.UnaryServerInfo,
-		handler grpc.UnaryHandler,
-	) (interface{}, error) {
-		h.timer.Start()
-
-		// 認可スキップ対象のメソッド
-		if info.FullMethod == "/pb.AuthService/Login" {
-			h.logger.InfoLog.Println("Login method called")
-			h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-			return handler(ctx, req)
+// ログアウト
+func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("Logout called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok {
+		h.logger.ErrorLog.Println("Logout failed: missing principal")
+		h.logger.PrintDuration("Logout", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+
+	// ログアウト(usecase層)
+	err := h.authUsecase.Logout(principal.UserID, principal.SessionID, req.RefreshToken, principal.TokenID, principal.ExpiresAt)
+	if err != nil {
+		switch err.Error() {
+		case "invalid refresh token":
+			h.logger.ErrorLog.Printf("Logout failed: %v", err)
+			h.logger.PrintDuration("Logout", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "invalid refresh token")
+		default:
+			h.logger.ErrorLog.Printf("Logout failed: %v", err)
+			h.logger.PrintDuration("Logout", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to logout")
 		}
+	}
+
+	h.logger.InfoLog.Println("Logout successful")
+	h.logger.PrintDuration("Logout", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// アカウントのロックを解除
+func (h *AuthHandler) UnlockAccount(ctx context.Context, req *pb.UnlockAccountRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("UnlockAccount called")
+	h.timer.Start()
 
-		// メタデータから Authorization ヘッダーを取得
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			h.logger.ErrorLog.Println("Missing metadata")
-			h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-			return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
+	// アカウントのロックを解除(usecase層)
+	err := h.authUsecase.UnlockAccount(req.Email)
+	if err != nil {
+		switch err.Error() {
+		case "email is empty", "invalid email format":
+			h.logger.ErrorLog.Printf("UnlockAccount failed: %v", err)
+			h.logger.PrintDuration("UnlockAccount", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		default:
+			h.logger.ErrorLog.Printf("UnlockAccount failed: %v", err)
+			h.logger.PrintDuration("UnlockAccount", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to unlock account")
 		}
+	}
 
-		authHeaders := md["authorization"]
-		if len(authHeaders) == 0 {
-			h.logger.ErrorLog.Println("Missing authorization header")
-			h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-			return nil, status.Errorf(codes.Unauthenticated, "missing authorization header")
+	h.logger.InfoLog.Println("UnlockAccount successful")
+	h.logger.PrintDuration("UnlockAccount", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// パスワード再設定をリクエスト
+// 登録されていないメールアドレスでも成功を返す。
+func (h *AuthHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("RequestPasswordReset called")
+	h.timer.Start()
+
+	// パスワード再設定をリクエスト(usecase層)
+	err := h.passwordResetUsecase.RequestPasswordReset(req.Email)
+	if err != nil {
+		switch err.Error() {
+		case "email is empty", "invalid email format":
+			h.logger.ErrorLog.Printf("RequestPasswordReset failed: %v", err)
+			h.logger.PrintDuration("RequestPasswordReset", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		default:
+			h.logger.ErrorLog.Printf("RequestPasswordReset failed: %v", err)
+			h.logger.PrintDuration("RequestPasswordReset", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to request password reset")
 		}
+	}
 
-		// Bearer トークンからJWT抽出
-		tokenString := strings.TrimPrefix(authHeaders[0], "Bearer ")
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				h.logger.ErrorLog.Println("Invalid token")
-				h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-				return nil, status.Errorf(codes.Unauthenticated, "unexpected signing method")
-			}
-			return []byte(jwtSecret), nil
-		})
+	h.logger.InfoLog.Println("RequestPasswordReset successful")
+	h.logger.PrintDuration("RequestPasswordReset", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
 
-		if err != nil || !token.Valid {
-			h.logger.ErrorLog.Println("Invalid token")
-			h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-			return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+// パスワードを再設定
+func (h *AuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("ResetPassword called")
+	h.timer.Start()
+
+	// パスワードを再設定(usecase層)
+	err := h.passwordResetUsecase.ResetPassword(req.Token, req.NewPassword)
+	if err != nil {
+		switch err.Error() {
+		case "token is empty", "password is too short", "password is too long":
+			h.logger.ErrorLog.Printf("ResetPassword failed: %v", err)
+			h.logger.PrintDuration("ResetPassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "invalid or expired reset token":
+			h.logger.ErrorLog.Printf("ResetPassword failed: %v", err)
+			h.logger.PrintDuration("ResetPassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "invalid or expired reset token")
+		default:
+			h.logger.ErrorLog.Printf("ResetPassword failed: %v", err)
+			h.logger.PrintDuration("ResetPassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to reset password")
 		}
+	}
+
+	h.logger.InfoLog.Println("ResetPassword successful")
+	h.logger.PrintDuration("ResetPassword", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// パスワードを変更
+// 成功時は現在のセッション以外のセッションを全て失効させる。
+func (h *AuthHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("ChangePassword called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		h.logger.ErrorLog.Println("ChangePassword failed: missing principal")
+		h.logger.PrintDuration("ChangePassword", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			h.logger.ErrorLog.Println("Invalid claims")
-			h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-			return nil, status.Errorf(codes.Unauthenticated, "invalid claims")
+	// パスワードを変更(usecase層)
+	err := h.authUsecase.ChangePassword(principal.UserID, principal.SessionID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		// 試行の制限中
+		var lockedErr *usecase_auth.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			retryAfter := int64(math.Ceil(lockedErr.RetryAfter.Seconds()))
+			if err := grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(retryAfter, 10))); err != nil {
+				h.logger.ErrorLog.Printf("Failed to set retry-after header: %v", err)
+			}
+			h.logger.ErrorLog.Printf("ChangePassword failed: %v", err)
+			h.logger.PrintDuration("ChangePassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.ResourceExhausted, "too many attempts. retry after %d seconds", retryAfter)
 		}
 
-		role := claims["role"].(string)
-		if role != requiredRole {
-			h.logger.ErrorLog.Println("Permission denied")
-			h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		switch err.Error() {
+		case "current_password is empty", "password is too short", "password is too long",
+			"new password must differ from current password":
+			h.logger.ErrorLog.Printf("ChangePassword failed: %v", err)
+			h.logger.PrintDuration("ChangePassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "invalid current password":
+			h.logger.ErrorLog.Printf("ChangePassword failed: %v", err)
+			h.logger.PrintDuration("ChangePassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "invalid current password")
+		case "user not found":
+			h.logger.ErrorLog.Printf("ChangePassword failed: %v", err)
+			h.logger.PrintDuration("ChangePassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		default:
+			h.logger.ErrorLog.Printf("ChangePassword failed: %v", err)
+			h.logger.PrintDuration("ChangePassword", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to change password")
 		}
+	}
 
-		// 必要なら ID を context に追加してハンドラーに渡す
-		userID := claims["id"].(string)
-		ctx = context.WithValue(ctx, h.AppConfig.UserID, userID)
+	h.logger.InfoLog.Println("ChangePassword successful")
+	h.logger.PrintDuration("ChangePassword", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
 
-		h.logger.InfoLog.Println("AuthInterceptor successful")
-		h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
-		return handler(ctx, req)
+// JWTトークンを生成
+// sidクレームにセッションIDを含め、インターセプターでセッションの失効を確認する。
+// rolesクレームには発行時点でユーザーに付与されているロールを含める。
+func (h *AuthHandler) GenerateToken(id string, sessionID string) (string, error) {
+	h.logger.InfoLog.Println("Generating token...")
+	h.timer.Start()
+
+	// ユーザーのロールを取得(usecase層)
+	roles, err := h.roleUsecase.GetUserRoles(id)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to get user roles: %v", err)
+		h.logger.PrintDuration("GenerateToken", h.timer.GetDuration())
+		return "", err
+	}
+
+	// トークンIDを生成(ログアウト時の失効に使用)
+	jti, err := pkg_token.GenerateID()
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to generate token id: %v", err)
+		h.logger.PrintDuration("GenerateToken", h.timer.GetDuration())
+		return "", err
+	}
+
+	// 署名鍵を取得
+	signingKey, err := h.keySet.SigningKey()
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to get signing key: %v", err)
+		h.logger.PrintDuration("GenerateToken", h.timer.GetDuration())
+		return "", err
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(signingKey.Method, jwt.MapClaims{
+		"id":    id,
+		"roles": roles,
+		"typ":   tokenTypeAccess,
+		"sid":   sessionID,
+		"jti":   jti,
+		"iat":   now.Unix(),
+		"exp":   now.Add(h.AppConfig.AccessTokenTTL).Unix(),
+	})
+	token.Header["kid"] = signingKey.KID
+
+	// JWTトークンをシグネーション
+	tokenString, err := token.SignedString(signingKey.PrivateKey)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to sign token: %v", err)
+		h.logger.PrintDuration("GenerateToken", h.timer.GetDuration())
+		return "", err
+	}
+
+	h.logger.InfoLog.Println("Token generated successfully")
+	h.logger.PrintDuration("GenerateToken", h.timer.GetDuration())
+	return tokenString, nil
+}
+
+// セッションを作成し、アクセストークンとリフレッシュトークンを発行
+func (h *AuthHandler) issueTokens(ctx context.Context, userID string) (string, string, error) {
+	// セッションを作成
+	sessionID, err := h.createSession(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// トークンを生成
+	tokenString, err := h.GenerateToken(userID, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// リフレッシュトークンを発行(usecase層)
+	refreshToken, err := h.authUsecase.IssueRefreshToken(userID, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tokenString, refreshToken, nil
+}
+
+// クライアントのアドレス(ポートを除く)を取得
+func clientAddrFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
 	}
+	return host
 }
@@ -0,0 +1,257 @@
+package interfaces_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 認証済みのストリーム
+// 認証情報を追加したコンテキストをハンドラーに渡すためにServerStreamをラップする。
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// 認証情報を含むコンテキストを返す
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// 認証インターセプター(Unary)
+// メソッドごとの認可ポリシー(methodPolicies)に従い、認証・認可を行う。
+func (h *AuthHandler) AuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		h.timer.Start()
+
+		// 認証・認可
+		ctx, err := h.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
+			return nil, err
+		}
+
+		h.logger.InfoLog.Println("AuthInterceptor successful")
+		h.logger.PrintDuration("AuthInterceptor", h.timer.GetDuration())
+		return handler(ctx, req)
+	}
+}
+
+// 認証インターセプター(Stream)
+// Unaryと同じ認証・認可を行い、認証情報を含むコンテキストをストリーム経由でハンドラーに渡す。
+func (h *AuthHandler) AuthStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		h.timer.Start()
+
+		// 認証・認可
+		ctx, err := h.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			h.logger.PrintDuration("AuthStreamInterceptor", h.timer.GetDuration())
+			return err
+		}
+
+		h.logger.InfoLog.Println("AuthStreamInterceptor successful")
+		h.logger.PrintDuration("AuthStreamInterceptor", h.timer.GetDuration())
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// 認証・認可を行い、認証情報を追加したコンテキストを返す
+// Unary・Streamの両インターセプターで共通して使用する。
+func (h *AuthHandler) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	// 認可ポリシーの取得(未定義のメソッドは拒否)
+	policy, ok := lookupPolicy(fullMethod)
+	if !ok {
+		h.logger.ErrorLog.Printf("No policy defined for method: %s", fullMethod)
+		return nil, undefinedPolicyError(fullMethod)
+	}
+
+	// 認証不要のメソッド
+	if policy.Public {
+		h.logger.InfoLog.Printf("Public method called: %s", fullMethod)
+		return ctx, nil
+	}
+
+	// メタデータから Authorization ヘッダーを取得
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		h.logger.ErrorLog.Println("Missing metadata")
+		return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
+	}
+
+	// APIキー(サービスアカウント)による認証
+	if apiKeys := md["x-api-key"]; len(apiKeys) > 0 {
+		return h.authenticateAPIKey(ctx, policy, fullMethod, apiKeys[0])
+	}
+
+	authHeaders := md["authorization"]
+	if len(authHeaders) == 0 {
+		h.logger.ErrorLog.Println("Missing authorization header")
+		return nil, status.Errorf(codes.Unauthenticated, "missing authorization header")
+	}
+
+	// Bearer トークンからクレームを取得
+	tokenString := strings.TrimPrefix(authHeaders[0], "Bearer ")
+	claims, err := h.parseClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// アクセストークン以外(チャレンジトークンなど)は受け付けない
+	if typ, _ := claims["typ"].(string); typ != tokenTypeAccess {
+		h.logger.ErrorLog.Printf("Invalid token type: %v", claims["typ"])
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+
+	userID, _ := claims["id"].(string)
+	if userID == "" {
+		h.logger.ErrorLog.Println("Missing user id")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid claims")
+	}
+
+	// ロール・権限による認可
+	roles := rolesFromClaims(claims)
+	permissions := domain_auth.PermissionsForRoles(roles)
+	err = policy.authorize(roles, permissions)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Permission denied: %s: %v", fullMethod, err)
+		return nil, err
+	}
+
+	// 失効済みトークン(ログアウト済み)の確認
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		h.logger.ErrorLog.Println("Missing token id")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+	revoked, err := h.authUsecase.IsAccessTokenRevoked(jti)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to check token revocation: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to verify token")
+	}
+	if revoked {
+		h.logger.ErrorLog.Println("Token revoked")
+		return nil, status.Errorf(codes.Unauthenticated, "token revoked")
+	}
+
+	// セッションの確認(失効済みのセッションのトークンは拒否する)
+	sessionID, _ := claims["sid"].(string)
+	if sessionID == "" {
+		h.logger.ErrorLog.Println("Missing session id")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+	err = h.authUsecase.ValidateSession(sessionID, userID)
+	if err != nil {
+		switch err.Error() {
+		case "session revoked":
+			h.logger.ErrorLog.Printf("Session revoked: %s", sessionID)
+			return nil, status.Errorf(codes.Unauthenticated, "session revoked")
+		default:
+			h.logger.ErrorLog.Printf("Failed to verify session: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to verify token")
+		}
+	}
+
+	// 認証済みの主体を context に追加してハンドラーに渡す
+	principal := &domain_auth.Principal{
+		Kind:        domain_auth.PrincipalKindUser,
+		UserID:      userID,
+		Roles:       roles,
+		Permissions: permissions,
+		TokenID:     jti,
+		SessionID:   sessionID,
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		principal.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+
+	return domain_auth.WithPrincipal(ctx, principal), nil
+}
+
+// APIキーで認証・認可を行い、認証情報を追加したコンテキストを返す
+// APIキーのスコープを権限として扱い、ロールは付与しない。
+func (h *AuthHandler) authenticateAPIKey(ctx context.Context, policy MethodPolicy, fullMethod string, key string) (context.Context, error) {
+	if !policy.ServiceAccounts {
+		h.logger.ErrorLog.Printf("Service account not allowed: %s", fullMethod)
+		return nil, serviceAccountNotAllowedError(fullMethod)
+	}
+
+	// APIキーを検証(usecase層)
+	apiKey, err := h.serviceAccountUsecase.AuthenticateAPIKey(key)
+	if err != nil {
+		switch err.Error() {
+		case "invalid api key":
+			h.logger.ErrorLog.Printf("Invalid api key: %v", err)
+			return nil, status.Errorf(codes.Unauthenticated, "invalid api key")
+		default:
+			h.logger.ErrorLog.Printf("Failed to verify api key: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to verify api key")
+		}
+	}
+
+	// スコープによる認可
+	permissions := domain_auth.PermissionsForScopes(apiKey.Scopes)
+	err = policy.authorize(nil, permissions)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Permission denied: %s: %v", fullMethod, err)
+		return nil, err
+	}
+
+	// 認証済みの主体を context に追加してハンドラーに渡す
+	principal := &domain_auth.Principal{
+		Kind:             domain_auth.PrincipalKindServiceAccount,
+		ServiceAccountID: apiKey.ServiceAccountID,
+		APIKeyID:         apiKey.ID,
+		Permissions:      permissions,
+	}
+
+	return domain_auth.WithPrincipal(ctx, principal), nil
+}
+
+// JWTを検証し、クレームを取得
+// ヘッダーのkidに対応する公開鍵で署名を検証する。
+func (h *AuthHandler) parseClaims(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, h.keySet.Keyfunc)
+	if err != nil || !token.Valid {
+		h.logger.ErrorLog.Printf("Invalid token: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		h.logger.ErrorLog.Println("Invalid claims")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid claims")
+	}
+
+	return claims, nil
+}
+
+// クレームからロール(roles)を取得
+func rolesFromClaims(claims jwt.MapClaims) []string {
+	roles := []string{}
+	if values, ok := claims["roles"].([]interface{}); ok {
+		for _, v := range values {
+			if role, ok := v.(string); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
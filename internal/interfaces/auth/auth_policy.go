@@ -0,0 +1,157 @@
+package interfaces_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pb "backend/proto/github.com/grpc/backend/proto"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// メソッドごとの認可ポリシー
+type MethodPolicy struct {
+	// 認証不要のメソッド
+	Public bool
+	// 許可するロール(いずれかを保持していればよい。空の場合はロールを問わない)
+	Roles []string
+	// 必要な権限(全てを保持している必要がある)
+	Permissions []string
+	// サービスアカウント(APIキー)からの呼び出しを許可する
+	ServiceAccounts bool
+}
+
+// gRPCのフルメソッド名ごとの認可ポリシー
+// ここに定義されていないメソッドは全て拒否する。
+var methodPolicies = map[string]MethodPolicy{
+	// AuthService
+	pb.AuthService_Login_FullMethodName:                   {Public: true},
+	pb.AuthService_Register_FullMethodName:                {Public: true},
+	pb.AuthService_RefreshToken_FullMethodName:            {Public: true},
+	pb.AuthService_Logout_FullMethodName:                  {},
+	pb.AuthService_RequestPasswordReset_FullMethodName:    {Public: true},
+	pb.AuthService_ResetPassword_FullMethodName:           {Public: true},
+	pb.AuthService_EnrollTotp_FullMethodName:              {},
+	pb.AuthService_ConfirmTotp_FullMethodName:             {},
+	pb.AuthService_DisableTotp_FullMethodName:             {},
+	pb.AuthService_VerifySecondFactor_FullMethodName:      {Public: true},
+	pb.AuthService_ListSessions_FullMethodName:            {},
+	pb.AuthService_RevokeSession_FullMethodName:           {},
+	pb.AuthService_ChangePassword_FullMethodName:          {},
+	pb.AuthService_SendVerificationEmail_FullMethodName:   {},
+	pb.AuthService_ResendVerificationEmail_FullMethodName: {Public: true},
+	pb.AuthService_RevokeAllUserSessions_FullMethodName: {
+		Roles:       []string{domain_auth.RoleAdmin},
+		Permissions: []string{domain_auth.PermissionSessionManage},
+	},
+	pb.AuthService_UnlockAccount_FullMethodName: {
+		Roles:       []string{domain_auth.RoleAdmin},
+		Permissions: []string{domain_auth.PermissionUserUnlock},
+	},
+	pb.AuthService_ListRoles_FullMethodName:  roleAdminPolicy,
+	pb.AuthService_AssignRole_FullMethodName: roleAdminPolicy,
+	pb.AuthService_RevokeRole_FullMethodName: roleAdminPolicy,
+
+	// UserService
+	pb.UserService_GetAllUsers_FullMethodName: {
+		Roles:       []string{domain_auth.RoleAdmin},
+		Permissions: []string{domain_auth.PermissionUserList},
+	},
+	pb.UserService_ListUsers_FullMethodName: {
+		Roles:       []string{domain_auth.RoleAdmin},
+		Permissions: []string{domain_auth.PermissionUserList},
+	},
+	pb.UserService_GetUserById_FullMethodName:   {},
+	pb.UserService_GetMe_FullMethodName:         {},
+	pb.UserService_UpdateProfile_FullMethodName: {},
+	pb.UserService_DeleteUser_FullMethodName:    {},
+	pb.UserService_ReactivateUser_FullMethodName: {
+		Roles:       []string{domain_auth.RoleAdmin},
+		Permissions: []string{domain_auth.PermissionUserManage},
+	},
+	pb.UserService_PurgeUser_FullMethodName: {
+		Roles:       []string{domain_auth.RoleAdmin},
+		Permissions: []string{domain_auth.PermissionUserManage},
+	},
+
+	// TodoService
+	pb.TodoService_GetAllTodos_FullMethodName:      {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_ListTodos_FullMethodName:        {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_SearchTodos_FullMethodName:      {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_ListOverdueTodos_FullMethodName: {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_ListDueToday_FullMethodName:     {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_GetTodoById_FullMethodName:      {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_GetTodoTree_FullMethodName:      {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_GetTodoByUserId_FullMethodName:  {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_CreateTodo_FullMethodName:       {Permissions: []string{domain_auth.PermissionTodoWrite}, ServiceAccounts: true},
+	pb.TodoService_UpdateTodo_FullMethodName:       {Permissions: []string{domain_auth.PermissionTodoWrite}, ServiceAccounts: true},
+	pb.TodoService_PatchTodo_FullMethodName:        {Permissions: []string{domain_auth.PermissionTodoWrite}, ServiceAccounts: true},
+	pb.TodoService_DeleteTodo_FullMethodName:       {Permissions: []string{domain_auth.PermissionTodoWrite}, ServiceAccounts: true},
+	pb.TodoService_ListTags_FullMethodName:         {Permissions: []string{domain_auth.PermissionTodoRead}, ServiceAccounts: true},
+	pb.TodoService_CreateTag_FullMethodName:        {Permissions: []string{domain_auth.PermissionTodoWrite}, ServiceAccounts: true},
+	pb.TodoService_UpdateTag_FullMethodName:        {Permissions: []string{domain_auth.PermissionTodoWrite}, ServiceAccounts: true},
+	pb.TodoService_DeleteTag_FullMethodName:        {Permissions: []string{domain_auth.PermissionTodoWrite}, ServiceAccounts: true},
+
+	// ServiceAccountService
+	pb.ServiceAccountService_CreateServiceAccount_FullMethodName: serviceAccountAdminPolicy,
+	pb.ServiceAccountService_ListServiceAccounts_FullMethodName:  serviceAccountAdminPolicy,
+	pb.ServiceAccountService_CreateApiKey_FullMethodName:         serviceAccountAdminPolicy,
+	pb.ServiceAccountService_ListApiKeys_FullMethodName:          serviceAccountAdminPolicy,
+	pb.ServiceAccountService_RevokeApiKey_FullMethodName:         serviceAccountAdminPolicy,
+}
+
+// ロールの管理(管理者のみ)
+var roleAdminPolicy = MethodPolicy{
+	Roles:       []string{domain_auth.RoleAdmin},
+	Permissions: []string{domain_auth.PermissionRoleManage},
+}
+
+// サービスアカウント・APIキーの管理(管理者のみ)
+var serviceAccountAdminPolicy = MethodPolicy{
+	Roles:       []string{domain_auth.RoleAdmin},
+	Permissions: []string{domain_auth.PermissionServiceAccountManage},
+}
+
+// メソッドの認可ポリシーを取得
+func lookupPolicy(fullMethod string) (MethodPolicy, bool) {
+	policy, ok := methodPolicies[fullMethod]
+	return policy, ok
+}
+
+// ロールと権限がポリシーを満たすか確認する
+// 拒否する場合は不足しているロール・権限を含むPermissionDeniedのエラーを返す。
+func (p MethodPolicy) authorize(roles []string, permissions map[string]bool) error {
+	if len(p.Roles) > 0 && !hasAnyRole(roles, p.Roles) {
+		return status.Errorf(codes.PermissionDenied,
+			"permission denied: requires one of roles [%s]", strings.Join(p.Roles, ", "))
+	}
+	for _, permission := range p.Permissions {
+		if !permissions[permission] {
+			return status.Errorf(codes.PermissionDenied,
+				"permission denied: missing permission %q", permission)
+		}
+	}
+	return nil
+}
+
+// いずれかのロールを保持しているか
+func hasAnyRole(roles []string, allowed []string) bool {
+	for _, role := range roles {
+		for _, a := range allowed {
+			if role == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// サービスアカウントからの呼び出しを許可しないメソッドに対するエラー
+func serviceAccountNotAllowedError(fullMethod string) error {
+	return status.Errorf(codes.PermissionDenied, "permission denied: %s is not available for service accounts", fullMethod)
+}
+
+// 未定義のメソッドに対するエラー
+func undefinedPolicyError(fullMethod string) error {
+	return status.Errorf(codes.PermissionDenied, "permission denied: no policy for %s", fullMethod)
+}
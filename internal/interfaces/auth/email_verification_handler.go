@@ -0,0 +1,161 @@
+package interfaces_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	usecase_auth "backend/internal/usecase/auth"
+	pb "backend/proto/github.com/grpc/backend/proto"
+	"context"
+	"errors"
+	"html/template"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// 確認メールを(再)送信
+func (h *AuthHandler) SendVerificationEmail(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("SendVerificationEmail called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		h.logger.ErrorLog.Println("SendVerificationEmail failed: missing principal")
+		h.logger.PrintDuration("SendVerificationEmail", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+
+	// 確認メールを送信(usecase層)
+	err := h.emailVerificationUsecase.SendVerificationEmail(principal.UserID)
+	if err != nil {
+		switch err.Error() {
+		case "email already verified":
+			h.logger.ErrorLog.Printf("SendVerificationEmail failed: %v", err)
+			h.logger.PrintDuration("SendVerificationEmail", h.timer.GetDuration())
+			return nil, status.Errorf(codes.FailedPrecondition, "email already verified")
+		case "verification email recently sent":
+			h.logger.ErrorLog.Printf("SendVerificationEmail failed: %v", err)
+			h.logger.PrintDuration("SendVerificationEmail", h.timer.GetDuration())
+			return nil, status.Errorf(codes.ResourceExhausted, "verification email recently sent")
+		case "user not found":
+			h.logger.ErrorLog.Printf("SendVerificationEmail failed: %v", err)
+			h.logger.PrintDuration("SendVerificationEmail", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		default:
+			h.logger.ErrorLog.Printf("SendVerificationEmail failed: %v", err)
+			h.logger.PrintDuration("SendVerificationEmail", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to send verification email")
+		}
+	}
+
+	h.logger.InfoLog.Println("SendVerificationEmail successful")
+	h.logger.PrintDuration("SendVerificationEmail", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// メールアドレスを指定して確認メールを再送
+// 登録されていないメールアドレスでも成功を返す。
+func (h *AuthHandler) ResendVerificationEmail(ctx context.Context, req *pb.ResendVerificationEmailRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("ResendVerificationEmail called")
+	h.timer.Start()
+
+	// 確認メールを再送(usecase層)
+	err := h.emailVerificationUsecase.ResendVerificationEmail(req.Email, clientAddrFromContext(ctx))
+	if err != nil {
+		var lockedErr *usecase_auth.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			retryAfter := int64(math.Ceil(lockedErr.RetryAfter.Seconds()))
+			if err := grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(retryAfter, 10))); err != nil {
+				h.logger.ErrorLog.Printf("Failed to set retry-after header: %v", err)
+			}
+			h.logger.ErrorLog.Printf("ResendVerificationEmail failed: %v", err)
+			h.logger.PrintDuration("ResendVerificationEmail", h.timer.GetDuration())
+			return nil, status.Errorf(codes.ResourceExhausted, "too many requests. retry after %d seconds", retryAfter)
+		}
+		switch err.Error() {
+		case "email is empty", "invalid email format":
+			h.logger.ErrorLog.Printf("ResendVerificationEmail failed: %v", err)
+			h.logger.PrintDuration("ResendVerificationEmail", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		default:
+			h.logger.ErrorLog.Printf("ResendVerificationEmail failed: %v", err)
+			h.logger.PrintDuration("ResendVerificationEmail", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to send verification email")
+		}
+	}
+
+	h.logger.InfoLog.Println("ResendVerificationEmail successful")
+	h.logger.PrintDuration("ResendVerificationEmail", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// 確認リンクを開いた際の確認画面
+// メールのリンクはプレビューなどで自動的に開かれることがあるため、GETでは状態を変更せず、ボタンの押下(POST)で確認する。
+var confirmEmailVerificationPage = template.Must(template.New("verify-email").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head><meta charset="utf-8"><title>メールアドレスの確認</title></head>
+<body>
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<p>ボタンを押して、メールアドレスの確認を完了してください。</p>
+<button type="submit">確認する</button>
+</form>
+</body>
+</html>
+`))
+
+// 確認リンクから確認画面を表示する(状態は変更しない)
+// GET /verify-email?token=...
+func (h *AuthHandler) ConfirmEmailVerification(c echo.Context) error {
+	h.logger.InfoLog.Println("ConfirmEmailVerification called")
+
+	token := c.QueryParam("token")
+	if token == "" {
+		h.logger.ErrorLog.Println("ConfirmEmailVerification failed: token is empty")
+		return c.JSON(http.StatusBadRequest, map[string]string{"message": "invalid or expired verification link"})
+	}
+
+	var page strings.Builder
+	err := confirmEmailVerificationPage.Execute(&page, map[string]string{
+		"Action": c.Request().URL.Path,
+		"Token":  token,
+	})
+	if err != nil {
+		h.logger.ErrorLog.Printf("ConfirmEmailVerification failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"message": "failed to verify email"})
+	}
+
+	return c.HTML(http.StatusOK, page.String())
+}
+
+// 確認リンクのトークンからメールアドレスを確認済みにする
+// POST /verify-email (token はフォームまたはクエリで指定)
+func (h *AuthHandler) VerifyEmail(c echo.Context) error {
+	h.logger.InfoLog.Println("VerifyEmail called")
+
+	// 確認リンクを検証(usecase層)
+	err := h.emailVerificationUsecase.VerifyEmail(c.FormValue("token"))
+	if err != nil {
+		switch err.Error() {
+		case "email already verified":
+			return c.JSON(http.StatusOK, map[string]string{"message": "email already verified"})
+		case "token is empty", "invalid or expired verification link":
+			h.logger.ErrorLog.Printf("VerifyEmail failed: %v", err)
+			return c.JSON(http.StatusBadRequest, map[string]string{"message": "invalid or expired verification link"})
+		default:
+			h.logger.ErrorLog.Printf("VerifyEmail failed: %v", err)
+			return c.JSON(http.StatusInternalServerError, map[string]string{"message": "failed to verify email"})
+		}
+	}
+
+	h.logger.InfoLog.Println("VerifyEmail successful")
+	return c.JSON(http.StatusOK, map[string]string{"message": "email verified"})
+}
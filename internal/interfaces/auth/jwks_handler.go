@@ -0,0 +1,17 @@
+package interfaces_auth
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// JWKS(JWT検証用の公開鍵一覧)を返す
+// GET /.well-known/jwks.json
+func (h *AuthHandler) GetJWKS(c echo.Context) error {
+	h.logger.InfoLog.Println("GetJWKS called")
+
+	// 鍵のローテーションに追従できるよう、キャッシュ期間は短くする
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, h.keySet.JWKS())
+}
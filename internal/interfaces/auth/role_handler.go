@@ -0,0 +1,109 @@
+package interfaces_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pb "backend/proto/github.com/grpc/backend/proto"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ロールの一覧を取得
+func (h *AuthHandler) ListRoles(ctx context.Context, req *pb.ListRolesRequest) (*pb.RoleList, error) {
+	h.logger.InfoLog.Println("ListRoles called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ロールの一覧を取得(usecase層)
+	roles, err := h.roleUsecase.ListRoles(principal, req.UserId)
+	if err != nil {
+		h.logger.ErrorLog.Printf("ListRoles failed: %v", err)
+		h.logger.PrintDuration("ListRoles", h.timer.GetDuration())
+		return nil, roleStatus(err, "failed to list roles")
+	}
+
+	pbRoles := make([]*pb.Role, len(roles))
+	for i, role := range roles {
+		pbRoles[i] = toPbRole(role)
+	}
+
+	h.logger.InfoLog.Printf("ListRoles success: %v roles", len(pbRoles))
+	h.logger.PrintDuration("ListRoles", h.timer.GetDuration())
+	return &pb.RoleList{Roles: pbRoles}, nil
+}
+
+// ユーザーにロールを付与
+func (h *AuthHandler) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("AssignRole called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ロールを付与(usecase層)
+	err := h.roleUsecase.AssignRole(principal, req.UserId, req.Role)
+	if err != nil {
+		h.logger.ErrorLog.Printf("AssignRole failed: %v", err)
+		h.logger.PrintDuration("AssignRole", h.timer.GetDuration())
+		return nil, roleStatus(err, "failed to assign role")
+	}
+
+	h.logger.InfoLog.Println("AssignRole successful")
+	h.logger.PrintDuration("AssignRole", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// ユーザーからロールを剥奪
+func (h *AuthHandler) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("RevokeRole called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ロールを剥奪(usecase層)
+	err := h.roleUsecase.RevokeRole(principal, req.UserId, req.Role)
+	if err != nil {
+		h.logger.ErrorLog.Printf("RevokeRole failed: %v", err)
+		h.logger.PrintDuration("RevokeRole", h.timer.GetDuration())
+		return nil, roleStatus(err, "failed to revoke role")
+	}
+
+	h.logger.InfoLog.Println("RevokeRole successful")
+	h.logger.PrintDuration("RevokeRole", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// ロールのユースケースのエラーをgRPCのステータスに変換
+// 対応するステータスがない場合はInternal(message)を返す。
+func roleStatus(err error, message string) error {
+	switch err.Error() {
+	case "unauthenticated":
+		return status.Errorf(codes.Unauthenticated, "unauthenticated")
+	case "permission denied":
+		return status.Errorf(codes.PermissionDenied, "permission denied")
+	case "user_id is empty", "role is empty":
+		return status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	case "user not found", "role not found", "role not assigned":
+		return status.Errorf(codes.NotFound, "%s", err.Error())
+	case "role already assigned":
+		return status.Errorf(codes.AlreadyExists, "role already assigned")
+	case "cannot revoke the last admin":
+		return status.Errorf(codes.FailedPrecondition, "cannot revoke the last admin")
+	default:
+		return status.Errorf(codes.Internal, "%s", message)
+	}
+}
+
+// ドメインのロールをgRPCのメッセージに変換
+func toPbRole(role domain_auth.Role) *pb.Role {
+	return &pb.Role{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: domain_auth.PermissionsOfRole(role.Name),
+	}
+}
@@ -0,0 +1,132 @@
+package interfaces_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pb "backend/proto/github.com/grpc/backend/proto"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 自分の有効なセッション一覧を取得
+func (h *AuthHandler) ListSessions(ctx context.Context, req *emptypb.Empty) (*pb.SessionList, error) {
+	h.logger.InfoLog.Println("ListSessions called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		h.logger.ErrorLog.Println("ListSessions failed: missing principal")
+		h.logger.PrintDuration("ListSessions", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+
+	// セッション一覧を取得(usecase層)
+	sessions, err := h.authUsecase.ListSessions(principal.UserID)
+	if err != nil {
+		h.logger.ErrorLog.Printf("ListSessions failed: %v", err)
+		h.logger.PrintDuration("ListSessions", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Internal, "failed to list sessions")
+	}
+
+	pbSessions := make([]*pb.Session, len(sessions))
+	for i, session := range sessions {
+		pbSessions[i] = toPbSession(session, principal.SessionID)
+	}
+
+	h.logger.InfoLog.Printf("ListSessions success: %v sessions", len(pbSessions))
+	h.logger.PrintDuration("ListSessions", h.timer.GetDuration())
+	return &pb.SessionList{Sessions: pbSessions}, nil
+}
+
+// 自分のセッションを失効
+func (h *AuthHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("RevokeSession called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		h.logger.ErrorLog.Println("RevokeSession failed: missing principal")
+		h.logger.PrintDuration("RevokeSession", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+
+	// セッションを失効(usecase層)
+	err := h.authUsecase.RevokeSession(principal.UserID, req.SessionId)
+	if err != nil {
+		switch err.Error() {
+		case "session_id is empty":
+			h.logger.ErrorLog.Printf("RevokeSession failed: %v", err)
+			h.logger.PrintDuration("RevokeSession", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "session_id is empty")
+		case "session not found":
+			h.logger.ErrorLog.Printf("RevokeSession failed: %v", err)
+			h.logger.PrintDuration("RevokeSession", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		default:
+			h.logger.ErrorLog.Printf("RevokeSession failed: %v", err)
+			h.logger.PrintDuration("RevokeSession", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to revoke session")
+		}
+	}
+
+	h.logger.InfoLog.Println("RevokeSession successful")
+	h.logger.PrintDuration("RevokeSession", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// ユーザーの全セッションを失効(管理者用)
+func (h *AuthHandler) RevokeAllUserSessions(ctx context.Context, req *pb.RevokeAllUserSessionsRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("RevokeAllUserSessions called")
+	h.timer.Start()
+
+	// 全セッションを失効(usecase層)
+	err := h.authUsecase.RevokeAllUserSessions(req.UserId)
+	if err != nil {
+		switch err.Error() {
+		case "user_id is empty":
+			h.logger.ErrorLog.Printf("RevokeAllUserSessions failed: %v", err)
+			h.logger.PrintDuration("RevokeAllUserSessions", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "user_id is empty")
+		default:
+			h.logger.ErrorLog.Printf("RevokeAllUserSessions failed: %v", err)
+			h.logger.PrintDuration("RevokeAllUserSessions", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to revoke sessions")
+		}
+	}
+
+	h.logger.InfoLog.Println("RevokeAllUserSessions successful")
+	h.logger.PrintDuration("RevokeAllUserSessions", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// セッションを作成し、セッションIDを返す
+// ユーザーエージェントはメタデータ、クライアントアドレスは接続元から取得する。
+func (h *AuthHandler) createSession(ctx context.Context, userID string) (string, error) {
+	userAgent := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md["user-agent"]; len(values) > 0 {
+			userAgent = values[0]
+		}
+	}
+
+	// セッションを作成(usecase層)
+	return h.authUsecase.CreateSession(userID, userAgent, clientAddrFromContext(ctx))
+}
+
+// ドメインのセッションをgRPCのメッセージに変換
+func toPbSession(session domain_auth.Session, currentSessionID string) *pb.Session {
+	return &pb.Session{
+		Id:         session.ID,
+		UserAgent:  session.UserAgent,
+		IpAddress:  session.IPAddress,
+		CreatedAt:  timestamppb.New(session.CreatedAt),
+		LastSeenAt: timestamppb.New(session.LastSeenAt),
+		Current:    session.ID == currentSessionID,
+	}
+}
@@ -0,0 +1,258 @@
+package interfaces_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pkg_token "backend/internal/pkg/token"
+	usecase_auth "backend/internal/usecase/auth"
+	pb "backend/proto/github.com/grpc/backend/proto"
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TOTPの登録を開始
+// 返却したシークレットはConfirmTotpで有効化するまで使用されない。
+func (h *AuthHandler) EnrollTotp(ctx context.Context, req *emptypb.Empty) (*pb.EnrollTotpResponse, error) {
+	h.logger.InfoLog.Println("EnrollTotp called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok {
+		h.logger.ErrorLog.Println("EnrollTotp failed: missing principal")
+		h.logger.PrintDuration("EnrollTotp", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+
+	// TOTPの登録を開始(usecase層)
+	enrollment, err := h.totpUsecase.EnrollTOTP(principal.UserID)
+	if err != nil {
+		switch err.Error() {
+		case "totp already enabled":
+			h.logger.ErrorLog.Printf("EnrollTotp failed: %v", err)
+			h.logger.PrintDuration("EnrollTotp", h.timer.GetDuration())
+			return nil, status.Errorf(codes.FailedPrecondition, "totp already enabled")
+		default:
+			h.logger.ErrorLog.Printf("EnrollTotp failed: %v", err)
+			h.logger.PrintDuration("EnrollTotp", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to enroll totp")
+		}
+	}
+
+	h.logger.InfoLog.Println("EnrollTotp successful")
+	h.logger.PrintDuration("EnrollTotp", h.timer.GetDuration())
+	return &pb.EnrollTotpResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+		QrCodePng:  enrollment.QRCodePNG,
+	}, nil
+}
+
+// コードを検証してTOTPを有効化
+func (h *AuthHandler) ConfirmTotp(ctx context.Context, req *pb.ConfirmTotpRequest) (*pb.ConfirmTotpResponse, error) {
+	h.logger.InfoLog.Println("ConfirmTotp called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok {
+		h.logger.ErrorLog.Println("ConfirmTotp failed: missing principal")
+		h.logger.PrintDuration("ConfirmTotp", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+
+	// TOTPを有効化(usecase層)
+	recoveryCodes, err := h.totpUsecase.ConfirmTOTP(principal.UserID, req.Code)
+	if err != nil {
+		switch err.Error() {
+		case "code is empty", "invalid code":
+			h.logger.ErrorLog.Printf("ConfirmTotp failed: %v", err)
+			h.logger.PrintDuration("ConfirmTotp", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "totp not enrolled", "totp already enabled":
+			h.logger.ErrorLog.Printf("ConfirmTotp failed: %v", err)
+			h.logger.PrintDuration("ConfirmTotp", h.timer.GetDuration())
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		default:
+			h.logger.ErrorLog.Printf("ConfirmTotp failed: %v", err)
+			h.logger.PrintDuration("ConfirmTotp", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to confirm totp")
+		}
+	}
+
+	h.logger.InfoLog.Println("ConfirmTotp successful")
+	h.logger.PrintDuration("ConfirmTotp", h.timer.GetDuration())
+	return &pb.ConfirmTotpResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// TOTPを無効化
+func (h *AuthHandler) DisableTotp(ctx context.Context, req *pb.DisableTotpRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("DisableTotp called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, ok := domain_auth.PrincipalFromContext(ctx)
+	if !ok {
+		h.logger.ErrorLog.Println("DisableTotp failed: missing principal")
+		h.logger.PrintDuration("DisableTotp", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+
+	// TOTPを無効化(usecase層)
+	err := h.totpUsecase.DisableTOTP(principal.UserID, req.Code)
+	if err != nil {
+		if st := h.secondFactorStatus("DisableTotp", err); st != nil {
+			return nil, st
+		}
+		h.logger.ErrorLog.Printf("DisableTotp failed: %v", err)
+		h.logger.PrintDuration("DisableTotp", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Internal, "failed to disable totp")
+	}
+
+	h.logger.InfoLog.Println("DisableTotp successful")
+	h.logger.PrintDuration("DisableTotp", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// チャレンジトークンと二要素目のコードを検証し、トークンを発行
+func (h *AuthHandler) VerifySecondFactor(ctx context.Context, req *pb.VerifySecondFactorRequest) (*pb.LoginResponse, error) {
+	h.logger.InfoLog.Println("VerifySecondFactor called")
+	h.timer.Start()
+
+	// チャレンジトークンを検証
+	userID, jti, expiresAt, err := h.parseChallengeToken(req.ChallengeToken)
+	if err != nil {
+		h.logger.ErrorLog.Printf("VerifySecondFactor failed: %v", err)
+		h.logger.PrintDuration("VerifySecondFactor", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Unauthenticated, "invalid challenge token")
+	}
+
+	// 二要素目を検証(usecase層)
+	err = h.totpUsecase.VerifySecondFactor(userID, req.Code)
+	if err != nil {
+		if st := h.secondFactorStatus("VerifySecondFactor", err); st != nil {
+			return nil, st
+		}
+		h.logger.ErrorLog.Printf("VerifySecondFactor failed: %v", err)
+		h.logger.PrintDuration("VerifySecondFactor", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Internal, "failed to verify second factor")
+	}
+
+	// チャレンジトークンを使用済みにする(usecase層)
+	err = h.authUsecase.RevokeAccessToken(jti, expiresAt)
+	if err != nil {
+		h.logger.ErrorLog.Printf("VerifySecondFactor failed: %v", err)
+		h.logger.PrintDuration("VerifySecondFactor", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Internal, "failed to verify second factor")
+	}
+
+	// アクセストークン・リフレッシュトークンを発行
+	tokenString, refreshToken, err := h.issueTokens(ctx, userID)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to issue tokens: %v", err)
+		h.logger.PrintDuration("VerifySecondFactor", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Internal, "failed to verify second factor")
+	}
+
+	h.logger.InfoLog.Println("VerifySecondFactor successful")
+	h.logger.PrintDuration("VerifySecondFactor", h.timer.GetDuration())
+	return &pb.LoginResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(h.AppConfig.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// 二要素目の検証エラーをgRPCのステータスに変換
+// 対応するステータスがない場合はnilを返す。
+func (h *AuthHandler) secondFactorStatus(method string, err error) error {
+	var lockedErr *usecase_auth.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		h.logger.ErrorLog.Printf("%s failed: %v", method, err)
+		h.logger.PrintDuration(method, h.timer.GetDuration())
+		return status.Errorf(codes.ResourceExhausted, "too many attempts. retry after %d seconds", int64(math.Ceil(lockedErr.RetryAfter.Seconds())))
+	}
+
+	switch err.Error() {
+	case "code is empty":
+		h.logger.ErrorLog.Printf("%s failed: %v", method, err)
+		h.logger.PrintDuration(method, h.timer.GetDuration())
+		return status.Errorf(codes.InvalidArgument, "code is empty")
+	case "invalid code":
+		h.logger.ErrorLog.Printf("%s failed: %v", method, err)
+		h.logger.PrintDuration(method, h.timer.GetDuration())
+		return status.Errorf(codes.Unauthenticated, "invalid code")
+	case "totp not enabled":
+		h.logger.ErrorLog.Printf("%s failed: %v", method, err)
+		h.logger.PrintDuration(method, h.timer.GetDuration())
+		return status.Errorf(codes.FailedPrecondition, "totp not enabled")
+	default:
+		return nil
+	}
+}
+
+// 二要素認証のチャレンジトークンを生成
+// アクセストークンと同じ鍵で署名し、typクレームで区別する。
+func (h *AuthHandler) generateChallengeToken(userID string) (string, error) {
+	jti, err := pkg_token.GenerateID()
+	if err != nil {
+		return "", err
+	}
+
+	// 署名鍵を取得
+	signingKey, err := h.keySet.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(signingKey.Method, jwt.MapClaims{
+		"id":  userID,
+		"typ": tokenTypeMFAChallenge,
+		"jti": jti,
+		"iat": now.Unix(),
+		"exp": now.Add(h.AppConfig.MFAChallengeTTL).Unix(),
+	})
+	token.Header["kid"] = signingKey.KID
+
+	return token.SignedString(signingKey.PrivateKey)
+}
+
+// チャレンジトークンを検証し、ユーザーID・トークンID・有効期限を取得
+// 使用済み(失効済み)のチャレンジトークンは受け付けない。
+func (h *AuthHandler) parseChallengeToken(tokenString string) (string, string, time.Time, error) {
+	if tokenString == "" {
+		return "", "", time.Time{}, errors.New("challenge token is empty")
+	}
+
+	claims, err := h.parseClaims(tokenString)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	if typ, _ := claims["typ"].(string); typ != tokenTypeMFAChallenge {
+		return "", "", time.Time{}, errors.New("invalid token type")
+	}
+
+	userID, _ := claims["id"].(string)
+	jti, _ := claims["jti"].(string)
+	exp, _ := claims["exp"].(float64)
+	if userID == "" || jti == "" || exp == 0 {
+		return "", "", time.Time{}, errors.New("invalid claims")
+	}
+
+	revoked, err := h.authUsecase.IsAccessTokenRevoked(jti)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	if revoked {
+		return "", "", time.Time{}, errors.New("challenge token already used")
+	}
+
+	return userID, jti, time.Unix(int64(exp), 0), nil
+}
@@ -0,0 +1,202 @@
+package interfaces_service_account
+
+import (
+	domain_service_account "backend/internal/domain/service_account"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_timer "backend/internal/pkg/timer"
+	usecase_service_account "backend/internal/usecase/service_account"
+	pb "backend/proto/github.com/grpc/backend/proto"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// サービスアカウントハンドラー層
+type ServiceAccountHandler struct {
+	logger *pkg_logger.AppLogger
+	timer  *pkg_timer.TimerPkg
+	pb.UnimplementedServiceAccountServiceServer
+	serviceAccountUsecase usecase_service_account.IServiceAccountUsecase
+}
+
+// サービスアカウントハンドラー層のインスタンス化
+func NewServiceAccountHandler(l *pkg_logger.AppLogger, serviceAccountUsecase usecase_service_account.IServiceAccountUsecase) *ServiceAccountHandler {
+	return &ServiceAccountHandler{logger: l, serviceAccountUsecase: serviceAccountUsecase, timer: pkg_timer.NewTimerPkg()}
+}
+
+// サービスアカウントを作成する
+func (h *ServiceAccountHandler) CreateServiceAccount(ctx context.Context, req *pb.CreateServiceAccountRequest) (*pb.ServiceAccount, error) {
+	h.logger.InfoLog.Println("CreateServiceAccount called")
+	h.timer.Start()
+
+	// サービスアカウントを作成する(usecase層)
+	serviceAccount, err := h.serviceAccountUsecase.CreateServiceAccount(req.Name, req.Description)
+	if err != nil {
+		switch err.Error() {
+		case "name is empty", "invalid name format":
+			h.logger.ErrorLog.Printf("Failed to create service account: %v", err)
+			h.logger.PrintDuration("CreateServiceAccount", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "service account already exists":
+			h.logger.ErrorLog.Printf("Failed to create service account: %v", err)
+			h.logger.PrintDuration("CreateServiceAccount", h.timer.GetDuration())
+			return nil, status.Errorf(codes.AlreadyExists, "service account already exists")
+		default:
+			h.logger.ErrorLog.Printf("Failed to create service account: %v", err)
+			h.logger.PrintDuration("CreateServiceAccount", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to create service account")
+		}
+	}
+
+	h.logger.InfoLog.Printf("CreateServiceAccount success: %s", serviceAccount.ID)
+	h.logger.PrintDuration("CreateServiceAccount", h.timer.GetDuration())
+	return toPbServiceAccount(serviceAccount), nil
+}
+
+// サービスアカウントの一覧を取得する
+func (h *ServiceAccountHandler) ListServiceAccounts(ctx context.Context, req *emptypb.Empty) (*pb.ServiceAccountList, error) {
+	h.logger.InfoLog.Println("ListServiceAccounts called")
+	h.timer.Start()
+
+	// サービスアカウントの一覧を取得する(usecase層)
+	serviceAccounts, err := h.serviceAccountUsecase.GetAllServiceAccounts()
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to get service accounts: %v", err)
+		h.logger.PrintDuration("ListServiceAccounts", h.timer.GetDuration())
+		return nil, status.Errorf(codes.Internal, "failed to get service accounts")
+	}
+
+	pbServiceAccounts := make([]*pb.ServiceAccount, len(serviceAccounts))
+	for i, serviceAccount := range serviceAccounts {
+		pbServiceAccounts[i] = toPbServiceAccount(serviceAccount)
+	}
+
+	h.logger.InfoLog.Printf("ListServiceAccounts success: %v service accounts", len(pbServiceAccounts))
+	h.logger.PrintDuration("ListServiceAccounts", h.timer.GetDuration())
+	return &pb.ServiceAccountList{ServiceAccounts: pbServiceAccounts}, nil
+}
+
+// APIキーを発行する
+func (h *ServiceAccountHandler) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	h.logger.InfoLog.Println("CreateApiKey called")
+	h.timer.Start()
+
+	// APIキーを発行する(usecase層)
+	plain, key, err := h.serviceAccountUsecase.CreateAPIKey(req.ServiceAccountId, req.Scopes, time.Duration(req.ExpiresIn)*time.Second)
+	if err != nil {
+		switch err.Error() {
+		case "service_account_id is empty", "scopes is empty", "invalid scope", "invalid expiration":
+			h.logger.ErrorLog.Printf("Failed to create api key: %v", err)
+			h.logger.PrintDuration("CreateApiKey", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "service account not found":
+			h.logger.ErrorLog.Printf("Failed to create api key: %v", err)
+			h.logger.PrintDuration("CreateApiKey", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "service account not found")
+		default:
+			h.logger.ErrorLog.Printf("Failed to create api key: %v", err)
+			h.logger.PrintDuration("CreateApiKey", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to create api key")
+		}
+	}
+
+	h.logger.InfoLog.Printf("CreateApiKey success: %s", key.ID)
+	h.logger.PrintDuration("CreateApiKey", h.timer.GetDuration())
+	return &pb.CreateApiKeyResponse{ApiKey: plain, Key: toPbApiKey(key)}, nil
+}
+
+// サービスアカウントのAPIキーの一覧を取得する
+func (h *ServiceAccountHandler) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ApiKeyList, error) {
+	h.logger.InfoLog.Println("ListApiKeys called")
+	h.timer.Start()
+
+	// APIキーの一覧を取得する(usecase層)
+	keys, err := h.serviceAccountUsecase.GetAPIKeys(req.ServiceAccountId)
+	if err != nil {
+		switch err.Error() {
+		case "service_account_id is empty":
+			h.logger.ErrorLog.Printf("Failed to get api keys: %v", err)
+			h.logger.PrintDuration("ListApiKeys", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "service_account_id is empty")
+		default:
+			h.logger.ErrorLog.Printf("Failed to get api keys: %v", err)
+			h.logger.PrintDuration("ListApiKeys", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to get api keys")
+		}
+	}
+
+	pbKeys := make([]*pb.ApiKey, len(keys))
+	for i, key := range keys {
+		pbKeys[i] = toPbApiKey(key)
+	}
+
+	h.logger.InfoLog.Printf("ListApiKeys success: %v api keys", len(pbKeys))
+	h.logger.PrintDuration("ListApiKeys", h.timer.GetDuration())
+	return &pb.ApiKeyList{ApiKeys: pbKeys}, nil
+}
+
+// APIキーを失効させる
+func (h *ServiceAccountHandler) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("RevokeApiKey called")
+	h.timer.Start()
+
+	// APIキーを失効させる(usecase層)
+	err := h.serviceAccountUsecase.RevokeAPIKey(req.Id)
+	if err != nil {
+		switch err.Error() {
+		case "id is empty":
+			h.logger.ErrorLog.Printf("Failed to revoke api key: %v", err)
+			h.logger.PrintDuration("RevokeApiKey", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "id is empty")
+		case "api key not found":
+			h.logger.ErrorLog.Printf("Failed to revoke api key: %v", err)
+			h.logger.PrintDuration("RevokeApiKey", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "api key not found")
+		default:
+			h.logger.ErrorLog.Printf("Failed to revoke api key: %v", err)
+			h.logger.PrintDuration("RevokeApiKey", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Internal, "failed to revoke api key")
+		}
+	}
+
+	h.logger.InfoLog.Println("RevokeApiKey success")
+	h.logger.PrintDuration("RevokeApiKey", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// サービスアカウントをレスポンスの形式に変換
+func toPbServiceAccount(serviceAccount domain_service_account.ServiceAccount) *pb.ServiceAccount {
+	return &pb.ServiceAccount{
+		Id:          serviceAccount.ID,
+		Name:        serviceAccount.Name,
+		Description: serviceAccount.Description,
+		CreatedAt:   timestamppb.New(serviceAccount.CreatedAt),
+		UpdatedAt:   timestamppb.New(serviceAccount.UpdatedAt),
+	}
+}
+
+// APIキーをレスポンスの形式に変換(キー本体・ハッシュ値は含めない)
+func toPbApiKey(key domain_service_account.APIKey) *pb.ApiKey {
+	return &pb.ApiKey{
+		Id:               key.ID,
+		ServiceAccountId: key.ServiceAccountID,
+		Prefix:           key.Prefix,
+		Scopes:           key.Scopes,
+		ExpiresAt:        toPbTimestamp(key.ExpiresAt),
+		LastUsedAt:       toPbTimestamp(key.LastUsedAt),
+		RevokedAt:        toPbTimestamp(key.RevokedAt),
+		CreatedAt:        timestamppb.New(key.CreatedAt),
+	}
+}
+
+// 未設定の場合はnilを返す
+func toPbTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,171 @@
+package interfaces_todo
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_todo "backend/internal/domain/todo"
+	pb "backend/proto/github.com/grpc/backend/proto"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// タグの一覧を取得する
+func (h *TodoHandler) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.TagList, error) {
+	h.logger.InfoLog.Println("ListTags called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// タグの一覧を取得する(usecase層)
+	tags, err := h.tagUsecase.ListTags(principal, req.UserId)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to list tags: %v", err)
+		h.logger.PrintDuration("ListTags", h.timer.GetDuration())
+		return nil, tagStatus(err)
+	}
+
+	pbTags := toPbTags(tags)
+
+	h.logger.InfoLog.Printf("ListTags success: %v tags", len(pbTags))
+	h.logger.PrintDuration("ListTags", h.timer.GetDuration())
+	return &pb.TagList{Tags: pbTags}, nil
+}
+
+// タグを作成する
+func (h *TodoHandler) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.Tag, error) {
+	h.logger.InfoLog.Println("CreateTag called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// タグを作成する(usecase層)
+	tag := domain_todo.Tag{
+		UserID: req.UserId,
+		Name:   req.Name,
+		Color:  req.Color,
+	}
+	createdTag, err := h.tagUsecase.CreateTag(principal, tag)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to create tag: %v", err)
+		h.logger.PrintDuration("CreateTag", h.timer.GetDuration())
+		return nil, tagStatus(err)
+	}
+
+	pbTag := toPbTag(createdTag)
+
+	h.logger.InfoLog.Printf("CreateTag success: %v", pbTag)
+	h.logger.PrintDuration("CreateTag", h.timer.GetDuration())
+	return pbTag, nil
+}
+
+// タグを更新する
+func (h *TodoHandler) UpdateTag(ctx context.Context, req *pb.UpdateTagRequest) (*pb.Tag, error) {
+	h.logger.InfoLog.Println("UpdateTag called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// タグを更新する(usecase層)
+	tag := domain_todo.Tag{
+		ID:    req.Id,
+		Name:  req.Name,
+		Color: req.Color,
+	}
+	updatedTag, err := h.tagUsecase.UpdateTag(principal, tag)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to update tag: %v", err)
+		h.logger.PrintDuration("UpdateTag", h.timer.GetDuration())
+		return nil, tagStatus(err)
+	}
+
+	pbTag := toPbTag(updatedTag)
+
+	h.logger.InfoLog.Printf("UpdateTag success: %v", pbTag)
+	h.logger.PrintDuration("UpdateTag", h.timer.GetDuration())
+	return pbTag, nil
+}
+
+// タグを削除する
+func (h *TodoHandler) DeleteTag(ctx context.Context, req *pb.DeleteTagRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("DeleteTag called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// タグを削除する(usecase層)
+	err := h.tagUsecase.DeleteTag(principal, req.Id)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to delete tag: %v", err)
+		h.logger.PrintDuration("DeleteTag", h.timer.GetDuration())
+		return nil, tagStatus(err)
+	}
+
+	h.logger.InfoLog.Println("DeleteTag success")
+	h.logger.PrintDuration("DeleteTag", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// タグのユースケースのエラーをgRPCのステータスに変換
+// 対応するステータスがない場合はそのまま返す。
+func tagStatus(err error) error {
+	switch err.Error() {
+	case "unauthenticated":
+		return status.Errorf(codes.Unauthenticated, "unauthenticated")
+	case "permission denied":
+		return status.Errorf(codes.PermissionDenied, "permission denied")
+	case "id is empty", "user_id is empty", "name is empty", "name is too long", "invalid color":
+		return status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	case "tag not found", "user not found":
+		return status.Errorf(codes.NotFound, "%s", err.Error())
+	case "tag already exists":
+		return status.Errorf(codes.AlreadyExists, "tag already exists")
+	default:
+		return err
+	}
+}
+
+// ドメインのタグをgRPCのメッセージに変換
+func toPbTag(tag domain_todo.Tag) *pb.Tag {
+	return &pb.Tag{
+		Id:        tag.ID,
+		UserId:    tag.UserID,
+		Name:      tag.Name,
+		Color:     tag.Color,
+		CreatedAt: timestamppb.New(tag.CreatedAt),
+		UpdatedAt: timestamppb.New(tag.UpdatedAt),
+	}
+}
+
+// ドメインのタグの一覧をgRPCのメッセージに変換
+func toPbTags(tags []domain_todo.Tag) []*pb.Tag {
+	pbTags := make([]*pb.Tag, len(tags))
+	for i, tag := range tags {
+		pbTags[i] = toPbTag(tag)
+	}
+	return pbTags
+}
+
+// タグのIDの一覧をドメインのタグに変換(Todoの作成・更新に使用)
+func tagsFromPbIDs(ids []string) []domain_todo.Tag {
+	tags := make([]domain_todo.Tag, len(ids))
+	for i, id := range ids {
+		tags[i] = domain_todo.Tag{ID: id}
+	}
+	return tags
+}
+
+// gRPCのタグをドメインのタグに変換(IDのみ使用する)
+func tagsFromPb(pbTags []*pb.Tag) []domain_todo.Tag {
+	tags := make([]domain_todo.Tag, len(pbTags))
+	for i, pbTag := range pbTags {
+		tags[i] = domain_todo.Tag{ID: pbTag.GetId()}
+	}
+	return tags
+}
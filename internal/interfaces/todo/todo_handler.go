@@ -1,12 +1,15 @@
 package interfaces_todo
 
 import (
+	domain_auth "backend/internal/domain/auth"
 	domain_todo "backend/internal/domain/todo"
 	pkg_logger "backend/internal/pkg/logger"
 	pkg_timer "backend/internal/pkg/timer"
 	usecase_todo "backend/internal/usecase/todo"
 	pb "backend/proto/github.com/grpc/backend/proto"
 	"context"
+	"errors"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,41 +23,231 @@ type TodoHandler struct {
 	timer  *pkg_timer.TimerPkg
 	pb.UnimplementedTodoServiceServer
 	todoUsecase usecase_todo.ITodoUsecase
+	tagUsecase  usecase_todo.ITagUsecase
 }
 
 // Todoハンドラー層のインスタンス化
-func NewTodoHandler(l *pkg_logger.AppLogger, todoUsecase usecase_todo.ITodoUsecase) *TodoHandler {
-	return &TodoHandler{logger: l, todoUsecase: todoUsecase, timer: pkg_timer.NewTimerPkg()}
+func NewTodoHandler(l *pkg_logger.AppLogger, todoUsecase usecase_todo.ITodoUsecase, tagUsecase usecase_todo.ITagUsecase) *TodoHandler {
+	return &TodoHandler{logger: l, todoUsecase: todoUsecase, tagUsecase: tagUsecase, timer: pkg_timer.NewTimerPkg()}
 }
 
 // Todo情報を取得する
-func (h *TodoHandler) GetAllTodos(ctx context.Context, req *emptypb.Empty) (*pb.TodoList, error) {
+func (h *TodoHandler) GetAllTodos(ctx context.Context, req *pb.GetAllTodosRequest) (*pb.TodoList, error) {
 	h.logger.InfoLog.Println("GetTodo called")
 	h.timer.Start()
 
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
 	// Todo情報を取得する(usecase層)
-	todos, err := h.todoUsecase.GetAllTodos()
+	todos, nextPageToken, err := h.todoUsecase.GetAllTodos(principal, req.PageSize, req.PageToken)
 	if err != nil {
-		h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
-		h.logger.PrintDuration("GetAllTodos", h.timer.GetDuration())
-		return nil, err
-	}
-
-	pbTodos := make([]*pb.Todo, len(todos))
-	for i, todo := range todos {
-		pbTodos[i] = &pb.Todo{
-			Id:          todo.ID,
-			Description: todo.Description,
-			Completed:   todo.Completed,
-			UserId:      todo.UserId,
-			CreatedAt:   timestamppb.New(todo.CreatedAt),
-			UpdatedAt:   timestamppb.New(todo.UpdatedAt),
+		switch err.Error() {
+		case "invalid page_size", "invalid page_token":
+			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
+			h.logger.PrintDuration("GetAllTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
+			h.logger.PrintDuration("GetAllTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
+			h.logger.PrintDuration("GetAllTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		default:
+			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
+			h.logger.PrintDuration("GetAllTodos", h.timer.GetDuration())
+			return nil, err
 		}
 	}
 
+	pbTodos := toPbTodos(todos)
+
 	h.logger.InfoLog.Printf("GetTodo success: %v todos", len(pbTodos))
 	h.logger.PrintDuration("GetAllTodos", h.timer.GetDuration())
-	return &pb.TodoList{Todos: pbTodos}, nil
+	return &pb.TodoList{Todos: pbTodos, NextPageToken: nextPageToken}, nil
+}
+
+// 条件を指定してTodoを取得する
+func (h *TodoHandler) ListTodos(ctx context.Context, req *pb.ListTodosRequest) (*pb.TodoList, error) {
+	h.logger.InfoLog.Println("ListTodos called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// 絞り込み条件
+	filter := domain_todo.TodoFilter{
+		UserID:      req.UserId,
+		CreatedFrom: timeFromPb(req.CreatedFrom),
+		CreatedTo:   timeFromPb(req.CreatedTo),
+		UpdatedFrom: timeFromPb(req.UpdatedFrom),
+		UpdatedTo:   timeFromPb(req.UpdatedTo),
+		Description: req.DescriptionContains,
+		TagIDs:      req.TagIds,
+		TagMatch:    req.TagMatch,
+	}
+	if req.Completed != nil {
+		completed := req.Completed.Value
+		filter.Completed = &completed
+	}
+	if req.Priority != nil {
+		priority := req.Priority.Value
+		filter.Priority = &priority
+	}
+
+	// 条件を指定してTodoを取得する(usecase層)
+	todos, nextPageToken, err := h.todoUsecase.ListTodos(principal, filter, req.OrderBy, req.PageSize, req.PageToken)
+	if err != nil {
+		switch err.Error() {
+		case "invalid order_by", "invalid page_size", "invalid page_token", "invalid created_at range",
+			"invalid updated_at range", "description filter is too long", "invalid priority", "invalid tag_match",
+			"tag_id is empty", "too many tags":
+			h.logger.ErrorLog.Printf("Failed to list todos: %v", err)
+			h.logger.PrintDuration("ListTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to list todos: %v", err)
+			h.logger.PrintDuration("ListTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to list todos: %v", err)
+			h.logger.PrintDuration("ListTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		default:
+			h.logger.ErrorLog.Printf("Failed to list todos: %v", err)
+			h.logger.PrintDuration("ListTodos", h.timer.GetDuration())
+			return nil, err
+		}
+	}
+
+	pbTodos := toPbTodos(todos)
+
+	h.logger.InfoLog.Printf("ListTodos success: %v todos", len(pbTodos))
+	h.logger.PrintDuration("ListTodos", h.timer.GetDuration())
+	return &pb.TodoList{Todos: pbTodos, NextPageToken: nextPageToken}, nil
+}
+
+// 期限切れのTodoを取得する
+func (h *TodoHandler) ListOverdueTodos(ctx context.Context, req *pb.ListOverdueTodosRequest) (*pb.TodoList, error) {
+	h.logger.InfoLog.Println("ListOverdueTodos called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// 期限切れのTodoを取得する(usecase層)
+	todos, nextPageToken, err := h.todoUsecase.ListOverdueTodos(principal, req.UserId, req.PageSize, req.PageToken)
+	if err != nil {
+		switch err.Error() {
+		case "invalid page_size", "invalid page_token":
+			h.logger.ErrorLog.Printf("Failed to list overdue todos: %v", err)
+			h.logger.PrintDuration("ListOverdueTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to list overdue todos: %v", err)
+			h.logger.PrintDuration("ListOverdueTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to list overdue todos: %v", err)
+			h.logger.PrintDuration("ListOverdueTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		default:
+			h.logger.ErrorLog.Printf("Failed to list overdue todos: %v", err)
+			h.logger.PrintDuration("ListOverdueTodos", h.timer.GetDuration())
+			return nil, err
+		}
+	}
+
+	pbTodos := toPbTodos(todos)
+
+	h.logger.InfoLog.Printf("ListOverdueTodos success: %v todos", len(pbTodos))
+	h.logger.PrintDuration("ListOverdueTodos", h.timer.GetDuration())
+	return &pb.TodoList{Todos: pbTodos, NextPageToken: nextPageToken}, nil
+}
+
+// 今日が期限のTodoを取得する
+func (h *TodoHandler) ListDueToday(ctx context.Context, req *pb.ListDueTodayRequest) (*pb.TodoList, error) {
+	h.logger.InfoLog.Println("ListDueToday called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// 今日が期限のTodoを取得する(usecase層)
+	todos, nextPageToken, err := h.todoUsecase.ListDueToday(principal, req.TimeZone, req.UserId, req.PageSize, req.PageToken)
+	if err != nil {
+		switch err.Error() {
+		case "invalid time_zone", "invalid page_size", "invalid page_token":
+			h.logger.ErrorLog.Printf("Failed to list todos due today: %v", err)
+			h.logger.PrintDuration("ListDueToday", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to list todos due today: %v", err)
+			h.logger.PrintDuration("ListDueToday", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to list todos due today: %v", err)
+			h.logger.PrintDuration("ListDueToday", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		default:
+			h.logger.ErrorLog.Printf("Failed to list todos due today: %v", err)
+			h.logger.PrintDuration("ListDueToday", h.timer.GetDuration())
+			return nil, err
+		}
+	}
+
+	pbTodos := toPbTodos(todos)
+
+	h.logger.InfoLog.Printf("ListDueToday success: %v todos", len(pbTodos))
+	h.logger.PrintDuration("ListDueToday", h.timer.GetDuration())
+	return &pb.TodoList{Todos: pbTodos, NextPageToken: nextPageToken}, nil
+}
+
+// Todoの説明を検索する
+func (h *TodoHandler) SearchTodos(ctx context.Context, req *pb.SearchTodosRequest) (*pb.SearchTodosResponse, error) {
+	h.logger.InfoLog.Println("SearchTodos called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// Todoの説明を検索する(usecase層)
+	results, nextPageToken, err := h.todoUsecase.SearchTodos(principal, req.Query, req.Mode, req.UserId, req.PageSize, req.PageToken)
+	if err != nil {
+		switch err.Error() {
+		case "query is empty", "query is too long", "invalid mode", "invalid page_size", "invalid page_token":
+			h.logger.ErrorLog.Printf("Failed to search todos: %v", err)
+			h.logger.PrintDuration("SearchTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to search todos: %v", err)
+			h.logger.PrintDuration("SearchTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to search todos: %v", err)
+			h.logger.PrintDuration("SearchTodos", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		default:
+			h.logger.ErrorLog.Printf("Failed to search todos: %v", err)
+			h.logger.PrintDuration("SearchTodos", h.timer.GetDuration())
+			return nil, err
+		}
+	}
+
+	pbResults := make([]*pb.TodoSearchResult, len(results))
+	for i, result := range results {
+		pbResults[i] = &pb.TodoSearchResult{
+			Todo:    toPbTodo(result.Todo),
+			Rank:    result.Rank,
+			Snippet: result.Snippet,
+		}
+	}
+
+	h.logger.InfoLog.Printf("SearchTodos success: %v todos", len(pbResults))
+	h.logger.PrintDuration("SearchTodos", h.timer.GetDuration())
+	return &pb.SearchTodosResponse{Results: pbResults, NextPageToken: nextPageToken}, nil
 }
 
 // Todoを取得する
@@ -62,14 +255,25 @@ func (h *TodoHandler) GetTodoById(ctx context.Context, req *pb.GetTodoByIdReques
 	h.logger.InfoLog.Println("GetTodoById called")
 	h.timer.Start()
 
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
 	// Todoを取得する(usecase層)
-	todo, err := h.todoUsecase.GetTodoById(req.Id)
+	todo, err := h.todoUsecase.GetTodoById(principal, req.Id)
 	if err != nil {
 		switch err.Error() {
 		case "id is empty":
 			h.logger.ErrorLog.Printf("Failed to get todo: %v", err)
 			h.logger.PrintDuration("GetTodoById", h.timer.GetDuration())
 			return nil, status.Errorf(codes.InvalidArgument, "id is empty")
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to get todo: %v", err)
+			h.logger.PrintDuration("GetTodoById", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "todo not found":
+			h.logger.ErrorLog.Printf("Failed to get todo: %v", err)
+			h.logger.PrintDuration("GetTodoById", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "todo not found")
 		default:
 			h.logger.ErrorLog.Printf("Failed to get todo: %v", err)
 			h.logger.PrintDuration("GetTodoById", h.timer.GetDuration())
@@ -77,33 +281,75 @@ func (h *TodoHandler) GetTodoById(ctx context.Context, req *pb.GetTodoByIdReques
 		}
 	}
 
-	pbTodo := &pb.Todo{
-		Id:          todo.ID,
-		Description: todo.Description,
-		Completed:   todo.Completed,
-		UserId:      todo.UserId,
-		CreatedAt:   timestamppb.New(todo.CreatedAt),
-		UpdatedAt:   timestamppb.New(todo.UpdatedAt),
-	}
+	pbTodo := toPbTodo(todo)
 
 	h.logger.InfoLog.Printf("GetTodoById success: %v", pbTodo)
 	h.logger.PrintDuration("GetTodoById", h.timer.GetDuration())
 	return pbTodo, nil
 }
 
+// Todoとそのサブタスクを階層で取得する
+func (h *TodoHandler) GetTodoTree(ctx context.Context, req *pb.GetTodoTreeRequest) (*pb.TodoTree, error) {
+	h.logger.InfoLog.Println("GetTodoTree called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// Todoとそのサブタスクを取得する(usecase層)
+	tree, err := h.todoUsecase.GetTodoTree(principal, req.Id)
+	if err != nil {
+		switch err.Error() {
+		case "id is empty":
+			h.logger.ErrorLog.Printf("Failed to get todo tree: %v", err)
+			h.logger.PrintDuration("GetTodoTree", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "id is empty")
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to get todo tree: %v", err)
+			h.logger.PrintDuration("GetTodoTree", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "todo not found":
+			h.logger.ErrorLog.Printf("Failed to get todo tree: %v", err)
+			h.logger.PrintDuration("GetTodoTree", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "todo not found")
+		default:
+			h.logger.ErrorLog.Printf("Failed to get todo tree: %v", err)
+			h.logger.PrintDuration("GetTodoTree", h.timer.GetDuration())
+			return nil, err
+		}
+	}
+
+	pbTree := toPbTodoTree(tree)
+
+	h.logger.InfoLog.Printf("GetTodoTree success: %v children", len(pbTree.Children))
+	h.logger.PrintDuration("GetTodoTree", h.timer.GetDuration())
+	return pbTree, nil
+}
+
 // 特定のユーザーのTodoを取得する
 func (h *TodoHandler) GetTodoByUserId(ctx context.Context, req *pb.GetTodoByUserIdRequest) (*pb.TodoList, error) {
 	h.logger.InfoLog.Println("GetTodoByUserId called")
 	h.timer.Start()
 
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
 	// 特定のユーザーのTodoを取得する(usecase層)
-	todos, err := h.todoUsecase.GetTodoByUserId(req.UserId)
+	todos, nextPageToken, err := h.todoUsecase.GetTodoByUserId(principal, req.UserId, req.PageSize, req.PageToken)
 	if err != nil {
 		switch err.Error() {
-		case "user_id is empty":
+		case "user_id is empty", "invalid page_size", "invalid page_token":
 			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
 			h.logger.PrintDuration("GetTodoByUserId", h.timer.GetDuration())
-			return nil, status.Errorf(codes.InvalidArgument, "user_id is empty")
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
+			h.logger.PrintDuration("GetTodoByUserId", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
+			h.logger.PrintDuration("GetTodoByUserId", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		default:
 			h.logger.ErrorLog.Printf("Failed to get todos: %v", err)
 			h.logger.PrintDuration("GetTodoByUserId", h.timer.GetDuration())
@@ -111,21 +357,11 @@ func (h *TodoHandler) GetTodoByUserId(ctx context.Context, req *pb.GetTodoByUser
 		}
 	}
 
-	pbTodos := make([]*pb.Todo, len(todos))
-	for i, todo := range todos {
-		pbTodos[i] = &pb.Todo{
-			Id:          todo.ID,
-			Description: todo.Description,
-			Completed:   todo.Completed,
-			UserId:      todo.UserId,
-			CreatedAt:   timestamppb.New(todo.CreatedAt),
-			UpdatedAt:   timestamppb.New(todo.UpdatedAt),
-		}
-	}
+	pbTodos := toPbTodos(todos)
 
 	h.logger.InfoLog.Printf("GetTodoByUserId success: %v todos", len(pbTodos))
 	h.logger.PrintDuration("GetTodoByUserId", h.timer.GetDuration())
-	return &pb.TodoList{Todos: pbTodos}, nil
+	return &pb.TodoList{Todos: pbTodos, NextPageToken: nextPageToken}, nil
 }
 
 // Todoを作成する
@@ -133,14 +369,38 @@ func (h *TodoHandler) CreateTodo(ctx context.Context, req *pb.CreateTodoRequest)
 	h.logger.InfoLog.Println("CreateTodo called")
 	h.timer.Start()
 
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
 	// Todoを作成する(usecase層)
 	todo := domain_todo.Todo{
 		Description: req.Description,
 		UserId:      req.UserId,
+		DueAt:       timeFromPb(req.DueAt),
+		RemindAt:    timeFromPb(req.RemindAt),
+		Priority:    int32(req.Priority),
+		Tags:        tagsFromPbIDs(req.TagIds),
+		ParentID:    todoIDFromPb(req.ParentId),
 	}
-	createdTodo, err := h.todoUsecase.CreateTodo(todo)
+	createdTodo, err := h.todoUsecase.CreateTodo(principal, todo)
 	if err != nil {
 		switch err.Error() {
+		case "parent not found":
+			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
+			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "parent not found")
+		case "subtask depth limit exceeded", "parent is completed":
+			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
+			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		case "invalid priority", "tag_id is empty", "too many tags":
+			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
+			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "tag not found", "user not found":
+			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
+			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 		case "description is empty":
 			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
 			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
@@ -149,6 +409,14 @@ func (h *TodoHandler) CreateTodo(ctx context.Context, req *pb.CreateTodoRequest)
 			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
 			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
 			return nil, status.Errorf(codes.InvalidArgument, "user_id is empty")
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
+			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
+			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		default:
 			h.logger.ErrorLog.Printf("Failed to create todo: %v", err)
 			h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
@@ -156,14 +424,7 @@ func (h *TodoHandler) CreateTodo(ctx context.Context, req *pb.CreateTodoRequest)
 		}
 	}
 
-	pbTodo := &pb.Todo{
-		Id:          createdTodo.ID,
-		Description: createdTodo.Description,
-		Completed:   createdTodo.Completed,
-		UserId:      createdTodo.UserId,
-		CreatedAt:   timestamppb.New(createdTodo.CreatedAt),
-		UpdatedAt:   timestamppb.New(createdTodo.UpdatedAt),
-	}
+	pbTodo := toPbTodo(createdTodo)
 
 	h.logger.InfoLog.Printf("CreateTodo success: %v", pbTodo)
 	h.logger.PrintDuration("CreateTodo", h.timer.GetDuration())
@@ -175,16 +436,53 @@ func (h *TodoHandler) UpdateTodo(ctx context.Context, req *pb.UpdateTodoRequest)
 	h.logger.InfoLog.Println("UpdateTodo called")
 	h.timer.Start()
 
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
 	// Todoを更新する(usecase層)
 	todo := domain_todo.Todo{
 		ID:          req.Id,
 		Description: req.Description,
 		Completed:   req.Completed,
 		UserId:      req.UserId,
+		Version:     req.Version,
+		DueAt:       timeFromPb(req.DueAt),
+		RemindAt:    timeFromPb(req.RemindAt),
+		Priority:    int32(req.Priority),
+		Tags:        tagsFromPbIDs(req.TagIds),
+		ParentID:    todoIDFromPb(req.ParentId),
 	}
-	updatedTodo, err := h.todoUsecase.UpdateTodo(todo)
+	updatedTodo, err := h.todoUsecase.UpdateTodo(principal, todo)
 	if err != nil {
 		switch err.Error() {
+		case "parent_id creates a cycle":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "parent_id creates a cycle")
+		case "parent not found":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "parent not found")
+		case "subtask depth limit exceeded", "todo has open subtasks", "parent is completed", "cannot change owner of todo with subtasks":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		case "invalid priority", "tag_id is empty", "too many tags":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "tag not found", "user not found":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
+		case "version is empty":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "version is empty")
+		case "version conflict":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, versionConflictStatus(err)
 		case "id is empty":
 			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
 			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
@@ -197,6 +495,18 @@ func (h *TodoHandler) UpdateTodo(ctx context.Context, req *pb.UpdateTodoRequest)
 			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
 			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
 			return nil, status.Errorf(codes.InvalidArgument, "user_id is empty")
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "todo not found":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "todo not found")
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
+			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		default:
 			h.logger.ErrorLog.Printf("Failed to update todo: %v", err)
 			h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
@@ -204,33 +514,109 @@ func (h *TodoHandler) UpdateTodo(ctx context.Context, req *pb.UpdateTodoRequest)
 		}
 	}
 
-	pbTodo := &pb.Todo{
-		Id:          updatedTodo.ID,
-		Description: updatedTodo.Description,
-		Completed:   updatedTodo.Completed,
-		UserId:      updatedTodo.UserId,
-		CreatedAt:   timestamppb.New(updatedTodo.CreatedAt),
-		UpdatedAt:   timestamppb.New(updatedTodo.UpdatedAt),
-	}
+	pbTodo := toPbTodo(updatedTodo)
 
 	h.logger.InfoLog.Printf("UpdateTodo success: %v", pbTodo)
 	h.logger.PrintDuration("UpdateTodo", h.timer.GetDuration())
 	return pbTodo, nil
 }
 
+// Todoの指定した項目のみを更新する
+func (h *TodoHandler) PatchTodo(ctx context.Context, req *pb.PatchTodoRequest) (*pb.Todo, error) {
+	h.logger.InfoLog.Println("PatchTodo called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// Todoの指定した項目を更新する(usecase層)
+	todo := domain_todo.Todo{
+		ID:          req.GetTodo().GetId(),
+		Description: req.GetTodo().GetDescription(),
+		Completed:   req.GetTodo().GetCompleted(),
+		UserId:      req.GetTodo().GetUserId(),
+		Version:     req.GetTodo().GetVersion(),
+		DueAt:       timeFromPb(req.GetTodo().GetDueAt()),
+		RemindAt:    timeFromPb(req.GetTodo().GetRemindAt()),
+		Priority:    int32(req.GetTodo().GetPriority()),
+		Tags:        tagsFromPb(req.GetTodo().GetTags()),
+		ParentID:    todoIDFromPb(req.GetTodo().GetParentId()),
+	}
+	patchedTodo, err := h.todoUsecase.PatchTodo(principal, todo, req.GetUpdateMask().GetPaths())
+	if err != nil {
+		switch err.Error() {
+		case "id is empty", "version is empty", "description is empty", "user_id is empty", "update_mask is empty",
+			"invalid update_mask", "immutable field in update_mask", "invalid priority", "tag_id is empty", "too many tags",
+			"parent_id creates a cycle":
+			h.logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+			h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case "version conflict":
+			h.logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+			h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+			return nil, versionConflictStatus(err)
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+			h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "todo not found", "tag not found", "parent not found", "user not found":
+			h.logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+			h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
+		case "subtask depth limit exceeded", "todo has open subtasks", "parent is completed", "cannot change owner of todo with subtasks":
+			h.logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+			h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		case "permission denied":
+			h.logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+			h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		default:
+			h.logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+			h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+			return nil, err
+		}
+	}
+
+	pbTodo := toPbTodo(patchedTodo)
+
+	h.logger.InfoLog.Printf("PatchTodo success: %v", pbTodo)
+	h.logger.PrintDuration("PatchTodo", h.timer.GetDuration())
+	return pbTodo, nil
+}
+
 // Todoを削除する
 func (h *TodoHandler) DeleteTodo(ctx context.Context, req *pb.DeleteTodoRequest) (*emptypb.Empty, error) {
 	h.logger.InfoLog.Println("DeleteTodo called")
 	h.timer.Start()
 
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
 	// Todoを削除する(usecase層)
-	err := h.todoUsecase.DeleteTodo(req.Id)
+	err := h.todoUsecase.DeleteTodo(principal, req.Id, req.Version)
 	if err != nil {
 		switch err.Error() {
 		case "id is empty":
 			h.logger.ErrorLog.Printf("Failed to delete todo: %v", err)
 			h.logger.PrintDuration("DeleteTodo", h.timer.GetDuration())
 			return nil, status.Errorf(codes.InvalidArgument, "id is empty")
+		case "version is empty":
+			h.logger.ErrorLog.Printf("Failed to delete todo: %v", err)
+			h.logger.PrintDuration("DeleteTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.InvalidArgument, "version is empty")
+		case "version conflict":
+			h.logger.ErrorLog.Printf("Failed to delete todo: %v", err)
+			h.logger.PrintDuration("DeleteTodo", h.timer.GetDuration())
+			return nil, versionConflictStatus(err)
+		case "unauthenticated":
+			h.logger.ErrorLog.Printf("Failed to delete todo: %v", err)
+			h.logger.PrintDuration("DeleteTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+		case "todo not found":
+			h.logger.ErrorLog.Printf("Failed to delete todo: %v", err)
+			h.logger.PrintDuration("DeleteTodo", h.timer.GetDuration())
+			return nil, status.Errorf(codes.NotFound, "todo not found")
 		default:
 			h.logger.ErrorLog.Printf("Failed to delete todo: %v", err)
 			h.logger.PrintDuration("DeleteTodo", h.timer.GetDuration())
@@ -242,3 +628,85 @@ func (h *TodoHandler) DeleteTodo(ctx context.Context, req *pb.DeleteTodoRequest)
 	h.logger.PrintDuration("DeleteTodo", h.timer.GetDuration())
 	return &emptypb.Empty{}, nil
 }
+
+// ドメインのTodoをgRPCのメッセージに変換
+func toPbTodo(todo domain_todo.Todo) *pb.Todo {
+	return &pb.Todo{
+		Id:          todo.ID,
+		Description: todo.Description,
+		Completed:   todo.Completed,
+		UserId:      todo.UserId,
+		CreatedAt:   timestamppb.New(todo.CreatedAt),
+		UpdatedAt:   timestamppb.New(todo.UpdatedAt),
+		Version:     todo.Version,
+		DueAt:       timeToPb(todo.DueAt),
+		RemindAt:    timeToPb(todo.RemindAt),
+		Priority:    pb.Priority(todo.Priority),
+		Tags:        toPbTags(todo.Tags),
+		ParentId:    todoIDToPb(todo.ParentID),
+	}
+}
+
+// ドメインのTodoの階層をgRPCのメッセージに変換
+func toPbTodoTree(tree domain_todo.TodoTree) *pb.TodoTree {
+	children := make([]*pb.TodoTree, len(tree.Children))
+	for i, child := range tree.Children {
+		children[i] = toPbTodoTree(child)
+	}
+	return &pb.TodoTree{Todo: toPbTodo(tree.Todo), Children: children}
+}
+
+// バージョンが一致しない場合のステータス(ABORTED)を作成
+// クライアントがマージできるよう、詳細にサーバーの現在のTodoを含める。
+func versionConflictStatus(err error) error {
+	st := status.New(codes.Aborted, "version conflict")
+	var conflict *domain_todo.VersionConflictError
+	if errors.As(err, &conflict) {
+		if detailed, detailErr := st.WithDetails(toPbTodo(conflict.Current)); detailErr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// ドメインのTodoの一覧をgRPCのメッセージに変換
+func toPbTodos(todos []domain_todo.Todo) []*pb.Todo {
+	pbTodos := make([]*pb.Todo, len(todos))
+	for i, todo := range todos {
+		pbTodos[i] = toPbTodo(todo)
+	}
+	return pbTodos
+}
+
+// gRPCのタイムスタンプを日時に変換(未指定の場合はnil)
+func timeFromPb(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// gRPCのTodoのIDを変換(空の場合はnil)
+func todoIDFromPb(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+// TodoのIDをgRPCの値に変換(未設定の場合は空)
+func todoIDToPb(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return *id
+}
+
+// 日時をgRPCのタイムスタンプに変換(未設定の場合はnil)
+func timeToPb(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -1,13 +1,18 @@
 package interfaces_user
 
 import (
+	domain_auth "backend/internal/domain/auth"
+	domain_user "backend/internal/domain/user"
 	pkg_logger "backend/internal/pkg/logger"
 	pkg_timer "backend/internal/pkg/timer"
 	usecase_user "backend/internal/usecase/user"
 	pb "backend/proto/github.com/grpc/backend/proto"
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ユーザーハンドラー層
@@ -49,3 +54,195 @@ func (h *UserHandler) GetAllUsers(ctx context.Context, req *emptypb.Empty) (*pb.
 	h.logger.PrintDuration("GetAllUsers", h.timer.GetDuration())
 	return &pb.UserList{Users: pbUsers}, nil
 }
+
+// ユーザー一覧をページ単位で取得する
+func (h *UserHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	h.logger.InfoLog.Println("ListUsers called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ユーザー一覧を取得する(usecase層)
+	users, nextPageToken, err := h.userUsecase.ListUsers(principal, req.Query, req.OrderBy, req.PageSize, req.PageToken)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to list users: %v", err)
+		h.logger.PrintDuration("ListUsers", h.timer.GetDuration())
+		return nil, userStatus(err, "failed to list users")
+	}
+
+	pbUsers := make([]*pb.User, len(users))
+	for i, user := range users {
+		pbUsers[i] = toPbUser(user)
+	}
+
+	h.logger.InfoLog.Printf("ListUsers success: %v users", len(pbUsers))
+	h.logger.PrintDuration("ListUsers", h.timer.GetDuration())
+	return &pb.ListUsersResponse{Users: pbUsers, NextPageToken: nextPageToken}, nil
+}
+
+// idを指定してユーザーを取得する
+func (h *UserHandler) GetUserById(ctx context.Context, req *pb.GetUserByIdRequest) (*pb.User, error) {
+	h.logger.InfoLog.Println("GetUserById called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ユーザーを取得する(usecase層)
+	user, err := h.userUsecase.GetUserById(principal, req.Id)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to get user: %v", err)
+		h.logger.PrintDuration("GetUserById", h.timer.GetDuration())
+		return nil, userStatus(err, "failed to get user")
+	}
+
+	h.logger.InfoLog.Printf("GetUserById success: %v", user.ID)
+	h.logger.PrintDuration("GetUserById", h.timer.GetDuration())
+	return toPbUser(user), nil
+}
+
+// 実行者自身のユーザーを取得する
+func (h *UserHandler) GetMe(ctx context.Context, req *emptypb.Empty) (*pb.User, error) {
+	h.logger.InfoLog.Println("GetMe called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ユーザーを取得する(usecase層)
+	user, err := h.userUsecase.GetMe(principal)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to get user: %v", err)
+		h.logger.PrintDuration("GetMe", h.timer.GetDuration())
+		return nil, userStatus(err, "failed to get user")
+	}
+
+	h.logger.InfoLog.Printf("GetMe success: %v", user.ID)
+	h.logger.PrintDuration("GetMe", h.timer.GetDuration())
+	return toPbUser(user), nil
+}
+
+// ユーザー名・メールアドレスを更新する
+func (h *UserHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.User, error) {
+	h.logger.InfoLog.Println("UpdateProfile called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ユーザーを更新する(usecase層)
+	user, err := h.userUsecase.UpdateProfile(principal, req.Id, req.Username, req.Email)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to update user: %v", err)
+		h.logger.PrintDuration("UpdateProfile", h.timer.GetDuration())
+		return nil, userStatus(err, "failed to update user")
+	}
+
+	h.logger.InfoLog.Printf("UpdateProfile success: %v", user.ID)
+	h.logger.PrintDuration("UpdateProfile", h.timer.GetDuration())
+	return toPbUser(user), nil
+}
+
+// ユーザーを無効化する
+func (h *UserHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("DeleteUser called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ユーザーを無効化する(usecase層)
+	err := h.userUsecase.DeleteUser(principal, req.Id)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to delete user: %v", err)
+		h.logger.PrintDuration("DeleteUser", h.timer.GetDuration())
+		return nil, userStatus(err, "failed to delete user")
+	}
+
+	h.logger.InfoLog.Printf("DeleteUser success: %v", req.Id)
+	h.logger.PrintDuration("DeleteUser", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// 無効化したユーザーを再有効化する
+func (h *UserHandler) ReactivateUser(ctx context.Context, req *pb.ReactivateUserRequest) (*emptypb.Empty, error) {
+	h.logger.InfoLog.Println("ReactivateUser called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ユーザーを再有効化する(usecase層)
+	err := h.userUsecase.ReactivateUser(principal, req.Id)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to reactivate user: %v", err)
+		h.logger.PrintDuration("ReactivateUser", h.timer.GetDuration())
+		return nil, userStatus(err, "failed to reactivate user")
+	}
+
+	h.logger.InfoLog.Printf("ReactivateUser success: %v", req.Id)
+	h.logger.PrintDuration("ReactivateUser", h.timer.GetDuration())
+	return &emptypb.Empty{}, nil
+}
+
+// 無効化済みのユーザーを完全に削除する
+func (h *UserHandler) PurgeUser(ctx context.Context, req *pb.PurgeUserRequest) (*pb.PurgeUserResponse, error) {
+	h.logger.InfoLog.Println("PurgeUser called")
+	h.timer.Start()
+
+	// インターセプターで設定された認証情報を取得
+	principal, _ := domain_auth.PrincipalFromContext(ctx)
+
+	// ユーザーを完全に削除する(usecase層)
+	result, err := h.userUsecase.PurgeUser(principal, req.Id, req.TodoPolicy, req.ReassignToUserId)
+	if err != nil {
+		h.logger.ErrorLog.Printf("Failed to purge user: %v", err)
+		h.logger.PrintDuration("PurgeUser", h.timer.GetDuration())
+		return nil, userStatus(err, "failed to purge user")
+	}
+
+	h.logger.InfoLog.Printf("PurgeUser success: %v", req.Id)
+	h.logger.PrintDuration("PurgeUser", h.timer.GetDuration())
+	return &pb.PurgeUserResponse{TodosAffected: result.TodosAffected}, nil
+}
+
+// ユースケースのエラーをgRPCのステータスに変換
+// 対応するステータスがない場合はInternal(message)を返す。
+func userStatus(err error, message string) error {
+	switch err.Error() {
+	case "unauthenticated":
+		return status.Errorf(codes.Unauthenticated, "unauthenticated")
+	case "id is empty", "username or email is required", "invalid username format", "invalid email format",
+		"query is too long", "invalid order_by", "invalid page_size", "invalid page_token",
+		"invalid todo_policy", "reassign_to is required", "cannot purge yourself":
+		return status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	case "permission denied":
+		return status.Errorf(codes.PermissionDenied, "permission denied")
+	case "user not found":
+		return status.Errorf(codes.NotFound, "user not found")
+	case "user already exists":
+		return status.Errorf(codes.AlreadyExists, "username or email already exists")
+	case "user already deactivated", "user is not deactivated", "reassign target not found", "user is in use",
+		"cannot remove the last admin":
+		return status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s", message)
+	}
+}
+
+// ドメインのユーザーをgRPCのメッセージに変換
+func toPbUser(user domain_user.Users) *pb.User {
+	pbUser := &pb.User{
+		Id:                      user.ID,
+		Username:                user.Username,
+		Email:                   user.Email,
+		EmailVerificationStatus: string(user.EmailVerificationStatus),
+		CreatedAt:               timestamppb.New(user.CreatedAt),
+		UpdatedAt:               timestamppb.New(user.UpdatedAt),
+	}
+	if user.DeactivatedAt != nil {
+		pbUser.DeactivatedAt = timestamppb.New(*user.DeactivatedAt)
+	}
+	return pbUser
+}
@@ -0,0 +1,221 @@
+package pkg_keyset
+
+import (
+	pkg_logger "backend/internal/pkg/logger"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// 署名鍵
+type SigningKey struct {
+	// 鍵ID(ファイル名から拡張子を除いたもの)
+	KID string
+	// 署名方式(RS256 または EdDSA)
+	Method jwt.SigningMethod
+	// 秘密鍵
+	PrivateKey crypto.PrivateKey
+	// 公開鍵
+	PublicKey crypto.PublicKey
+}
+
+// JWT署名鍵のセット
+// ディレクトリ内のPEMファイルを読み込み、kidごとに管理する。
+// ファイル名の辞書順で最後の鍵を署名に使用し、それ以外の鍵は検証のみに使用する。
+type KeySet struct {
+	logger *pkg_logger.AppLogger
+	dir    string
+
+	mu        sync.RWMutex
+	keys      map[string]*SigningKey
+	activeKID string
+	// 前回読み込み時のファイル一覧(変更検知用)
+	snapshot string
+}
+
+// JWT署名鍵のセットのインスタンス化
+func NewKeySet(l *pkg_logger.AppLogger, dir string) *KeySet {
+	return &KeySet{
+		logger: l,
+		dir:    dir,
+		keys:   map[string]*SigningKey{},
+	}
+}
+
+// ディレクトリから鍵を読み込む
+// 有効な鍵が1つも無い場合はエラーを返し、現在の鍵セットを維持する。
+func (k *KeySet) Load() error {
+	snapshot, err := k.readSnapshot()
+	if err != nil {
+		return err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(k.dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	keys := map[string]*SigningKey{}
+	activeKID := ""
+	for _, path := range paths {
+		key, err := loadSigningKey(path)
+		if err != nil {
+			k.logger.WarnLog.Printf("Skipping invalid signing key %s: %v", path, err)
+			continue
+		}
+		keys[key.KID] = key
+		activeKID = key.KID
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no valid signing keys found in %s", k.dir)
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.activeKID = activeKID
+	k.snapshot = snapshot
+	k.mu.Unlock()
+
+	k.logger.InfoLog.Printf("Loaded %d signing keys. Active kid: %s", len(keys), activeKID)
+	return nil
+}
+
+// 定期的にディレクトリを確認し、変更があれば鍵を再読み込みする
+// 再起動せずに鍵のローテーションを行うために使用する。
+func (k *KeySet) StartAutoReload(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			snapshot, err := k.readSnapshot()
+			if err != nil {
+				k.logger.ErrorLog.Printf("Failed to read signing key directory: %v", err)
+				continue
+			}
+
+			k.mu.RLock()
+			changed := snapshot != k.snapshot
+			k.mu.RUnlock()
+			if !changed {
+				continue
+			}
+
+			k.logger.InfoLog.Println("Signing key directory changed. Reloading keys...")
+			if err := k.Load(); err != nil {
+				k.logger.ErrorLog.Printf("Failed to reload signing keys: %v", err)
+			}
+		}
+	}()
+}
+
+// 署名に使用する鍵を取得
+func (k *KeySet) SigningKey() (*SigningKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[k.activeKID]
+	if !ok {
+		return nil, errors.New("no active signing key")
+	}
+	return key, nil
+}
+
+// kidから検証用の鍵を取得
+func (k *KeySet) VerificationKey(kid string) (*SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// JWTを検証するためのKeyfunc
+// ヘッダーのkidで鍵を選択し、署名方式が鍵と一致しない場合は拒否する。
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("missing kid")
+	}
+	key, ok := k.VerificationKey(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown kid: %s", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+	}
+	return key.PublicKey, nil
+}
+
+// ディレクトリ内のPEMファイルの名前・更新日時・サイズを連結した文字列を取得
+func (k *KeySet) readSnapshot() (string, error) {
+	entries, err := os.ReadDir(k.dir)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".pem" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", entry.Name(), info.ModTime().UnixNano(), info.Size())
+	}
+	return b.String(), nil
+}
+
+// PEMファイルから署名鍵を読み込む
+// PKCS#8(RSA/Ed25519)とPKCS#1(RSA)の秘密鍵に対応する。
+func loadSigningKey(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var privateKey interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < 2048 {
+			return nil, errors.New("RSA key must be at least 2048 bits")
+		}
+		return &SigningKey{KID: kid, Method: jwt.SigningMethodRS256, PrivateKey: key, PublicKey: &key.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{KID: kid, Method: jwt.SigningMethodEdDSA, PrivateKey: key, PublicKey: key.Public()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", privateKey)
+	}
+}
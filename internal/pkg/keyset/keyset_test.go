@@ -0,0 +1,298 @@
+package pkg_keyset
+
+import (
+	pkg_logger "backend/internal/pkg/logger"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// ログを出力しない鍵セット
+func newTestKeySet(dir string) *KeySet {
+	l := pkg_logger.NewAppLogger()
+	l.InfoLog.SetOutput(io.Discard)
+	l.ErrorLog.SetOutput(io.Discard)
+	l.WarnLog.SetOutput(io.Discard)
+	return NewKeySet(l, dir)
+}
+
+// Ed25519の秘密鍵をPKCS#8のPEMファイルとして書き込む
+func writeEd25519Key(t *testing.T, dir string, name string) ed25519.PublicKey {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	writePEM(t, dir, name, "PRIVATE KEY", der)
+	return pub
+}
+
+// RSAの秘密鍵をPKCS#1のPEMファイルとして書き込む
+func writeRSAKey(t *testing.T, dir string, name string, bits int) *rsa.PublicKey {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	writePEM(t, dir, name, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(priv))
+	return &priv.PublicKey
+}
+
+func writePEM(t *testing.T, dir string, name string, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+// 鍵セットの署名鍵でkidを付けたJWTを作成
+func signTestToken(t *testing.T, key *SigningKey, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(key.Method, jwt.MapClaims{"sub": "user-1"})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func TestLoadSelectsActiveKeyByFilename(t *testing.T) {
+	dir := t.TempDir()
+	// 書き込み順ではなくファイル名の辞書順で最後の鍵を署名に使用する
+	writeEd25519Key(t, dir, "2024-03.pem")
+	writeEd25519Key(t, dir, "2024-01.pem")
+	writeEd25519Key(t, dir, "2024-02.pem")
+
+	ks := newTestKeySet(dir)
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	key, err := ks.SigningKey()
+	if err != nil {
+		t.Fatalf("SigningKey() error = %v", err)
+	}
+	if key.KID != "2024-03" {
+		t.Errorf("SigningKey().KID = %q, want %q", key.KID, "2024-03")
+	}
+	for _, kid := range []string{"2024-01", "2024-02", "2024-03"} {
+		if _, ok := ks.VerificationKey(kid); !ok {
+			t.Errorf("VerificationKey(%q) ok = false, want true", kid)
+		}
+	}
+}
+
+func TestLoadSkipsInvalidKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeEd25519Key(t, dir, "a.pem")
+	// 後ろの名前でも不正な鍵は署名に使用しない
+	if err := os.WriteFile(filepath.Join(dir, "z-broken.pem"), []byte("not a pem"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	writePEM(t, dir, "y-cert.pem", "CERTIFICATE", []byte("cert"))
+	writeRSAKey(t, dir, "x-short.pem", 1024)
+	writeEd25519Key(t, dir, "ignored.txt")
+
+	ks := newTestKeySet(dir)
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	key, err := ks.SigningKey()
+	if err != nil {
+		t.Fatalf("SigningKey() error = %v", err)
+	}
+	if key.KID != "a" {
+		t.Errorf("SigningKey().KID = %q, want %q", key.KID, "a")
+	}
+	for _, kid := range []string{"z-broken", "y-cert", "x-short", "ignored"} {
+		if _, ok := ks.VerificationKey(kid); ok {
+			t.Errorf("VerificationKey(%q) ok = true, want false", kid)
+		}
+	}
+}
+
+func TestLoadWithoutValidKeys(t *testing.T) {
+	dir := t.TempDir()
+	ks := newTestKeySet(dir)
+	if err := ks.Load(); err == nil {
+		t.Fatal("Load() error = nil, want error for empty directory")
+	}
+	if _, err := ks.SigningKey(); err == nil {
+		t.Error("SigningKey() error = nil, want error")
+	}
+
+	// 有効な鍵がなくなった場合は現在の鍵セットを維持する
+	writeEd25519Key(t, dir, "a.pem")
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := os.Remove(filepath.Join(dir, "a.pem")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := ks.Load(); err == nil {
+		t.Fatal("Load() error = nil, want error after removing all keys")
+	}
+	if key, err := ks.SigningKey(); err != nil || key.KID != "a" {
+		t.Errorf("SigningKey() = %v, %v, want kid a", key, err)
+	}
+}
+
+func TestReloadRotatesKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeEd25519Key(t, dir, "2024-01.pem")
+	ks := newTestKeySet(dir)
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	oldKey, err := ks.SigningKey()
+	if err != nil {
+		t.Fatalf("SigningKey() error = %v", err)
+	}
+	oldToken := signTestToken(t, oldKey, oldKey.KID)
+
+	// 新しい鍵を追加すると署名に使用し、古い鍵で署名したトークンも検証できる
+	writeEd25519Key(t, dir, "2024-02.pem")
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	newKey, err := ks.SigningKey()
+	if err != nil {
+		t.Fatalf("SigningKey() error = %v", err)
+	}
+	if newKey.KID != "2024-02" {
+		t.Errorf("SigningKey().KID = %q, want %q", newKey.KID, "2024-02")
+	}
+	if _, err := jwt.Parse(oldToken, ks.Keyfunc); err != nil {
+		t.Errorf("Parse(old token) error = %v, want nil", err)
+	}
+
+	// 古い鍵を削除すると、そのkidのトークンは拒否される
+	if err := os.Remove(filepath.Join(dir, "2024-01.pem")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := ks.VerificationKey("2024-01"); ok {
+		t.Error("VerificationKey(2024-01) ok = true after removal, want false")
+	}
+	if _, err := jwt.Parse(oldToken, ks.Keyfunc); err == nil {
+		t.Error("Parse(old token) error = nil after removal, want error")
+	}
+	if _, err := jwt.Parse(signTestToken(t, newKey, newKey.KID), ks.Keyfunc); err != nil {
+		t.Errorf("Parse(new token) error = %v, want nil", err)
+	}
+}
+
+func TestKeyfuncRejectsInvalidHeaders(t *testing.T) {
+	dir := t.TempDir()
+	writeEd25519Key(t, dir, "ed.pem")
+	writeRSAKey(t, dir, "rsa.pem", 2048)
+	ks := newTestKeySet(dir)
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	edKey, _ := ks.VerificationKey("ed")
+	rsaKey, _ := ks.VerificationKey("rsa")
+
+	// 別の鍵のkidを付けたトークン(署名方式が一致しない)
+	mismatched := signTestToken(t, edKey, "rsa")
+	// kidのないトークン
+	noKID := jwt.NewWithClaims(edKey.Method, jwt.MapClaims{"sub": "user-1"})
+	noKIDToken, err := noKID.SignedString(edKey.PrivateKey)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	// HS256で公開鍵を共有鍵として署名したトークン
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1"})
+	hs.Header["kid"] = "ed"
+	hsToken, err := hs.SignedString([]byte(edKey.PublicKey.(ed25519.PublicKey)))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"Ed25519", signTestToken(t, edKey, "ed"), false},
+		{"RSA", signTestToken(t, rsaKey, "rsa"), false},
+		{"未知のkid", signTestToken(t, edKey, "unknown"), true},
+		{"kidなし", noKIDToken, true},
+		{"署名方式が鍵と異なる", mismatched, true},
+		{"HS256", hsToken, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.Parse(tt.token, ks.Keyfunc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	dir := t.TempDir()
+	edPub := writeEd25519Key(t, dir, "b-ed.pem")
+	rsaPub := writeRSAKey(t, dir, "a-rsa.pem", 2048)
+	ks := newTestKeySet(dir)
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	jwks := ks.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("JWKS() keys = %d, want 2", len(jwks.Keys))
+	}
+
+	// kidの昇順に並ぶ
+	rsaJWK, edJWK := jwks.Keys[0], jwks.Keys[1]
+	if rsaJWK.Kid != "a-rsa" || edJWK.Kid != "b-ed" {
+		t.Fatalf("JWKS() kids = %q, %q, want a-rsa, b-ed", rsaJWK.Kid, edJWK.Kid)
+	}
+
+	if rsaJWK.Kty != "RSA" || rsaJWK.Alg != "RS256" || rsaJWK.Use != "sig" || rsaJWK.Crv != "" || rsaJWK.X != "" {
+		t.Errorf("JWKS() RSA key = %+v", rsaJWK)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(rsaJWK.N)
+	if err != nil || new(big.Int).SetBytes(n).Cmp(rsaPub.N) != 0 {
+		t.Errorf("JWKS() RSA n does not match the public key (err = %v)", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(rsaJWK.E)
+	if err != nil || new(big.Int).SetBytes(e).Int64() != int64(rsaPub.E) {
+		t.Errorf("JWKS() RSA e does not match the public key (err = %v)", err)
+	}
+
+	if edJWK.Kty != "OKP" || edJWK.Crv != "Ed25519" || edJWK.Alg != "EdDSA" || edJWK.Use != "sig" || edJWK.N != "" || edJWK.E != "" {
+		t.Errorf("JWKS() Ed25519 key = %+v", edJWK)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(edJWK.X)
+	if err != nil || !edPub.Equal(ed25519.PublicKey(x)) {
+		t.Errorf("JWKS() Ed25519 x does not match the public key (err = %v)", err)
+	}
+}
+
+func TestJWKSEmpty(t *testing.T) {
+	ks := newTestKeySet(t.TempDir())
+	jwks := ks.JWKS()
+	if jwks.Keys == nil || len(jwks.Keys) != 0 {
+		t.Errorf("JWKS() = %+v, want empty keys", jwks)
+	}
+}
@@ -0,0 +1,42 @@
+package pkg_mailer
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// メールの内容
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// メール送信(IF)
+type IMailer interface {
+	// メールを送信
+	Send(msg Message) error
+}
+
+// RFC 5322形式のメールを組み立てる
+// ヘッダーインジェクションを防ぐため、改行を含むアドレス・件名はエラーとする。
+func buildMessage(from string, msg Message) ([]byte, error) {
+	if strings.ContainsAny(from+msg.To+msg.Subject, "\r\n") {
+		return nil, errors.New("invalid mail header")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,44 @@
+package pkg_mailer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ファイル出力によるメール送信(ローカル開発・テスト用)
+// 送信する代わりに、指定ディレクトリに.emlファイルとして保存する。
+type OutboxMailer struct {
+	Dir  string
+	From string
+}
+
+// ファイル出力によるメール送信のインスタンス化
+func NewOutboxMailer(dir string, from string) IMailer {
+	return &OutboxMailer{
+		Dir:  dir,
+		From: from,
+	}
+}
+
+// メールをファイルに保存
+func (m *OutboxMailer) Send(msg Message) error {
+	data, err := buildMessage(m.From, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o700); err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+	return os.WriteFile(filepath.Join(m.Dir, name), data, 0o600)
+}
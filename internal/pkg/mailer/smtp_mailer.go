@@ -0,0 +1,44 @@
+package pkg_mailer
+
+import (
+	"net"
+	"net/smtp"
+	"strconv"
+)
+
+// SMTPによるメール送信
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPによるメール送信のインスタンス化
+func NewSMTPMailer(host string, port int, username string, password string, from string) IMailer {
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+	}
+}
+
+// メールを送信
+// ユーザー名が設定されている場合はPLAIN認証を行う(STARTTLSが利用可能な場合は自動で使用される)。
+func (m *SMTPMailer) Send(msg Message) error {
+	data, err := buildMessage(m.From, msg)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	addr := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+	return smtp.SendMail(addr, auth, m.From, []string{msg.To}, data)
+}
@@ -0,0 +1,26 @@
+package pkg_notifier
+
+import (
+	pkg_logger "backend/internal/pkg/logger"
+	"time"
+)
+
+// ログ出力による通知(ローカル開発・テスト用)
+// 送信する代わりに、通知の内容をログに出力する。
+type LogNotifier struct {
+	Logger *pkg_logger.AppLogger
+}
+
+// ログ出力による通知のインスタンス化
+func NewLogNotifier(l *pkg_logger.AppLogger) INotifier {
+	return &LogNotifier{
+		Logger: l,
+	}
+}
+
+// 通知をログに出力
+func (n *LogNotifier) Notify(notification Notification) error {
+	n.Logger.InfoLog.Printf("Notification %s: user=%s todo=%s remind_at=%s",
+		notification.Event, notification.UserID, notification.TodoID, notification.RemindAt.Format(time.RFC3339))
+	return nil
+}
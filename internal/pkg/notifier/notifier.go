@@ -0,0 +1,25 @@
+package pkg_notifier
+
+import "time"
+
+// 通知の種類
+const (
+	// Todoのリマインダー
+	EventTodoReminder = "todo.reminder"
+)
+
+// 通知の内容
+type Notification struct {
+	Event       string     `json:"event"`
+	UserID      string     `json:"user_id"`
+	TodoID      string     `json:"todo_id"`
+	Description string     `json:"description"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	RemindAt    time.Time  `json:"remind_at"`
+}
+
+// 通知の送信(IF)
+type INotifier interface {
+	// 通知を送信
+	Notify(n Notification) error
+}
@@ -0,0 +1,75 @@
+package pkg_notifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Webhookの署名ヘッダー(sha256=<HMAC-SHA256(タイムスタンプ + "." + 本文)の16進数>)
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+// Webhookのタイムスタンプヘッダー(UNIX秒。受信側でリプレイ攻撃の検出に使用する)
+const WebhookTimestampHeader = "X-Webhook-Timestamp"
+
+// Webhookの送信のタイムアウト
+const webhookTimeout = 10 * time.Second
+
+// Webhookによる通知
+// 通知の内容をJSONでPOSTし、2xx以外の応答はエラーとする。シークレットが設定されている場合は本文に署名する。
+type WebhookNotifier struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+// Webhookによる通知のインスタンス化
+func NewWebhookNotifier(url string, secret string) INotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Secret: secret,
+		Client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// 通知をWebhookに送信
+func (n *WebhookNotifier) Notify(notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(n.Secret))
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(body)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// コネクションを再利用できるよう、本文を読み捨てる
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package pkg_pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+)
+
+// ページサイズの既定値(未指定の場合)
+const DefaultPageSize = 20
+
+// ページサイズの上限(超える場合は上限に切り詰める)
+const MaxPageSize = 100
+
+// ページトークンが不正な場合のエラー
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// カーソルのIDの形式(UUID)
+var idPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ページサイズが不正な場合のエラー
+var ErrInvalidPageSize = errors.New("invalid page size")
+
+// 要求されたページサイズを正規化する
+// 0の場合は既定値、上限を超える場合は上限を返す。負の値はErrInvalidPageSizeを返す。
+func PageSize(requested int32) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, ErrInvalidPageSize
+	case requested == 0:
+		return DefaultPageSize, nil
+	case requested > MaxPageSize:
+		return MaxPageSize, nil
+	default:
+		return int(requested), nil
+	}
+}
+
+// カーソル(キーセットの値)を不透明なページトークンにエンコードする
+// クライアントは中身に依存せず、そのまま次のリクエストに渡すこと。
+func EncodeToken(cursor interface{}) (string, error) {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ページトークンをカーソルにデコードする
+// 不正なトークンはErrInvalidPageTokenを返す。
+func DecodeToken(token string, cursor interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return ErrInvalidPageToken
+	}
+	return nil
+}
+
+// カーソルのIDがUUIDの形式か
+// 改ざんされたトークンのIDをそのままクエリに渡すと、DBでエラーになるため事前に検証する。
+func IsValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
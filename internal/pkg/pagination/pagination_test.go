@@ -0,0 +1,99 @@
+package pkg_pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+// テスト用のカーソル
+type testCursor struct {
+	ID    string `json:"i"`
+	Scope string `json:"s"`
+}
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested int32
+		want      int
+		wantErr   error
+	}{
+		{"未指定は既定値", 0, DefaultPageSize, nil},
+		{"上限以下はそのまま", 10, 10, nil},
+		{"上限ちょうど", MaxPageSize, MaxPageSize, nil},
+		{"上限を超える場合は切り詰める", MaxPageSize + 1, MaxPageSize, nil},
+		{"負の値は不正", -1, 0, ErrInvalidPageSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PageSize(tt.requested)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PageSize(%d) error = %v, want %v", tt.requested, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PageSize(%d) = %d, want %d", tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeToken(t *testing.T) {
+	want := testCursor{ID: "6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b", Scope: "all"}
+	token, err := EncodeToken(want)
+	if err != nil {
+		t.Fatalf("EncodeToken() error = %v", err)
+	}
+
+	var got testCursor
+	if err := DecodeToken(token, &got); err != nil {
+		t.Fatalf("DecodeToken() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("DecodeToken() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeTokenInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"base64でない", "!!!"},
+		{"JSONでない", base64.RawURLEncoding.EncodeToString([]byte("not json"))},
+		{"型が異なる", base64.RawURLEncoding.EncodeToString([]byte(`{"i":1}`))},
+		{"パディング付きのbase64", base64.URLEncoding.EncodeToString([]byte(`{"i":"xy"}`))},
+		{"途中で切れたトークン", base64.RawURLEncoding.EncodeToString([]byte(`{"i":"xy"}`))[:8]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursor testCursor
+			if err := DecodeToken(tt.token, &cursor); !errors.Is(err, ErrInvalidPageToken) {
+				t.Errorf("DecodeToken(%q) error = %v, want %v", tt.token, err, ErrInvalidPageToken)
+			}
+		})
+	}
+}
+
+func TestIsValidID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{"UUID", "6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b", true},
+		{"大文字のUUID", "6F1C2A9E-3B4D-4E5F-8A7B-9C0D1E2F3A4B", true},
+		{"空", "", false},
+		{"ハイフンなし", "6f1c2a9e3b4d4e5f8a7b9c0d1e2f3a4b", false},
+		{"16進数以外を含む", "6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4z", false},
+		{"前後に余分な文字", "x6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b", false},
+		{"SQLを含む", "' OR 1=1 --", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidID(tt.id); got != tt.want {
+				t.Errorf("IsValidID(%q) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
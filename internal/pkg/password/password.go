@@ -0,0 +1,74 @@
+package pkg_password
+
+import (
+	"crypto/subtle"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// パスワードハッシャー
+type PasswordHasher struct {
+	// bcryptのコスト
+	Cost int
+	// 存在しないユーザーの照合に使用するダミーハッシュ
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+}
+
+// パスワードハッシャーのインスタンス化
+// コストがbcryptの許容範囲外の場合はデフォルトコストを使用する。
+func NewPasswordHasher(cost int) *PasswordHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &PasswordHasher{Cost: cost}
+}
+
+// パスワードをハッシュ化
+func (p *PasswordHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), p.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// パスワードを検証
+// matchedは一致した場合にtrueを返す。
+// needsRehashは平文で保存された旧形式、またはコストが設定値と異なる場合にtrueを返す。
+func (p *PasswordHasher) Verify(hashed string, password string) (matched bool, needsRehash bool) {
+	if !IsHashed(hashed) {
+		// 旧形式(平文)のパスワードは定数時間で比較する
+		matched = subtle.ConstantTimeCompare([]byte(hashed), []byte(password)) == 1
+		return matched, matched
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
+	if err != nil {
+		return false, false
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashed))
+	if err != nil {
+		return true, true
+	}
+	return true, cost != p.Cost
+}
+
+// ダミーハッシュと照合する
+// 存在しないユーザーのログイン時にも同等の処理時間をかけ、応答時間からのユーザー推測を防ぐ。
+func (p *PasswordHasher) VerifyDummy(password string) {
+	p.dummyHashOnce.Do(func() {
+		p.dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), p.Cost)
+	})
+	_ = bcrypt.CompareHashAndPassword(p.dummyHash, []byte(password))
+}
+
+// ハッシュ化済みのパスワードかどうかを判定
+func IsHashed(hashed string) bool {
+	return strings.HasPrefix(hashed, "$2a$") ||
+		strings.HasPrefix(hashed, "$2b$") ||
+		strings.HasPrefix(hashed, "$2y$")
+}
@@ -0,0 +1,68 @@
+package pkg_secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// 秘密情報の暗号化(AES-256-GCM)
+// TOTPのシークレットなど、復号が必要な値をDBに保存する際に使用する。
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// 秘密情報の暗号化のインスタンス化
+// 鍵はBase64でエンコードされた32バイトの値(例: openssl rand -base64 32)。
+func NewSecretBox(encodedKey string) (*SecretBox, error) {
+	if encodedKey == "" {
+		return nil, errors.New("encryption key is empty")
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid encryption key length: %d bytes (expected 32)", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretBox{aead: aead}, nil
+}
+
+// 暗号化し、nonceと暗号文を連結してBase64で返す
+func (b *SecretBox) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Encryptで暗号化した値を復号
+func (b *SecretBox) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	nonceSize := b.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("ciphertext too short")
+	}
+	plaintext, err := b.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
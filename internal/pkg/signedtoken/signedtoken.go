@@ -0,0 +1,68 @@
+package pkg_signedtoken
+
+import (
+	pkg_keyset "backend/internal/pkg/keyset"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// トークンが不正・期限切れ・用途違いの場合のエラー
+var ErrInvalidToken = errors.New("invalid signed token")
+
+// 用途を限定した署名付きトークン(メールのリンクなどに埋め込む)
+// JWTの署名鍵で署名し、typクレームで用途を区別する。アクセストークンとしては使用できない。
+type Signer struct {
+	keySet *pkg_keyset.KeySet
+}
+
+// 署名付きトークンのインスタンス化
+func NewSigner(keySet *pkg_keyset.KeySet) *Signer {
+	return &Signer{keySet: keySet}
+}
+
+// 用途(purpose)とクレームを含むトークンを生成
+func (s *Signer) Sign(purpose string, claims map[string]interface{}, ttl time.Duration) (string, error) {
+	// 署名鍵を取得
+	signingKey, err := s.keySet.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	mapClaims := jwt.MapClaims{}
+	for k, v := range claims {
+		mapClaims[k] = v
+	}
+	mapClaims["typ"] = purpose
+	mapClaims["iat"] = now.Unix()
+	mapClaims["exp"] = now.Add(ttl).Unix()
+
+	token := jwt.NewWithClaims(signingKey.Method, mapClaims)
+	token.Header["kid"] = signingKey.KID
+
+	return token.SignedString(signingKey.PrivateKey)
+}
+
+// トークンを検証し、クレームを取得
+// 署名・有効期限・用途のいずれかが不正な場合はErrInvalidTokenを返す。
+func (s *Signer) Verify(purpose string, tokenString string) (map[string]interface{}, error) {
+	if tokenString == "" {
+		return nil, ErrInvalidToken
+	}
+
+	token, err := jwt.Parse(tokenString, s.keySet.Keyfunc)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	if typ, _ := claims["typ"].(string); typ != purpose {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
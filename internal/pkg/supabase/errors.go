@@ -0,0 +1,34 @@
+package pkg_supabase
+
+import (
+	"errors"
+
+	"github.com/jackc/pgconn"
+)
+
+// 一意制約違反のエラーコード
+const uniqueViolationCode = "23505"
+
+// 一意制約違反のエラーかどうか
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// 外部キー制約違反のエラーコード
+const foreignKeyViolationCode = "23503"
+
+// 外部キー制約違反のエラーかどうか
+func IsForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode
+}
+
+// 不正な入力値(uuidの形式が不正など)のエラーコード
+const invalidTextRepresentationCode = "22P02"
+
+// 不正な入力値のエラーかどうか
+func IsInvalidTextRepresentation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == invalidTextRepresentationCode
+}
@@ -0,0 +1,8 @@
+package pkg_supabase
+
+import "strings"
+
+// LIKE検索のワイルドカード(%, _)とエスケープ文字をエスケープする
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
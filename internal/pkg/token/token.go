@@ -0,0 +1,34 @@
+package pkg_token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// 推測不可能なランダムトークンを生成する
+// byteLenバイトの乱数をURLセーフなBase64でエンコードして返す。
+func GenerateOpaqueToken(byteLen int) (string, error) {
+	b := make([]byte, byteLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ランダムなIDを生成する(JWTのjtiなどに使用)
+func GenerateID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// トークンをSHA-256でハッシュ化する
+// 十分なエントロピーを持つトークンの保存用であり、パスワードには使用しないこと。
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package pkg_totp
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"image/png"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// TOTPの設定(RFC 6238 / Google Authenticator互換)
+const (
+	// コードの有効期間(秒)
+	period = 30
+	// 前後に許容するステップ数(時刻のずれ対策)
+	skew = 1
+	// コードの桁数
+	digits = otp.DigitsSix
+	// QRコード画像のサイズ(px)
+	qrCodeSize = 256
+)
+
+// TOTPの鍵
+type Key struct {
+	// Base32でエンコードされたシークレット
+	Secret string
+	// 認証アプリに登録するotpauth URI(QRコードの内容)
+	URI string
+}
+
+// TOTPの鍵を生成
+func Generate(issuer string, accountName string) (Key, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: accountName,
+		Period:      period,
+		Digits:      digits,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{Secret: key.Secret(), URI: key.URL()}, nil
+}
+
+// otpauth URIからQRコード画像(PNG)を生成
+func QRCodePNG(uri string) ([]byte, error) {
+	key, err := otp.NewKeyFromURL(uri)
+	if err != nil {
+		return nil, err
+	}
+	img, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// コードを検証
+// 一致した場合は時間ステップ(Unix時刻/期間)を返す。同じステップの再利用(リプレイ)の判定に使用する。
+func Validate(secret string, code string, now time.Time) (int64, bool) {
+	if len(code) != digits.Length() {
+		return 0, false
+	}
+
+	current := now.Unix() / period
+	for i := -skew; i <= skew; i++ {
+		step := current + int64(i)
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*period, 0), totp.ValidateOpts{
+			Period:    period,
+			Digits:    digits,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
@@ -1,7 +1,29 @@
 package repository_auth
 
+import (
+	domain_user "backend/internal/domain/user"
+	"errors"
+	"time"
+)
+
+// ユーザーが存在しない場合のエラー
+var ErrUserNotFound = errors.New("user not found")
+
+// メールアドレスの確認状態が遷移できない場合のエラー(確認済み・メールアドレス変更済みなど)
+var ErrEmailVerificationConflict = errors.New("email verification state conflict")
+
 // 認証リポジトリ(IF)
 type IAuthRepository interface {
-	// ログイン
-	Login(email string, password string) (string, error)
+	// メールアドレスからユーザーを取得
+	GetUserByEmail(email string) (domain_user.Users, error)
+	// IDからユーザーを取得
+	GetUserByID(id string) (domain_user.Users, error)
+	// パスワードを更新
+	UpdatePassword(id string, hashedPassword string) error
+	// パスワードを変更し、現在のセッション以外のセッション・リフレッシュトークンを失効
+	ChangePassword(id string, hashedPassword string, currentSessionID string) error
+	// 確認メールの送信を記録
+	MarkEmailVerificationSent(id string, sentAt time.Time) error
+	// メールアドレスを確認済みにする
+	MarkEmailVerified(id string, email string) error
 }
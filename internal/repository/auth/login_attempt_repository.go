@@ -0,0 +1,18 @@
+package repository_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	"time"
+)
+
+// ログイン試行リポジトリ(IF)
+type ILoginAttemptRepository interface {
+	// キーを指定してログイン試行の記録を取得
+	GetLoginAttempts(keys []string) ([]domain_auth.LoginAttempt, error)
+	// ログイン失敗を記録(resetAfterより前の失敗は数えない)
+	RecordLoginFailure(key string, resetAfter time.Duration) (domain_auth.LoginAttempt, error)
+	// ロック解除日時を設定
+	LockLoginAttempt(key string, lockedUntil time.Time) error
+	// ログイン試行の記録を削除
+	ResetLoginAttempts(keys []string) error
+}
@@ -0,0 +1,17 @@
+package repository_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	"errors"
+)
+
+// パスワード再設定トークンが無効(存在しない・使用済み・期限切れ)の場合のエラー
+var ErrPasswordResetTokenInvalid = errors.New("password reset token invalid")
+
+// パスワード再設定リポジトリ(IF)
+type IPasswordResetRepository interface {
+	// パスワード再設定トークンを作成(同じユーザーの未使用トークンは無効化する)
+	CreatePasswordResetToken(token domain_auth.PasswordResetToken) error
+	// トークンを使用済みにしてパスワードを更新(ユーザーのリフレッシュトークン・セッションも失効させる)
+	ResetPassword(tokenHash string, hashedPassword string) (string, error)
+}
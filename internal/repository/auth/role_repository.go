@@ -0,0 +1,35 @@
+package repository_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	"errors"
+)
+
+// ロールが存在しない場合のエラー
+var ErrRoleNotFound = errors.New("role not found")
+
+// ロールが既に付与されている場合のエラー
+var ErrRoleAlreadyAssigned = errors.New("role already assigned")
+
+// ロールが付与されていない場合のエラー
+var ErrRoleNotAssigned = errors.New("role not assigned")
+
+// 最後の管理者からadminロールを剥奪しようとした場合のエラー
+var ErrLastAdmin = errors.New("cannot revoke the last admin")
+
+// 最初の管理者の作成時に、既に有効な管理者が存在する場合のエラー
+var ErrAdminAlreadyExists = errors.New("admin already exists")
+
+// ロールリポジトリ(IF)
+type IRoleRepository interface {
+	// 全てのロールを取得
+	GetAllRoles() ([]domain_auth.Role, error)
+	// ユーザーに付与されたロールを取得
+	GetRolesByUserId(userID string) ([]domain_auth.Role, error)
+	// 有効な管理者が存在しない場合のみ、ユーザーにadminロールを付与し、監査ログを記録
+	BootstrapAdmin(userID string, actorID string) error
+	// ユーザーにロールを付与し、監査ログを記録
+	AssignRole(userID string, role string, actorID string) error
+	// ユーザーからロールを剥奪し、監査ログを記録
+	RevokeRole(userID string, role string, actorID string) error
+}
@@ -0,0 +1,26 @@
+package repository_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	"errors"
+	"time"
+)
+
+// セッションが存在しない場合のエラー
+var ErrSessionNotFound = errors.New("session not found")
+
+// セッションリポジトリ(IF)
+type ISessionRepository interface {
+	// セッションを作成
+	CreateSession(session domain_auth.Session) (domain_auth.Session, error)
+	// 特定のセッションを取得
+	GetSessionById(id string) (domain_auth.Session, error)
+	// ユーザーの有効なセッションを取得(activeSince以降に利用されたもの)
+	GetActiveSessionsByUserId(userID string, activeSince time.Time) ([]domain_auth.Session, error)
+	// セッションを失効し、紐づくリフレッシュトークンも失効
+	RevokeSession(userID string, id string) error
+	// ユーザーの全セッションを失効し、リフレッシュトークンも全て失効(exceptIDのセッションは除く)
+	RevokeUserSessions(userID string, exceptID string) error
+	// 最終利用日時を更新
+	TouchSession(id string, seenAt time.Time) error
+}
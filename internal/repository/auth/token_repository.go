@@ -0,0 +1,29 @@
+package repository_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	"errors"
+	"time"
+)
+
+// リフレッシュトークンが存在しない場合のエラー
+var ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+// リフレッシュトークンが既に使用(失効)済みの場合のエラー
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+
+// トークンリポジトリ(IF)
+type ITokenRepository interface {
+	// リフレッシュトークンを作成
+	CreateRefreshToken(token domain_auth.RefreshToken) (domain_auth.RefreshToken, error)
+	// ハッシュ値からリフレッシュトークンを取得
+	GetRefreshTokenByHash(tokenHash string) (domain_auth.RefreshToken, error)
+	// リフレッシュトークンをローテーション(旧トークンを失効させ、新トークンを作成)
+	RotateRefreshToken(oldID string, newToken domain_auth.RefreshToken) (domain_auth.RefreshToken, error)
+	// トークン系列を全て失効
+	RevokeTokenFamily(familyID string) error
+	// アクセストークンを失効
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	// アクセストークンが失効済みか確認
+	IsAccessTokenRevoked(jti string) (bool, error)
+}
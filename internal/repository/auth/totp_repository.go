@@ -0,0 +1,25 @@
+package repository_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	"errors"
+)
+
+// TOTPの設定が存在しない場合のエラー
+var ErrTOTPNotFound = errors.New("totp not found")
+
+// TOTPリポジトリ(IF)
+type ITOTPRepository interface {
+	// ユーザーのTOTPの設定を取得
+	GetTOTP(userID string) (domain_auth.UserTOTP, error)
+	// シークレットを保存(未有効化の状態で登録し直す)
+	SaveTOTPSecret(userID string, encryptedSecret string) error
+	// TOTPを有効化し、リカバリーコードを登録(既存のリカバリーコードは削除)
+	EnableTOTP(userID string, step int64, recoveryCodeHashes []string) error
+	// TOTPを無効化し、リカバリーコードを削除
+	DisableTOTP(userID string) error
+	// 使用したコードの時間ステップを記録(使用済みのステップ以前の場合はfalse)
+	UseTOTPStep(userID string, step int64) (bool, error)
+	// リカバリーコードを使用済みにする(未使用のコードが存在しない場合はfalse)
+	UseRecoveryCode(userID string, codeHash string) (bool, error)
+}
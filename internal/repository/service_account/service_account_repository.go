@@ -0,0 +1,36 @@
+package repository_service_account
+
+import (
+	domain_service_account "backend/internal/domain/service_account"
+	"errors"
+	"time"
+)
+
+// サービスアカウント名が既に使用されている場合のエラー
+var ErrServiceAccountAlreadyExists = errors.New("service account already exists")
+
+// サービスアカウントが存在しない場合のエラー
+var ErrServiceAccountNotFound = errors.New("service account not found")
+
+// APIキーが存在しない場合のエラー
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// サービスアカウントリポジトリ(IF)
+type IServiceAccountRepository interface {
+	// サービスアカウントを作成
+	CreateServiceAccount(serviceAccount domain_service_account.ServiceAccount) (domain_service_account.ServiceAccount, error)
+	// 全てのサービスアカウントを取得
+	GetAllServiceAccounts() ([]domain_service_account.ServiceAccount, error)
+	// 特定のサービスアカウントを取得
+	GetServiceAccountById(id string) (domain_service_account.ServiceAccount, error)
+	// APIキーを作成
+	CreateAPIKey(key domain_service_account.APIKey) (domain_service_account.APIKey, error)
+	// サービスアカウントのAPIキーを取得
+	GetAPIKeysByServiceAccountId(serviceAccountId string) ([]domain_service_account.APIKey, error)
+	// ハッシュ値からAPIキーを取得
+	GetAPIKeyByHash(keyHash string) (domain_service_account.APIKey, error)
+	// APIキーを失効
+	RevokeAPIKey(id string) error
+	// APIキーの最終使用日時を更新
+	UpdateAPIKeyLastUsedAt(id string, usedAt time.Time) error
+}
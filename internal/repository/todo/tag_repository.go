@@ -0,0 +1,29 @@
+package repository_todo
+
+import (
+	domain_todo "backend/internal/domain/todo"
+	"errors"
+)
+
+// タグが存在しない(または所有者が異なる)場合のエラー
+var ErrTagNotFound = errors.New("tag not found")
+
+// 同じユーザーに同じ名前のタグが存在する場合のエラー
+var ErrTagAlreadyExists = errors.New("tag already exists")
+
+// タグの所有者が存在しない場合のエラー
+var ErrTagOwnerNotFound = errors.New("tag owner not found")
+
+// タグリポジトリ(IF)
+type ITagRepository interface {
+	// 特定のユーザーのタグを名前順に取得
+	GetTagsByUserId(userID string) ([]domain_todo.Tag, error)
+	// 特定のタグを取得
+	GetTagById(id string) (domain_todo.Tag, error)
+	// 新しいタグを作成
+	CreateTag(tag domain_todo.Tag) (domain_todo.Tag, error)
+	// 特定のタグの名前・色を更新
+	UpdateTag(tag domain_todo.Tag) (domain_todo.Tag, error)
+	// 特定のタグを削除(Todoからも外れる)
+	DeleteTag(id string) error
+}
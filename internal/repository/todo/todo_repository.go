@@ -2,20 +2,60 @@ package repository_todo
 
 import (
 	domain_todo "backend/internal/domain/todo"
+	"errors"
+	"time"
 )
 
+// Todoが存在しない場合のエラー
+var ErrTodoNotFound = errors.New("todo not found")
+
+// Todoのバージョンが一致しない(他のクライアントに更新された)場合のエラー
+var ErrTodoVersionConflict = errors.New("todo version conflict")
+
+// 親のTodoが存在しない(または所有者が異なる)場合のエラー
+var ErrTodoParentNotFound = errors.New("parent todo not found")
+
+// 親のTodoに自身・自身のサブタスクを指定した場合のエラー
+var ErrTodoParentCycle = errors.New("todo parent cycle")
+
+// 階層の深さがMaxTodoDepthを超える場合のエラー
+var ErrTodoTreeTooDeep = errors.New("todo tree too deep")
+
+// 未完了のサブタスクがあるTodoを完了にしようとした場合のエラー(ParentCompletionBlock)
+var ErrTodoHasOpenSubtasks = errors.New("todo has open subtasks")
+
+// 完了済みの親に未完了のサブタスクを追加しようとした場合のエラー(ParentCompletionBlock)
+var ErrTodoParentCompleted = errors.New("parent todo completed")
+
+// サブタスクのあるTodoの所有者を変更しようとした場合のエラー
+var ErrTodoSubtaskOwnerMismatch = errors.New("todo subtask owner mismatch")
+
+// Todoの所有者(ユーザー)が存在しない場合のエラー
+var ErrTodoOwnerNotFound = errors.New("todo owner not found")
+
 // Todoリポジトリ(IF)
 type ITodoRepository interface {
-	// 全てのTodoを取得
-	GetAllTodos() ([]domain_todo.Todo, error)
+	// 全てのTodoをページ単位で取得
+	GetAllTodos(page domain_todo.TodoPageQuery) ([]domain_todo.Todo, error)
 	// 特定のTodoを取得
 	GetTodoById(id string) (domain_todo.Todo, error)
-	// 特定のユーザーのTodoを取得
-	GetTodoByUserId(userId string) ([]domain_todo.Todo, error)
-	// 新しいTodoを作成
-	CreateTodo(todo domain_todo.Todo) (domain_todo.Todo, error)
-	// 特定のTodoを更新
-	UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo, error)
-	// 特定のTodoを削除
-	DeleteTodo(id string) error
+	// 特定のユーザーのTodoをページ単位で取得
+	GetTodoByUserId(userId string, page domain_todo.TodoPageQuery) ([]domain_todo.Todo, error)
+	// 条件に一致するTodoをページ単位で取得
+	ListTodos(filter domain_todo.TodoFilter, page domain_todo.TodoPageQuery) ([]domain_todo.Todo, error)
+	// 説明を検索し、関連度の高い順に取得
+	SearchTodos(query domain_todo.TodoSearchQuery) ([]domain_todo.TodoSearchResult, error)
+	// 特定のTodoとその子孫を階層の浅い順に取得
+	GetTodoTree(id string) ([]domain_todo.Todo, error)
+	// 新しいTodoを作成(ruleに従い親のTodoの完了状態を扱う)
+	CreateTodo(todo domain_todo.Todo, rule domain_todo.ParentCompletionRule) (domain_todo.Todo, error)
+	// 特定のTodoを更新(todo.Versionが現在のバージョンと一致する場合のみ。ruleに従い親のTodoの完了状態を扱う)
+	UpdateTodo(todo domain_todo.Todo, rule domain_todo.ParentCompletionRule) (domain_todo.Todo, error)
+	// 特定のTodoの指定した項目のみを更新(versionが現在のバージョンと一致する場合のみ。ruleに従い親のTodoの完了状態を扱う)
+	PatchTodo(id string, version int64, patch domain_todo.TodoPatch, rule domain_todo.ParentCompletionRule) (domain_todo.Todo, error)
+	// 特定のTodoとそのサブタスクを削除(versionが現在のバージョンと一致する場合のみ。ruleに従い親のTodoの完了状態を扱う)
+	DeleteTodo(id string, version int64, rule domain_todo.ParentCompletionRule) error
+	// 通知日時を過ぎた未送信のリマインダーを最大limit件確保してdispatchに渡し、結果を記録(送信数・失敗数を返す)
+	// 確保した行はleaseの間、他の呼び出しから取得されない
+	ProcessDueReminders(limit int, maxAttempts int, lease time.Duration, dispatch func(todo domain_todo.Todo) error) (int, int, error)
 }
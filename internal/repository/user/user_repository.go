@@ -2,10 +2,46 @@ package repository_user
 
 import (
 	domain_user "backend/internal/domain/user"
+	"errors"
 )
 
+// ユーザー名またはメールアドレスが既に使用されている場合のエラー
+var ErrUserAlreadyExists = errors.New("user already exists")
+
+// ユーザーが存在しない場合のエラー
+var ErrUserNotFound = errors.New("user not found")
+
+// 他のデータから参照されているため削除できない場合のエラー
+var ErrUserInUse = errors.New("user is referenced by other records")
+
+// 既に無効化されている場合のエラー
+var ErrUserAlreadyDeactivated = errors.New("user already deactivated")
+
+// 無効化されていない場合のエラー(パージ・再有効化時)
+var ErrUserNotDeactivated = errors.New("user is not deactivated")
+
+// 最後の有効な管理者を無効化・削除しようとした場合のエラー
+var ErrLastAdmin = errors.New("cannot remove the last admin")
+
+// Todoの付け替え先のユーザーが存在しない(または無効化されている)場合のエラー
+var ErrReassignTargetNotFound = errors.New("reassign target not found")
+
 // ユーザーリポジトリ(IF)
 type IUserRepository interface {
 	// 全ユーザー取得
 	GetAllUsers() ([]domain_user.Users, error)
+	// 条件に一致するユーザーをキーセットで取得
+	ListUsers(query domain_user.ListUsersQuery) ([]domain_user.Users, error)
+	// ユーザー作成
+	CreateUser(user domain_user.Users, role string) (domain_user.Users, error)
+	// 特定のユーザーを取得
+	GetUserById(id string) (domain_user.Users, error)
+	// ユーザー名・メールアドレスを更新(メールアドレスが変わった場合は未確認に戻す)
+	UpdateUser(user domain_user.Users) (domain_user.Users, error)
+	// ユーザーを無効化し、セッション・リフレッシュトークンを失効
+	DeactivateUser(id string) error
+	// 無効化したユーザーを再有効化
+	ReactivateUser(id string) error
+	// 無効化済みのユーザーを完全に削除し、監査ログを記録
+	PurgeUser(id string, policy domain_user.TodoCascadePolicy, reassignTo string, actorID string) (domain_user.PurgeResult, error)
 }
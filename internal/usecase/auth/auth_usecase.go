@@ -1,34 +1,111 @@
 package usecase_auth
 
 import (
+	domain_auth "backend/internal/domain/auth"
+	domain_user "backend/internal/domain/user"
 	pkg_logger "backend/internal/pkg/logger"
+	pkg_password "backend/internal/pkg/password"
+	pkg_token "backend/internal/pkg/token"
 	repository_auth "backend/internal/repository/auth"
+	repository_user "backend/internal/repository/user"
 	"errors"
-	"regexp"
+	"time"
 )
 
+// パスワードの最小文字数
+const minPasswordLength = 8
+
+// パスワードの最大バイト数(bcryptの上限)
+const maxPasswordBytes = 72
+
 // 認証ユースケース(IF)
 type IAuthUsecase interface {
 	// ログイン
-	Login(email string, password string) (string, error)
+	Login(email string, password string, clientAddr string) (string, error)
+	// アカウントのロックを解除
+	UnlockAccount(email string) error
+	// ユーザー登録
+	Register(username string, email string, password string) (domain_user.Users, error)
+	// パスワードを変更
+	ChangePassword(userID string, sessionID string, currentPassword string, newPassword string) error
+	// リフレッシュトークンを発行
+	IssueRefreshToken(userID string, sessionID string) (string, error)
+	// リフレッシュトークンをローテーション
+	RefreshToken(refreshToken string) (string, string, string, error)
+	// ログアウト
+	Logout(userID string, sessionID string, refreshToken string, jti string, expiresAt time.Time) error
+	// アクセストークンが失効済みか確認
+	IsAccessTokenRevoked(jti string) (bool, error)
+	// アクセストークン(チャレンジトークンを含む)を失効
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	// セッションを作成
+	CreateSession(userID string, userAgent string, ipAddress string) (string, error)
+	// セッションが有効か確認
+	ValidateSession(sessionID string, userID string) error
+	// 自分の有効なセッション一覧を取得
+	ListSessions(userID string) ([]domain_auth.Session, error)
+	// 自分のセッションを失効
+	RevokeSession(userID string, sessionID string) error
+	// ユーザーの全セッションを失効
+	RevokeAllUserSessions(userID string) error
 }
 
 // 認証ユースケース(Impl)
 type AuthUsecase struct {
-	Logger         *pkg_logger.AppLogger
-	authRepository repository_auth.IAuthRepository
+	Logger          *pkg_logger.AppLogger
+	authRepository  repository_auth.IAuthRepository
+	tokenRepository repository_auth.ITokenRepository
+	userRepository  repository_user.IUserRepository
+	passwordHasher  *pkg_password.PasswordHasher
+	refreshTokenTTL time.Duration
+	// ログイン試行の制限
+	loginAttemptRepository repository_auth.ILoginAttemptRepository
+	emailThrottle          domain_auth.LoginThrottlePolicy
+	clientThrottle         domain_auth.LoginThrottlePolicy
+	// ログインセッション
+	sessionRepository repository_auth.ISessionRepository
+	// メールアドレスが未確認のユーザーのログインを拒否する
+	requireVerifiedEmail bool
+	// 新規登録したユーザーに付与するロール
+	defaultRole string
 }
 
 // 認証ユースケースのインスタンス化
-func NewAuthUsecase(l *pkg_logger.AppLogger, ar repository_auth.IAuthRepository) IAuthUsecase {
+func NewAuthUsecase(
+	l *pkg_logger.AppLogger,
+	ar repository_auth.IAuthRepository,
+	tr repository_auth.ITokenRepository,
+	ur repository_user.IUserRepository,
+	sr repository_auth.ISessionRepository,
+	lar repository_auth.ILoginAttemptRepository,
+	ph *pkg_password.PasswordHasher,
+	refreshTokenTTL time.Duration,
+	emailThrottle domain_auth.LoginThrottlePolicy,
+	clientThrottle domain_auth.LoginThrottlePolicy,
+	requireVerifiedEmail bool,
+	defaultRole string,
+) IAuthUsecase {
 	return &AuthUsecase{
-		Logger:         l,
-		authRepository: ar,
+		Logger:          l,
+		authRepository:  ar,
+		tokenRepository: tr,
+		userRepository:  ur,
+		passwordHasher:  ph,
+		refreshTokenTTL: refreshTokenTTL,
+
+		loginAttemptRepository: lar,
+		emailThrottle:          emailThrottle,
+		clientThrottle:         clientThrottle,
+
+		sessionRepository:    sr,
+		requireVerifiedEmail: requireVerifiedEmail,
+		defaultRole:          defaultRole,
 	}
 }
 
 // ログイン
-func (u *AuthUsecase) Login(email string, password string) (string, error) {
+// メールアドレス・クライアントアドレスごとに失敗回数を記録し、閾値を超えた場合は一定期間ログインを拒否する。
+func (u *AuthUsecase) Login(email string, password string, clientAddr string) (string, error) {
 	u.Logger.InfoLog.Println("Login called")
 
 	// バリデーション
@@ -37,19 +114,431 @@ func (u *AuthUsecase) Login(email string, password string) (string, error) {
 		return "", errors.New("invalid email or password")
 	}
 	// Emailの形式チェック
-	matched, err := regexp.MatchString(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`, email)
-	if err != nil || !matched {
+	if !domain_user.IsValidEmail(email) {
 		u.Logger.ErrorLog.Println("Invalid email format")
 		return "", errors.New("invalid email format")
 	}
 
-	// 認証リポジトリからログイン(repository層)
-	id, err := u.authRepository.Login(email, password)
+	// ログイン試行の制限を確認
+	targets := u.throttleTargets(email, clientAddr)
+	if err := u.checkLoginThrottle(targets); err != nil {
+		return "", err
+	}
+
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByEmail(email)
 	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			// 存在しないユーザーでもハッシュ照合を行い、応答時間からの推測を防ぐ
+			u.passwordHasher.VerifyDummy(password)
+			u.recordLoginFailure(targets)
+			u.Logger.ErrorLog.Println("Invalid email or password")
+			return "", errors.New("invalid email or password")
+		}
 		u.Logger.ErrorLog.Printf("Failed to login: %v", err)
 		return "", errors.New("failed to login")
 	}
 
+	// パスワードの検証
+	matched, needsRehash := u.passwordHasher.Verify(user.Password, password)
+	if !matched {
+		u.recordLoginFailure(targets)
+		u.Logger.ErrorLog.Println("Invalid email or password")
+		return "", errors.New("invalid email or password")
+	}
+
+	// 平文など旧形式のパスワードはログイン成功時にハッシュ化して置き換える
+	if needsRehash {
+		u.rehashPassword(user.ID, password)
+	}
+
+	// メールアドレスの失敗回数をリセット
+	// クライアントアドレスは他アカウントへの攻撃を考慮し、期間経過でのみリセットする。
+	err = u.loginAttemptRepository.ResetLoginAttempts([]string{targets[0].key})
+	if err != nil {
+		u.Logger.WarnLog.Printf("Failed to reset login attempts: %v", err)
+	}
+
+	// 無効化されたユーザーはログインできない
+	if user.IsDeactivated() {
+		u.Logger.ErrorLog.Printf("Account deactivated: %s", user.ID)
+		return "", errors.New("account deactivated")
+	}
+
+	// メールアドレスの確認
+	if u.requireVerifiedEmail && !user.EmailVerificationStatus.IsVerified() {
+		u.Logger.ErrorLog.Printf("Email not verified: %s", user.ID)
+		return "", errors.New("email not verified")
+	}
+
 	u.Logger.InfoLog.Println("Login successful. 1 user found")
-	return id, nil
+	return user.ID, nil
+}
+
+// ユーザー登録
+// パスワードはハッシュ化して保存する。
+func (u *AuthUsecase) Register(username string, email string, password string) (domain_user.Users, error) {
+	u.Logger.InfoLog.Println("Register called")
+
+	email = domain_user.NormalizeEmail(email)
+
+	// バリデーション
+	if username == "" {
+		u.Logger.ErrorLog.Println("username is empty")
+		return domain_user.Users{}, errors.New("username is empty")
+	}
+	if !domain_user.IsValidUsername(username) {
+		u.Logger.ErrorLog.Println("Invalid username format")
+		return domain_user.Users{}, errors.New("invalid username format")
+	}
+	if email == "" {
+		u.Logger.ErrorLog.Println("email is empty")
+		return domain_user.Users{}, errors.New("email is empty")
+	}
+	// Emailの形式チェック
+	if !domain_user.IsValidEmail(email) {
+		u.Logger.ErrorLog.Println("Invalid email format")
+		return domain_user.Users{}, errors.New("invalid email format")
+	}
+	// パスワードの長さチェック
+	if err := validatePassword(password); err != nil {
+		u.Logger.ErrorLog.Printf("Invalid password: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	// パスワードのハッシュ化
+	hashed, err := u.passwordHasher.Hash(password)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to hash password: %v", err)
+		return domain_user.Users{}, errors.New("failed to register")
+	}
+
+	// ユーザーリポジトリからユーザーを作成し、デフォルトのロールを付与(repository層)
+	user, err := u.userRepository.CreateUser(domain_user.Users{
+		Username: username,
+		Email:    email,
+		Password: hashed,
+	}, u.defaultRole)
+	if err != nil {
+		if errors.Is(err, repository_user.ErrUserAlreadyExists) {
+			u.Logger.ErrorLog.Println("User already exists")
+			return domain_user.Users{}, errors.New("user already exists")
+		}
+		u.Logger.ErrorLog.Printf("Failed to create user: %v", err)
+		return domain_user.Users{}, errors.New("failed to register")
+	}
+
+	u.Logger.InfoLog.Printf("Registered user: %s", user.ID)
+	return user, nil
+}
+
+// パスワードを変更
+// 現在のパスワードの確認に失敗し続けた場合は一定期間拒否する。
+// 成功時は現在のセッション以外のセッション・リフレッシュトークンを全て失効させる。
+func (u *AuthUsecase) ChangePassword(userID string, sessionID string, currentPassword string, newPassword string) error {
+	u.Logger.InfoLog.Println("ChangePassword called")
+
+	// バリデーション
+	if currentPassword == "" {
+		u.Logger.ErrorLog.Println("current_password is empty")
+		return errors.New("current_password is empty")
+	}
+	if err := validatePassword(newPassword); err != nil {
+		u.Logger.ErrorLog.Printf("Invalid password: %v", err)
+		return err
+	}
+	if currentPassword == newPassword {
+		u.Logger.ErrorLog.Println("New password is the same as the current password")
+		return errors.New("new password must differ from current password")
+	}
+
+	// 試行の制限を確認
+	targets := []throttleTarget{{key: domain_auth.PasswordChangeAttemptKey(userID), policy: u.emailThrottle}}
+	if err := checkThrottle(u.Logger, u.loginAttemptRepository, targets); err != nil {
+		var lockedErr *LoginLockedError
+		if errors.As(err, &lockedErr) {
+			return err
+		}
+		return errors.New("failed to change password")
+	}
+
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.ErrorLog.Println("User not found")
+			return errors.New("user not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user: %v", err)
+		return errors.New("failed to change password")
+	}
+
+	// 現在のパスワードの検証
+	if matched, _ := u.passwordHasher.Verify(user.Password, currentPassword); !matched {
+		recordThrottleFailure(u.Logger, u.loginAttemptRepository, targets)
+		u.Logger.ErrorLog.Println("Invalid current password")
+		return errors.New("invalid current password")
+	}
+
+	// パスワードのハッシュ化
+	hashed, err := u.passwordHasher.Hash(newPassword)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to hash password: %v", err)
+		return errors.New("failed to change password")
+	}
+
+	// 認証リポジトリからパスワードを変更(repository層)
+	err = u.authRepository.ChangePassword(userID, hashed, sessionID)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to change password: %v", err)
+		return errors.New("failed to change password")
+	}
+
+	// 失敗回数をリセット
+	err = u.loginAttemptRepository.ResetLoginAttempts([]string{targets[0].key})
+	if err != nil {
+		u.Logger.WarnLog.Printf("Failed to reset login attempts: %v", err)
+	}
+
+	u.Logger.InfoLog.Printf("Password changed for user: %s", userID)
+	return nil
+}
+
+// パスワードの長さチェック
+func validatePassword(password string) error {
+	if len([]rune(password)) < minPasswordLength {
+		return errors.New("password is too short")
+	}
+	if len(password) > maxPasswordBytes {
+		return errors.New("password is too long")
+	}
+	return nil
+}
+
+// パスワードを再ハッシュ化して保存する
+// 失敗してもログインは継続し、次回ログイン時に再度試行する。
+func (u *AuthUsecase) rehashPassword(id string, password string) {
+	u.Logger.InfoLog.Println("Rehashing password")
+
+	hashed, err := u.passwordHasher.Hash(password)
+	if err != nil {
+		u.Logger.WarnLog.Printf("Failed to hash password: %v", err)
+		return
+	}
+
+	// 認証リポジトリからパスワードを更新(repository層)
+	err = u.authRepository.UpdatePassword(id, hashed)
+	if err != nil {
+		u.Logger.WarnLog.Printf("Failed to update password: %v", err)
+		return
+	}
+
+	u.Logger.InfoLog.Println("Password rehashed successfully")
+}
+
+// リフレッシュトークンを発行
+// 新しいトークン系列を開始し、平文のトークンを返す。
+// トークンはセッションに紐づけ、セッションの失効時に合わせて失効させる。
+func (u *AuthUsecase) IssueRefreshToken(userID string, sessionID string) (string, error) {
+	u.Logger.InfoLog.Println("IssueRefreshToken called")
+
+	// バリデーション
+	if userID == "" {
+		u.Logger.ErrorLog.Println("user_id is empty")
+		return "", errors.New("user_id is empty")
+	}
+
+	plain, token, err := u.newRefreshToken(userID, "", sessionID)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to generate refresh token: %v", err)
+		return "", errors.New("failed to issue refresh token")
+	}
+
+	// トークンリポジトリからリフレッシュトークンを作成(repository層)
+	_, err = u.tokenRepository.CreateRefreshToken(token)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to create refresh token: %v", err)
+		return "", errors.New("failed to issue refresh token")
+	}
+
+	u.Logger.InfoLog.Println("Refresh token issued successfully")
+	return plain, nil
+}
+
+// リフレッシュトークンをローテーション
+// 成功時はユーザーID・セッションIDと新しいリフレッシュトークンを返す。
+// 失効済みのトークンが再利用された場合は、盗難とみなしてトークン系列とセッションを全て失効させる。
+func (u *AuthUsecase) RefreshToken(refreshToken string) (string, string, string, error) {
+	u.Logger.InfoLog.Println("RefreshToken called")
+
+	// バリデーション
+	if refreshToken == "" {
+		u.Logger.ErrorLog.Println("refresh_token is empty")
+		return "", "", "", errors.New("refresh_token is empty")
+	}
+
+	// トークンリポジトリからリフレッシュトークンを取得(repository層)
+	current, err := u.tokenRepository.GetRefreshTokenByHash(pkg_token.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrRefreshTokenNotFound) {
+			u.Logger.ErrorLog.Println("Invalid refresh token")
+			return "", "", "", errors.New("invalid refresh token")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get refresh token: %v", err)
+		return "", "", "", errors.New("failed to refresh token")
+	}
+
+	// 失効済みトークンの再利用
+	if current.IsRevoked() {
+		u.revokeTokenFamily(current)
+		return "", "", "", errors.New("refresh token reused")
+	}
+	// 有効期限切れ
+	if current.IsExpired(time.Now()) {
+		u.Logger.ErrorLog.Println("Refresh token expired")
+		return "", "", "", errors.New("refresh token expired")
+	}
+	// セッション導入前に発行されたトークンはセッションを引き継げないため、系列を失効させて再ログインさせる
+	if current.SessionID == "" {
+		u.Logger.ErrorLog.Println("Refresh token has no session")
+		err = u.tokenRepository.RevokeTokenFamily(current.FamilyID)
+		if err != nil {
+			u.Logger.ErrorLog.Printf("Failed to revoke token family: %v", err)
+		}
+		return "", "", "", errors.New("refresh token has no session")
+	}
+
+	plain, next, err := u.newRefreshToken(current.UserID, current.FamilyID, current.SessionID)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to generate refresh token: %v", err)
+		return "", "", "", errors.New("failed to refresh token")
+	}
+
+	// トークンリポジトリからリフレッシュトークンをローテーション(repository層)
+	_, err = u.tokenRepository.RotateRefreshToken(current.ID, next)
+	if err != nil {
+		// 同時に使用された場合も再利用とみなす
+		if errors.Is(err, repository_auth.ErrRefreshTokenReused) {
+			u.revokeTokenFamily(current)
+			return "", "", "", errors.New("refresh token reused")
+		}
+		u.Logger.ErrorLog.Printf("Failed to rotate refresh token: %v", err)
+		return "", "", "", errors.New("failed to refresh token")
+	}
+
+	u.Logger.InfoLog.Println("Refresh token rotated successfully")
+	return current.UserID, current.SessionID, plain, nil
+}
+
+// ログアウト
+// アクセストークン(jti)と現在のセッションを失効させ、リフレッシュトークンが指定された場合はその系列も失効させる。
+func (u *AuthUsecase) Logout(userID string, sessionID string, refreshToken string, jti string, expiresAt time.Time) error {
+	u.Logger.InfoLog.Println("Logout called")
+
+	// 現在のセッションを失効(紐づくリフレッシュトークンも失効する)
+	if sessionID != "" {
+		// セッションリポジトリからセッションを失効(repository層)
+		err := u.sessionRepository.RevokeSession(userID, sessionID)
+		if err != nil && !errors.Is(err, repository_auth.ErrSessionNotFound) {
+			u.Logger.ErrorLog.Printf("Failed to revoke session: %v", err)
+			return errors.New("failed to logout")
+		}
+	}
+
+	// リフレッシュトークンの系列を失効
+	if refreshToken != "" {
+		// トークンリポジトリからリフレッシュトークンを取得(repository層)
+		current, err := u.tokenRepository.GetRefreshTokenByHash(pkg_token.HashToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, repository_auth.ErrRefreshTokenNotFound) {
+				u.Logger.ErrorLog.Println("Invalid refresh token")
+				return errors.New("invalid refresh token")
+			}
+			u.Logger.ErrorLog.Printf("Failed to get refresh token: %v", err)
+			return errors.New("failed to logout")
+		}
+		// 他のユーザーのトークンは失効させない
+		if current.UserID != userID {
+			u.Logger.ErrorLog.Println("Refresh token does not belong to the caller")
+			return errors.New("invalid refresh token")
+		}
+
+		// トークンリポジトリからトークン系列を失効(repository層)
+		err = u.tokenRepository.RevokeTokenFamily(current.FamilyID)
+		if err != nil {
+			u.Logger.ErrorLog.Printf("Failed to revoke token family: %v", err)
+			return errors.New("failed to logout")
+		}
+	}
+
+	// アクセストークンを失効
+	if jti != "" {
+		// トークンリポジトリからアクセストークンを失効(repository層)
+		err := u.tokenRepository.RevokeAccessToken(jti, expiresAt)
+		if err != nil {
+			u.Logger.ErrorLog.Printf("Failed to revoke access token: %v", err)
+			return errors.New("failed to logout")
+		}
+	}
+
+	u.Logger.InfoLog.Println("Logout successful")
+	return nil
+}
+
+// アクセストークンが失効済みか確認
+func (u *AuthUsecase) IsAccessTokenRevoked(jti string) (bool, error) {
+	// トークンリポジトリから失効状態を取得(repository層)
+	revoked, err := u.tokenRepository.IsAccessTokenRevoked(jti)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to check access token: %v", err)
+		return false, err
+	}
+	return revoked, nil
+}
+
+// アクセストークンを失効
+func (u *AuthUsecase) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	// トークンリポジトリからアクセストークンを失効(repository層)
+	err := u.tokenRepository.RevokeAccessToken(jti, expiresAt)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to revoke access token: %v", err)
+		return err
+	}
+	return nil
+}
+
+// 新しいリフレッシュトークンを生成
+// familyIDが空の場合は新しい系列としてリポジトリ側で採番する。
+func (u *AuthUsecase) newRefreshToken(userID string, familyID string, sessionID string) (string, domain_auth.RefreshToken, error) {
+	plain, err := pkg_token.GenerateOpaqueToken(32)
+	if err != nil {
+		return "", domain_auth.RefreshToken{}, err
+	}
+	token := domain_auth.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		SessionID: sessionID,
+		TokenHash: pkg_token.HashToken(plain),
+		ExpiresAt: time.Now().Add(u.refreshTokenTTL),
+	}
+	return plain, token, nil
+}
+
+// トークン系列とセッションを失効させる(再利用検知時)
+func (u *AuthUsecase) revokeTokenFamily(token domain_auth.RefreshToken) {
+	u.Logger.WarnLog.Printf("Refresh token reuse detected. Revoking token family: %s", token.FamilyID)
+
+	// トークンリポジトリからトークン系列を失効(repository層)
+	err := u.tokenRepository.RevokeTokenFamily(token.FamilyID)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to revoke token family: %v", err)
+	}
+
+	// セッションリポジトリからセッションを失効(repository層)
+	if token.SessionID != "" {
+		err = u.sessionRepository.RevokeSession(token.UserID, token.SessionID)
+		if err != nil && !errors.Is(err, repository_auth.ErrSessionNotFound) {
+			u.Logger.ErrorLog.Printf("Failed to revoke session: %v", err)
+		}
+	}
 }
@@ -0,0 +1,275 @@
+package usecase_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_user "backend/internal/domain/user"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_mailer "backend/internal/pkg/mailer"
+	pkg_signedtoken "backend/internal/pkg/signedtoken"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// 確認リンクのトークンの用途(typクレーム)
+const emailVerificationPurpose = "email_verification"
+
+// 確認メールを再送できるまでの間隔
+const emailVerificationResendInterval = time.Minute
+
+// メールアドレス確認ユースケース(IF)
+type IEmailVerificationUsecase interface {
+	// 確認メールを送信
+	SendVerificationEmail(userID string) error
+	// メールアドレスを指定して確認メールを再送(未ログインで使用する)
+	ResendVerificationEmail(email string, clientAddr string) error
+	// 確認リンクのトークンを検証し、メールアドレスを確認済みにする
+	VerifyEmail(token string) error
+}
+
+// メールアドレス確認ユースケース(Impl)
+type EmailVerificationUsecase struct {
+	Logger                 *pkg_logger.AppLogger
+	authRepository         repository_auth.IAuthRepository
+	loginAttemptRepository repository_auth.ILoginAttemptRepository
+	mailer                 pkg_mailer.IMailer
+	signer                 *pkg_signedtoken.Signer
+	// 未ログインでの再送の制限ポリシー(メールアドレス・クライアントアドレスごと)
+	resendThrottle domain_auth.LoginThrottlePolicy
+	// 確認リンクの有効期間
+	tokenTTL time.Duration
+	// 確認リンクのURL(GET /verify-email)
+	verifyURL string
+}
+
+// メールアドレス確認ユースケースのインスタンス化
+func NewEmailVerificationUsecase(
+	l *pkg_logger.AppLogger,
+	ar repository_auth.IAuthRepository,
+	lar repository_auth.ILoginAttemptRepository,
+	mailer pkg_mailer.IMailer,
+	signer *pkg_signedtoken.Signer,
+	resendThrottle domain_auth.LoginThrottlePolicy,
+	tokenTTL time.Duration,
+	verifyURL string,
+) IEmailVerificationUsecase {
+	return &EmailVerificationUsecase{
+		Logger:                 l,
+		authRepository:         ar,
+		loginAttemptRepository: lar,
+		mailer:                 mailer,
+		signer:                 signer,
+		resendThrottle:         resendThrottle,
+		tokenTTL:               tokenTTL,
+		verifyURL:              verifyURL,
+	}
+}
+
+// 確認メールを送信
+// 未確認(または送信済み)の状態を送信済みに遷移させ、署名付きの確認リンクをメールで送る。
+// メール送信は非同期で行う。
+func (u *EmailVerificationUsecase) SendVerificationEmail(userID string) error {
+	u.Logger.InfoLog.Println("SendVerificationEmail called")
+
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.ErrorLog.Println("User not found")
+			return errors.New("user not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user: %v", err)
+		return errors.New("failed to send verification email")
+	}
+
+	// 状態遷移の確認
+	if !user.EmailVerificationStatus.CanTransitionTo(domain_user.EmailVerificationSent) {
+		u.Logger.ErrorLog.Printf("Email already verified: %s", user.ID)
+		return errors.New("email already verified")
+	}
+	now := time.Now()
+	if user.EmailVerificationSentAt != nil && now.Sub(*user.EmailVerificationSentAt) < emailVerificationResendInterval {
+		u.Logger.ErrorLog.Printf("Verification email recently sent: %s", user.ID)
+		return errors.New("verification email recently sent")
+	}
+
+	// 確認リンクのトークンを生成(メールアドレスを含め、変更後は無効にする)
+	token, err := u.signer.Sign(emailVerificationPurpose, map[string]interface{}{
+		"id":    user.ID,
+		"email": user.Email,
+	}, u.tokenTTL)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to sign verification token: %v", err)
+		return errors.New("failed to send verification email")
+	}
+
+	// 認証リポジトリに送信済みを記録(repository層)
+	err = u.authRepository.MarkEmailVerificationSent(user.ID, now)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrEmailVerificationConflict) {
+			u.Logger.ErrorLog.Printf("Email already verified: %s", user.ID)
+			return errors.New("email already verified")
+		}
+		u.Logger.ErrorLog.Printf("Failed to record verification email: %v", err)
+		return errors.New("failed to send verification email")
+	}
+
+	go u.sendVerificationMail(user, token)
+
+	u.Logger.InfoLog.Printf("Verification email accepted for user: %s", user.ID)
+	return nil
+}
+
+// メールアドレスを指定して確認メールを再送
+// 登録時のメールが届かなかった未ログインのユーザーが使用する。
+// アカウントの存在を推測されないよう、登録済みか・確認済みかに関わらず同じ結果を返す(送信は非同期で行う)。
+// メールアドレス・クライアントアドレスごとにリクエスト回数を記録し、多すぎる場合は一定期間拒否する。
+func (u *EmailVerificationUsecase) ResendVerificationEmail(email string, clientAddr string) error {
+	u.Logger.InfoLog.Println("ResendVerificationEmail called")
+
+	email = domain_user.NormalizeEmail(email)
+
+	// バリデーション
+	if email == "" {
+		u.Logger.ErrorLog.Println("email is empty")
+		return errors.New("email is empty")
+	}
+	// Emailの形式チェック
+	if !domain_user.IsValidEmail(email) {
+		u.Logger.ErrorLog.Println("Invalid email format")
+		return errors.New("invalid email format")
+	}
+
+	// リクエスト回数の制限を確認し、今回のリクエストを記録
+	targets := []throttleTarget{{key: domain_auth.VerificationResendEmailKey(email), policy: u.resendThrottle}}
+	if clientAddr != "" {
+		targets = append(targets, throttleTarget{key: domain_auth.VerificationResendClientKey(clientAddr), policy: u.resendThrottle})
+	}
+	if err := checkThrottle(u.Logger, u.loginAttemptRepository, targets); err != nil {
+		var lockedErr *LoginLockedError
+		if errors.As(err, &lockedErr) {
+			return err
+		}
+		return errors.New("failed to send verification email")
+	}
+	recordThrottleFailure(u.Logger, u.loginAttemptRepository, targets)
+
+	go u.resendVerificationEmail(email)
+
+	u.Logger.InfoLog.Println("ResendVerificationEmail accepted")
+	return nil
+}
+
+// メールアドレスのユーザーに確認メールを送信する
+// 結果は応答に含めないため、ログのみ出力する。
+func (u *EmailVerificationUsecase) resendVerificationEmail(email string) {
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.InfoLog.Println("Verification email requested for unknown email")
+			return
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user: %v", err)
+		return
+	}
+	// 無効化されたユーザーにはメールを送らない
+	if user.IsDeactivated() {
+		u.Logger.InfoLog.Println("Verification email requested for deactivated user")
+		return
+	}
+
+	if err := u.SendVerificationEmail(user.ID); err != nil {
+		u.Logger.InfoLog.Printf("Verification email not sent for user %s: %v", user.ID, err)
+	}
+}
+
+// 確認リンクのトークンを検証し、メールアドレスを確認済みにする
+// 送信後にメールアドレスが変更された場合、リンクは無効になる。
+func (u *EmailVerificationUsecase) VerifyEmail(token string) error {
+	u.Logger.InfoLog.Println("VerifyEmail called")
+
+	// バリデーション
+	if token == "" {
+		u.Logger.ErrorLog.Println("token is empty")
+		return errors.New("token is empty")
+	}
+
+	// トークンを検証
+	claims, err := u.signer.Verify(emailVerificationPurpose, token)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Invalid verification token: %v", err)
+		return errors.New("invalid or expired verification link")
+	}
+	userID, _ := claims["id"].(string)
+	email, _ := claims["email"].(string)
+	if userID == "" || email == "" {
+		u.Logger.ErrorLog.Println("Invalid verification token claims")
+		return errors.New("invalid or expired verification link")
+	}
+
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.ErrorLog.Println("User not found")
+			return errors.New("invalid or expired verification link")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user: %v", err)
+		return errors.New("failed to verify email")
+	}
+
+	// 状態遷移の確認
+	if user.EmailVerificationStatus.IsVerified() {
+		u.Logger.InfoLog.Printf("Email already verified: %s", user.ID)
+		return errors.New("email already verified")
+	}
+	if !user.EmailVerificationStatus.CanTransitionTo(domain_user.EmailVerified) ||
+		domain_user.NormalizeEmail(user.Email) != domain_user.NormalizeEmail(email) {
+		u.Logger.ErrorLog.Printf("Verification link no longer valid for user: %s", user.ID)
+		return errors.New("invalid or expired verification link")
+	}
+
+	// 認証リポジトリから確認済みにする(repository層)
+	err = u.authRepository.MarkEmailVerified(user.ID, email)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrEmailVerificationConflict) {
+			u.Logger.ErrorLog.Printf("Verification link no longer valid for user: %s", user.ID)
+			return errors.New("invalid or expired verification link")
+		}
+		u.Logger.ErrorLog.Printf("Failed to verify email: %v", err)
+		return errors.New("failed to verify email")
+	}
+
+	u.Logger.InfoLog.Printf("Email verified for user: %s", user.ID)
+	return nil
+}
+
+// 確認メールを送信する
+func (u *EmailVerificationUsecase) sendVerificationMail(user domain_user.Users, token string) {
+	err := u.mailer.Send(pkg_mailer.Message{
+		To:      user.Email,
+		Subject: "メールアドレス確認のお願い",
+		Body:    u.verificationMailBody(user.Username, token),
+	})
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to send verification mail: %v", err)
+		return
+	}
+
+	u.Logger.InfoLog.Printf("Verification mail sent to user: %s", user.ID)
+}
+
+// 確認メールの本文
+func (u *EmailVerificationUsecase) verificationMailBody(username string, token string) string {
+	return fmt.Sprintf(`%s 様
+
+以下のURLを開いて、メールアドレスの確認を完了してください。
+%s?token=%s
+
+有効期限: %d分
+このメールに心当たりがない場合は、破棄してください。
+`, username, u.verifyURL, url.QueryEscape(token), int(u.tokenTTL.Minutes()))
+}
@@ -0,0 +1,133 @@
+package usecase_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_user "backend/internal/domain/user"
+	pkg_logger "backend/internal/pkg/logger"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"time"
+)
+
+// ログイン試行が制限されている場合のエラー
+type LoginLockedError struct {
+	// 再試行可能になるまでの時間
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return "too many login attempts"
+}
+
+// ログイン試行の制限対象のキーと適用するポリシー
+type throttleTarget struct {
+	key    string
+	policy domain_auth.LoginThrottlePolicy
+}
+
+// ログイン試行の制限対象を取得
+// メールアドレスとクライアントアドレスのそれぞれで制限する。
+func (u *AuthUsecase) throttleTargets(email string, clientAddr string) []throttleTarget {
+	targets := []throttleTarget{
+		{key: domain_auth.EmailAttemptKey(domain_user.NormalizeEmail(email)), policy: u.emailThrottle},
+	}
+	if clientAddr != "" {
+		targets = append(targets, throttleTarget{key: domain_auth.ClientAttemptKey(clientAddr), policy: u.clientThrottle})
+	}
+	return targets
+}
+
+// ログイン試行が制限中か確認
+// 制限中の場合はLoginLockedErrorを返す。
+func (u *AuthUsecase) checkLoginThrottle(targets []throttleTarget) error {
+	return checkThrottle(u.Logger, u.loginAttemptRepository, targets)
+}
+
+// ログイン失敗を記録し、失敗回数に応じてロックする
+func (u *AuthUsecase) recordLoginFailure(targets []throttleTarget) {
+	recordThrottleFailure(u.Logger, u.loginAttemptRepository, targets)
+}
+
+// 試行が制限中か確認
+// ログイン以外(二要素認証など)の試行制限でも共通して使用する。
+func checkThrottle(l *pkg_logger.AppLogger, loginAttemptRepository repository_auth.ILoginAttemptRepository, targets []throttleTarget) error {
+	keys := make([]string, len(targets))
+	for i, t := range targets {
+		keys[i] = t.key
+	}
+
+	// ログイン試行リポジトリから記録を取得(repository層)
+	attempts, err := loginAttemptRepository.GetLoginAttempts(keys)
+	if err != nil {
+		l.ErrorLog.Printf("Failed to get login attempts: %v", err)
+		return errors.New("failed to login")
+	}
+
+	// 最も長い待機時間を返す
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, attempt := range attempts {
+		if d, locked := attempt.RetryAfter(now); locked && d > retryAfter {
+			retryAfter = d
+		}
+	}
+	if retryAfter > 0 {
+		l.ErrorLog.Printf("Login throttled. Retry after %v", retryAfter)
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// 失敗を記録し、失敗回数に応じてロックする
+func recordThrottleFailure(l *pkg_logger.AppLogger, loginAttemptRepository repository_auth.ILoginAttemptRepository, targets []throttleTarget) {
+	for _, t := range targets {
+		// ログイン試行リポジトリに失敗を記録(repository層)
+		attempt, err := loginAttemptRepository.RecordLoginFailure(t.key, t.policy.LockoutDuration)
+		if err != nil {
+			l.ErrorLog.Printf("Failed to record login failure: %v", err)
+			continue
+		}
+
+		lockDuration := t.policy.LockDuration(attempt.FailureCount)
+		if lockDuration <= 0 {
+			continue
+		}
+		if attempt.FailureCount >= t.policy.MaxAttempts {
+			l.WarnLog.Printf("Locking %s for %v after %d failures", t.key, lockDuration, attempt.FailureCount)
+		}
+
+		// ログイン試行リポジトリにロック解除日時を設定(repository層)
+		err = loginAttemptRepository.LockLoginAttempt(t.key, time.Now().Add(lockDuration))
+		if err != nil {
+			l.ErrorLog.Printf("Failed to lock login attempt: %v", err)
+		}
+	}
+}
+
+// アカウントのロックを解除
+func (u *AuthUsecase) UnlockAccount(email string) error {
+	u.Logger.InfoLog.Println("UnlockAccount called")
+
+	email = domain_user.NormalizeEmail(email)
+
+	// バリデーション
+	if email == "" {
+		u.Logger.ErrorLog.Println("email is empty")
+		return errors.New("email is empty")
+	}
+	if !domain_user.IsValidEmail(email) {
+		u.Logger.ErrorLog.Println("Invalid email format")
+		return errors.New("invalid email format")
+	}
+
+	// ログイン試行リポジトリから記録を削除(repository層)
+	err := u.loginAttemptRepository.ResetLoginAttempts([]string{domain_auth.EmailAttemptKey(email)})
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to unlock account: %v", err)
+		return errors.New("failed to unlock account")
+	}
+
+	u.Logger.InfoLog.Println("Account unlocked successfully")
+	return nil
+}
@@ -0,0 +1,185 @@
+package usecase_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_user "backend/internal/domain/user"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_mailer "backend/internal/pkg/mailer"
+	pkg_password "backend/internal/pkg/password"
+	pkg_token "backend/internal/pkg/token"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// パスワード再設定ユースケース(IF)
+type IPasswordResetUsecase interface {
+	// パスワード再設定をリクエスト
+	RequestPasswordReset(email string) error
+	// パスワードを再設定
+	ResetPassword(token string, newPassword string) error
+}
+
+// パスワード再設定ユースケース(Impl)
+type PasswordResetUsecase struct {
+	Logger                  *pkg_logger.AppLogger
+	authRepository          repository_auth.IAuthRepository
+	passwordResetRepository repository_auth.IPasswordResetRepository
+	passwordHasher          *pkg_password.PasswordHasher
+	mailer                  pkg_mailer.IMailer
+	// トークンの有効期間
+	tokenTTL time.Duration
+	// メールに記載する再設定画面のURL(空の場合はトークンのみ記載)
+	resetURL string
+}
+
+// パスワード再設定ユースケースのインスタンス化
+func NewPasswordResetUsecase(
+	l *pkg_logger.AppLogger,
+	ar repository_auth.IAuthRepository,
+	prr repository_auth.IPasswordResetRepository,
+	ph *pkg_password.PasswordHasher,
+	mailer pkg_mailer.IMailer,
+	tokenTTL time.Duration,
+	resetURL string,
+) IPasswordResetUsecase {
+	return &PasswordResetUsecase{
+		Logger:                  l,
+		authRepository:          ar,
+		passwordResetRepository: prr,
+		passwordHasher:          ph,
+		mailer:                  mailer,
+		tokenTTL:                tokenTTL,
+		resetURL:                resetURL,
+	}
+}
+
+// パスワード再設定をリクエスト
+// アカウントの存在を推測されないよう、登録済みかどうかに関わらず同じ結果を返す。
+// トークンの発行とメール送信は非同期で行い、応答時間にも差が出ないようにする。
+func (u *PasswordResetUsecase) RequestPasswordReset(email string) error {
+	u.Logger.InfoLog.Println("RequestPasswordReset called")
+
+	email = domain_user.NormalizeEmail(email)
+
+	// バリデーション
+	if email == "" {
+		u.Logger.ErrorLog.Println("email is empty")
+		return errors.New("email is empty")
+	}
+	// Emailの形式チェック
+	if !domain_user.IsValidEmail(email) {
+		u.Logger.ErrorLog.Println("Invalid email format")
+		return errors.New("invalid email format")
+	}
+
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.InfoLog.Println("Password reset requested for unknown email")
+			return nil
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user: %v", err)
+		return errors.New("failed to request password reset")
+	}
+	// 無効化されたユーザーにはメールを送らない(存在の有無と同様に応答は変えない)
+	if user.IsDeactivated() {
+		u.Logger.InfoLog.Println("Password reset requested for deactivated user")
+		return nil
+	}
+
+	go u.sendPasswordResetMail(user)
+
+	u.Logger.InfoLog.Println("RequestPasswordReset accepted")
+	return nil
+}
+
+// パスワードを再設定
+// トークンは1回のみ使用可能。成功時はユーザーの既存のリフレッシュトークンを全て失効させる。
+func (u *PasswordResetUsecase) ResetPassword(token string, newPassword string) error {
+	u.Logger.InfoLog.Println("ResetPassword called")
+
+	// バリデーション
+	if token == "" {
+		u.Logger.ErrorLog.Println("token is empty")
+		return errors.New("token is empty")
+	}
+	if err := validatePassword(newPassword); err != nil {
+		u.Logger.ErrorLog.Printf("Invalid password: %v", err)
+		return err
+	}
+
+	// パスワードのハッシュ化
+	hashed, err := u.passwordHasher.Hash(newPassword)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to hash password: %v", err)
+		return errors.New("failed to reset password")
+	}
+
+	// パスワード再設定リポジトリからパスワードを更新(repository層)
+	userID, err := u.passwordResetRepository.ResetPassword(pkg_token.HashToken(token), hashed)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrPasswordResetTokenInvalid) {
+			u.Logger.ErrorLog.Println("Invalid or expired reset token")
+			return errors.New("invalid or expired reset token")
+		}
+		u.Logger.ErrorLog.Printf("Failed to reset password: %v", err)
+		return errors.New("failed to reset password")
+	}
+
+	u.Logger.InfoLog.Printf("Password reset successfully for user: %s", userID)
+	return nil
+}
+
+// パスワード再設定トークンを発行し、メールを送信する
+func (u *PasswordResetUsecase) sendPasswordResetMail(user domain_user.Users) {
+	plain, err := pkg_token.GenerateOpaqueToken(32)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to generate password reset token: %v", err)
+		return
+	}
+
+	// パスワード再設定リポジトリからトークンを作成(repository層)
+	err = u.passwordResetRepository.CreatePasswordResetToken(domain_auth.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: pkg_token.HashToken(plain),
+		ExpiresAt: time.Now().Add(u.tokenTTL),
+	})
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to create password reset token: %v", err)
+		return
+	}
+
+	err = u.mailer.Send(pkg_mailer.Message{
+		To:      user.Email,
+		Subject: "パスワード再設定のご案内",
+		Body:    u.passwordResetMailBody(user.Username, plain),
+	})
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to send password reset mail: %v", err)
+		return
+	}
+
+	u.Logger.InfoLog.Printf("Password reset mail sent to user: %s", user.ID)
+}
+
+// パスワード再設定メールの本文
+func (u *PasswordResetUsecase) passwordResetMailBody(username string, token string) string {
+	instruction := fmt.Sprintf("再設定トークン: %s", token)
+	if u.resetURL != "" {
+		instruction = fmt.Sprintf("以下のURLからパスワードを再設定してください。\n%s?token=%s", u.resetURL, url.QueryEscape(token))
+	}
+
+	return fmt.Sprintf(`%s 様
+
+パスワード再設定のリクエストを受け付けました。
+
+%s
+
+有効期限: %d分
+このメールに心当たりがない場合は、破棄してください。
+`, username, instruction, int(u.tokenTTL.Minutes()))
+}
@@ -0,0 +1,226 @@
+package usecase_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_user "backend/internal/domain/user"
+	pkg_logger "backend/internal/pkg/logger"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"strings"
+)
+
+// bootstrapで付与した場合の付与者(監査ログのactor_id)
+const bootstrapActor = "bootstrap"
+
+// ロールユースケース(IF)
+type IRoleUsecase interface {
+	// ユーザーに付与されたロール名を取得(アクセストークンの発行に使用)
+	GetUserRoles(userID string) ([]string, error)
+	// ロールの一覧を取得(userIDを指定した場合はそのユーザーのロール)
+	ListRoles(principal *domain_auth.Principal, userID string) ([]domain_auth.Role, error)
+	// ユーザーにロールを付与
+	AssignRole(principal *domain_auth.Principal, userID string, role string) error
+	// ユーザーからロールを剥奪
+	RevokeRole(principal *domain_auth.Principal, userID string, role string) error
+	// 最初の管理者を作成(管理者が存在しない場合のみ)
+	BootstrapAdmin(email string) (domain_user.Users, error)
+}
+
+// ロールユースケース(Impl)
+type RoleUsecase struct {
+	Logger         *pkg_logger.AppLogger
+	authRepository repository_auth.IAuthRepository
+	roleRepository repository_auth.IRoleRepository
+}
+
+// ロールユースケースのインスタンス化
+func NewRoleUsecase(l *pkg_logger.AppLogger, ar repository_auth.IAuthRepository, rr repository_auth.IRoleRepository) IRoleUsecase {
+	return &RoleUsecase{
+		Logger:         l,
+		authRepository: ar,
+		roleRepository: rr,
+	}
+}
+
+// ユーザーに付与されたロール名を取得
+func (u *RoleUsecase) GetUserRoles(userID string) ([]string, error) {
+	u.Logger.InfoLog.Println("GetUserRoles called")
+
+	// ロールリポジトリからユーザーのロールを取得(repository層)
+	roles, err := u.roleRepository.GetRolesByUserId(userID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.ErrorLog.Printf("User not found: %s", userID)
+			return nil, errors.New("user not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user roles: %v", err)
+		return nil, err
+	}
+
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return names, nil
+}
+
+// ロールの一覧を取得
+func (u *RoleUsecase) ListRoles(principal *domain_auth.Principal, userID string) ([]domain_auth.Role, error) {
+	u.Logger.InfoLog.Println("ListRoles called")
+
+	if err := u.authorize(principal); err != nil {
+		return nil, err
+	}
+
+	// ロールリポジトリからロールを取得(repository層)
+	var roles []domain_auth.Role
+	var err error
+	if userID == "" {
+		roles, err = u.roleRepository.GetAllRoles()
+	} else {
+		roles, err = u.roleRepository.GetRolesByUserId(userID)
+	}
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.ErrorLog.Printf("User not found: %s", userID)
+			return nil, errors.New("user not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to list roles: %v", err)
+		return nil, err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d roles", len(roles))
+	return roles, nil
+}
+
+// ユーザーにロールを付与
+// 付与したロールは次回のアクセストークンの発行(ログイン・リフレッシュ)から反映される。
+func (u *RoleUsecase) AssignRole(principal *domain_auth.Principal, userID string, role string) error {
+	u.Logger.InfoLog.Println("AssignRole called")
+
+	role = strings.ToLower(strings.TrimSpace(role))
+	if err := u.validateRoleRequest(principal, userID, role); err != nil {
+		return err
+	}
+
+	// ロールリポジトリからロールを付与(repository層)
+	err := u.roleRepository.AssignRole(userID, role, principal.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_auth.ErrUserNotFound):
+			u.Logger.ErrorLog.Printf("User not found: %s", userID)
+			return errors.New("user not found")
+		case errors.Is(err, repository_auth.ErrRoleNotFound):
+			u.Logger.ErrorLog.Printf("Role not found: %s", role)
+			return errors.New("role not found")
+		case errors.Is(err, repository_auth.ErrRoleAlreadyAssigned):
+			u.Logger.ErrorLog.Printf("Role already assigned: %s", role)
+			return errors.New("role already assigned")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to assign role: %v", err)
+			return err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Assigned role %s to user: %s", role, userID)
+	return nil
+}
+
+// ユーザーからロールを剥奪
+// 剥奪したロールは次回のアクセストークンの発行(ログイン・リフレッシュ)から反映される。
+func (u *RoleUsecase) RevokeRole(principal *domain_auth.Principal, userID string, role string) error {
+	u.Logger.InfoLog.Println("RevokeRole called")
+
+	role = strings.ToLower(strings.TrimSpace(role))
+	if err := u.validateRoleRequest(principal, userID, role); err != nil {
+		return err
+	}
+
+	// ロールリポジトリからロールを剥奪(repository層)
+	err := u.roleRepository.RevokeRole(userID, role, principal.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_auth.ErrRoleNotAssigned):
+			u.Logger.ErrorLog.Printf("Role not assigned: %s", role)
+			return errors.New("role not assigned")
+		case errors.Is(err, repository_auth.ErrLastAdmin):
+			u.Logger.ErrorLog.Printf("Cannot revoke the last admin: %s", userID)
+			return errors.New("cannot revoke the last admin")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to revoke role: %v", err)
+			return err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Revoked role %s from user: %s", role, userID)
+	return nil
+}
+
+// 最初の管理者を作成
+// 登録済みのユーザーにadminロールを付与する。既に有効な管理者が存在する場合は何もしない。
+func (u *RoleUsecase) BootstrapAdmin(email string) (domain_user.Users, error) {
+	u.Logger.InfoLog.Println("BootstrapAdmin called")
+
+	email = domain_user.NormalizeEmail(email)
+
+	// バリデーション
+	if email == "" {
+		u.Logger.ErrorLog.Println("email is empty")
+		return domain_user.Users{}, errors.New("email is empty")
+	}
+
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrUserNotFound) {
+			u.Logger.ErrorLog.Printf("User not found: %s", email)
+			return domain_user.Users{}, errors.New("user not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user: %v", err)
+		return domain_user.Users{}, err
+	}
+	if user.IsDeactivated() {
+		u.Logger.ErrorLog.Printf("Account deactivated: %s", user.ID)
+		return domain_user.Users{}, errors.New("account deactivated")
+	}
+
+	// ロールリポジトリから、管理者が存在しない場合のみadminロールを付与(repository層)
+	err = u.roleRepository.BootstrapAdmin(user.ID, bootstrapActor)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrAdminAlreadyExists) {
+			u.Logger.ErrorLog.Println("Admin already exists")
+			return domain_user.Users{}, errors.New("admin already exists")
+		}
+		u.Logger.ErrorLog.Printf("Failed to assign admin role: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	u.Logger.InfoLog.Printf("Bootstrapped admin: %s", user.ID)
+	return user, nil
+}
+
+// ロールの付与・剥奪のリクエストを検証
+func (u *RoleUsecase) validateRoleRequest(principal *domain_auth.Principal, userID string, role string) error {
+	if userID == "" {
+		u.Logger.ErrorLog.Println("user_id is empty")
+		return errors.New("user_id is empty")
+	}
+	if role == "" {
+		u.Logger.ErrorLog.Println("role is empty")
+		return errors.New("role is empty")
+	}
+	return u.authorize(principal)
+}
+
+// ロールを管理できるか確認
+func (u *RoleUsecase) authorize(principal *domain_auth.Principal) error {
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return errors.New("unauthenticated")
+	}
+	if !principal.HasPermission(domain_auth.PermissionRoleManage) {
+		u.Logger.ErrorLog.Printf("User %s cannot manage roles", principal.UserID)
+		return errors.New("permission denied")
+	}
+	return nil
+}
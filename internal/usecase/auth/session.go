@@ -0,0 +1,139 @@
+package usecase_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	repository_auth "backend/internal/repository/auth"
+	"errors"
+	"time"
+)
+
+// セッションの最終利用日時を更新する間隔
+const sessionTouchInterval = time.Minute
+
+// ユーザーエージェントの最大文字数
+const maxUserAgentLength = 512
+
+// セッションを作成
+// ログイン(トークン発行)ごとに呼び出し、作成したセッションIDを返す。
+func (u *AuthUsecase) CreateSession(userID string, userAgent string, ipAddress string) (string, error) {
+	u.Logger.InfoLog.Println("CreateSession called")
+
+	// バリデーション
+	if userID == "" {
+		u.Logger.ErrorLog.Println("user_id is empty")
+		return "", errors.New("user_id is empty")
+	}
+	if runes := []rune(userAgent); len(runes) > maxUserAgentLength {
+		userAgent = string(runes[:maxUserAgentLength])
+	}
+
+	// セッションリポジトリからセッションを作成(repository層)
+	session, err := u.sessionRepository.CreateSession(domain_auth.Session{
+		UserID:    userID,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+	})
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to create session: %v", err)
+		return "", errors.New("failed to create session")
+	}
+
+	u.Logger.InfoLog.Printf("Created session: %s", session.ID)
+	return session.ID, nil
+}
+
+// セッションが有効か確認
+// 有効な場合は最終利用日時を記録する(sessionTouchInterval以内の再記録は省略)。
+func (u *AuthUsecase) ValidateSession(sessionID string, userID string) error {
+	// セッションリポジトリからセッションを取得(repository層)
+	session, err := u.sessionRepository.GetSessionById(sessionID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrSessionNotFound) {
+			u.Logger.ErrorLog.Printf("Session not found: %s", sessionID)
+			return errors.New("session revoked")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get session: %v", err)
+		return errors.New("failed to verify session")
+	}
+
+	// 他のユーザーのセッション・失効済みのセッションは無効
+	if session.UserID != userID || session.IsRevoked() {
+		u.Logger.ErrorLog.Printf("Session revoked: %s", sessionID)
+		return errors.New("session revoked")
+	}
+
+	// 最終利用日時の記録はリクエストを待たせないよう非同期で行う
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		go func(id string) {
+			if err := u.sessionRepository.TouchSession(id, now); err != nil {
+				u.Logger.ErrorLog.Printf("Failed to record session activity: %v", err)
+			}
+		}(session.ID)
+	}
+
+	return nil
+}
+
+// 自分の有効なセッション一覧を取得
+// リフレッシュトークンの有効期限内に利用されたセッションのみ返す。
+func (u *AuthUsecase) ListSessions(userID string) ([]domain_auth.Session, error) {
+	u.Logger.InfoLog.Println("ListSessions called")
+
+	// セッションリポジトリからセッションを取得(repository層)
+	sessions, err := u.sessionRepository.GetActiveSessionsByUserId(userID, time.Now().Add(-u.refreshTokenTTL))
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to get sessions: %v", err)
+		return nil, errors.New("failed to list sessions")
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d sessions", len(sessions))
+	return sessions, nil
+}
+
+// 自分のセッションを失効
+// 他のユーザーのセッションは存在しないものとして扱う。
+func (u *AuthUsecase) RevokeSession(userID string, sessionID string) error {
+	u.Logger.InfoLog.Println("RevokeSession called")
+
+	// バリデーション
+	if sessionID == "" {
+		u.Logger.ErrorLog.Println("session_id is empty")
+		return errors.New("session_id is empty")
+	}
+
+	// セッションリポジトリからセッションを失効(repository層)
+	err := u.sessionRepository.RevokeSession(userID, sessionID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrSessionNotFound) {
+			u.Logger.ErrorLog.Printf("Session not found: %s", sessionID)
+			return errors.New("session not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to revoke session: %v", err)
+		return errors.New("failed to revoke session")
+	}
+
+	u.Logger.InfoLog.Printf("Revoked session: %s", sessionID)
+	return nil
+}
+
+// ユーザーの全セッションを失効(管理者用)
+func (u *AuthUsecase) RevokeAllUserSessions(userID string) error {
+	u.Logger.InfoLog.Println("RevokeAllUserSessions called")
+
+	// バリデーション
+	if userID == "" {
+		u.Logger.ErrorLog.Println("user_id is empty")
+		return errors.New("user_id is empty")
+	}
+
+	// セッションリポジトリから全セッションを失効(repository層)
+	err := u.sessionRepository.RevokeUserSessions(userID, "")
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to revoke sessions: %v", err)
+		return errors.New("failed to revoke sessions")
+	}
+
+	u.Logger.InfoLog.Printf("Revoked all sessions for user: %s", userID)
+	return nil
+}
@@ -0,0 +1,341 @@
+package usecase_auth
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_secretbox "backend/internal/pkg/secretbox"
+	pkg_token "backend/internal/pkg/token"
+	pkg_totp "backend/internal/pkg/totp"
+	repository_auth "backend/internal/repository/auth"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// 発行するリカバリーコードの数
+const recoveryCodeCount = 10
+
+// TOTP登録の情報
+type TOTPEnrollment struct {
+	// Base32でエンコードされたシークレット(手入力用)
+	Secret string
+	// 認証アプリに登録するotpauth URI
+	URI string
+	// otpauth URIのQRコード画像(PNG)
+	QRCodePNG []byte
+}
+
+// 二要素認証(TOTP)ユースケース(IF)
+type ITOTPUsecase interface {
+	// TOTPの登録を開始
+	EnrollTOTP(userID string) (TOTPEnrollment, error)
+	// コードを検証してTOTPを有効化し、リカバリーコードを返す
+	ConfirmTOTP(userID string, code string) ([]string, error)
+	// コード(またはリカバリーコード)を検証してTOTPを無効化
+	DisableTOTP(userID string, code string) error
+	// TOTPが有効か確認
+	IsTOTPEnabled(userID string) (bool, error)
+	// ログイン時の二要素目を検証(コードまたはリカバリーコード)
+	VerifySecondFactor(userID string, code string) error
+}
+
+// 二要素認証(TOTP)ユースケース(Impl)
+type TOTPUsecase struct {
+	Logger         *pkg_logger.AppLogger
+	authRepository repository_auth.IAuthRepository
+	totpRepository repository_auth.ITOTPRepository
+	secretBox      *pkg_secretbox.SecretBox
+	// 認証アプリに表示する発行者名
+	issuer string
+	// 二要素目の検証の試行制限
+	loginAttemptRepository repository_auth.ILoginAttemptRepository
+	throttle               domain_auth.LoginThrottlePolicy
+}
+
+// 二要素認証(TOTP)ユースケースのインスタンス化
+func NewTOTPUsecase(
+	l *pkg_logger.AppLogger,
+	ar repository_auth.IAuthRepository,
+	tr repository_auth.ITOTPRepository,
+	lar repository_auth.ILoginAttemptRepository,
+	secretBox *pkg_secretbox.SecretBox,
+	issuer string,
+	throttle domain_auth.LoginThrottlePolicy,
+) ITOTPUsecase {
+	return &TOTPUsecase{
+		Logger:         l,
+		authRepository: ar,
+		totpRepository: tr,
+		secretBox:      secretBox,
+		issuer:         issuer,
+
+		loginAttemptRepository: lar,
+		throttle:               throttle,
+	}
+}
+
+// TOTPの登録を開始
+// 有効化(ConfirmTOTP)されるまでは、ログイン時に二要素目を要求しない。
+func (u *TOTPUsecase) EnrollTOTP(userID string) (TOTPEnrollment, error) {
+	u.Logger.InfoLog.Println("EnrollTOTP called")
+
+	// 有効化済みの場合は登録し直せない
+	current, err := u.totpRepository.GetTOTP(userID)
+	if err != nil && !errors.Is(err, repository_auth.ErrTOTPNotFound) {
+		u.Logger.ErrorLog.Printf("Failed to get totp: %v", err)
+		return TOTPEnrollment{}, errors.New("failed to enroll totp")
+	}
+	if err == nil && current.Enabled {
+		u.Logger.ErrorLog.Println("Totp already enabled")
+		return TOTPEnrollment{}, errors.New("totp already enabled")
+	}
+
+	// 認証リポジトリからユーザーを取得(repository層)
+	user, err := u.authRepository.GetUserByID(userID)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to get user: %v", err)
+		return TOTPEnrollment{}, errors.New("failed to enroll totp")
+	}
+
+	// 鍵を生成
+	key, err := pkg_totp.Generate(u.issuer, user.Email)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to generate totp key: %v", err)
+		return TOTPEnrollment{}, errors.New("failed to enroll totp")
+	}
+	qrCode, err := pkg_totp.QRCodePNG(key.URI)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to generate qr code: %v", err)
+		return TOTPEnrollment{}, errors.New("failed to enroll totp")
+	}
+
+	// シークレットを暗号化して保存(repository層)
+	encrypted, err := u.secretBox.Encrypt(key.Secret)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to encrypt totp secret: %v", err)
+		return TOTPEnrollment{}, errors.New("failed to enroll totp")
+	}
+	err = u.totpRepository.SaveTOTPSecret(userID, encrypted)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to save totp secret: %v", err)
+		return TOTPEnrollment{}, errors.New("failed to enroll totp")
+	}
+
+	u.Logger.InfoLog.Printf("Totp enrollment started for user: %s", userID)
+	return TOTPEnrollment{Secret: key.Secret, URI: key.URI, QRCodePNG: qrCode}, nil
+}
+
+// コードを検証してTOTPを有効化
+// リカバリーコードはこの時のみ平文で返却する。
+func (u *TOTPUsecase) ConfirmTOTP(userID string, code string) ([]string, error) {
+	u.Logger.InfoLog.Println("ConfirmTOTP called")
+
+	// バリデーション
+	if code == "" {
+		u.Logger.ErrorLog.Println("code is empty")
+		return nil, errors.New("code is empty")
+	}
+
+	totp, err := u.totpRepository.GetTOTP(userID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrTOTPNotFound) {
+			u.Logger.ErrorLog.Println("Totp not enrolled")
+			return nil, errors.New("totp not enrolled")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get totp: %v", err)
+		return nil, errors.New("failed to confirm totp")
+	}
+	if totp.Enabled {
+		u.Logger.ErrorLog.Println("Totp already enabled")
+		return nil, errors.New("totp already enabled")
+	}
+
+	// コードの検証
+	step, ok, err := u.validateCode(totp, code)
+	if err != nil {
+		return nil, errors.New("failed to confirm totp")
+	}
+	if !ok {
+		u.Logger.ErrorLog.Println("Invalid totp code")
+		return nil, errors.New("invalid code")
+	}
+
+	// リカバリーコードを生成
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i], err = generateRecoveryCode()
+		if err != nil {
+			u.Logger.ErrorLog.Printf("Failed to generate recovery code: %v", err)
+			return nil, errors.New("failed to confirm totp")
+		}
+		hashes[i] = pkg_token.HashToken(normalizeRecoveryCode(codes[i]))
+	}
+
+	// TOTPを有効化(repository層)
+	err = u.totpRepository.EnableTOTP(userID, step, hashes)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrTOTPNotFound) {
+			u.Logger.ErrorLog.Println("Totp already enabled")
+			return nil, errors.New("totp already enabled")
+		}
+		u.Logger.ErrorLog.Printf("Failed to enable totp: %v", err)
+		return nil, errors.New("failed to confirm totp")
+	}
+
+	u.Logger.InfoLog.Printf("Totp enabled for user: %s", userID)
+	return codes, nil
+}
+
+// コード(またはリカバリーコード)を検証してTOTPを無効化
+func (u *TOTPUsecase) DisableTOTP(userID string, code string) error {
+	u.Logger.InfoLog.Println("DisableTOTP called")
+
+	// バリデーション
+	if code == "" {
+		u.Logger.ErrorLog.Println("code is empty")
+		return errors.New("code is empty")
+	}
+
+	// 二要素目の検証
+	err := u.VerifySecondFactor(userID, code)
+	if err != nil {
+		return err
+	}
+
+	// TOTPを無効化(repository層)
+	err = u.totpRepository.DisableTOTP(userID)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to disable totp: %v", err)
+		return errors.New("failed to disable totp")
+	}
+
+	u.Logger.InfoLog.Printf("Totp disabled for user: %s", userID)
+	return nil
+}
+
+// TOTPが有効か確認
+func (u *TOTPUsecase) IsTOTPEnabled(userID string) (bool, error) {
+	totp, err := u.totpRepository.GetTOTP(userID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrTOTPNotFound) {
+			return false, nil
+		}
+		u.Logger.ErrorLog.Printf("Failed to get totp: %v", err)
+		return false, err
+	}
+	return totp.Enabled, nil
+}
+
+// ログイン時の二要素目を検証
+// 6桁の数字はTOTPのコード、それ以外はリカバリーコードとして検証する。
+// 使用済みのコードは再利用できず、失敗が続いた場合は一定期間検証を拒否する。
+func (u *TOTPUsecase) VerifySecondFactor(userID string, code string) error {
+	u.Logger.InfoLog.Println("VerifySecondFactor called")
+
+	// バリデーション
+	code = strings.TrimSpace(code)
+	if code == "" {
+		u.Logger.ErrorLog.Println("code is empty")
+		return errors.New("code is empty")
+	}
+
+	// 試行の制限を確認
+	targets := []throttleTarget{{key: domain_auth.SecondFactorAttemptKey(userID), policy: u.throttle}}
+	if err := checkThrottle(u.Logger, u.loginAttemptRepository, targets); err != nil {
+		return err
+	}
+
+	totp, err := u.totpRepository.GetTOTP(userID)
+	if err != nil {
+		if errors.Is(err, repository_auth.ErrTOTPNotFound) {
+			u.Logger.ErrorLog.Println("Totp not enabled")
+			return errors.New("totp not enabled")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get totp: %v", err)
+		return errors.New("failed to verify code")
+	}
+	if !totp.Enabled {
+		u.Logger.ErrorLog.Println("Totp not enabled")
+		return errors.New("totp not enabled")
+	}
+
+	var ok bool
+	if isTOTPCode(code) {
+		// TOTPのコードを検証し、同じステップ以前のコードの再利用を拒否
+		var step int64
+		step, ok, err = u.validateCode(totp, code)
+		if err != nil {
+			return errors.New("failed to verify code")
+		}
+		if ok {
+			ok, err = u.totpRepository.UseTOTPStep(userID, step)
+			if err != nil {
+				u.Logger.ErrorLog.Printf("Failed to use totp step: %v", err)
+				return errors.New("failed to verify code")
+			}
+		}
+	} else {
+		// リカバリーコードを使用済みにする
+		ok, err = u.totpRepository.UseRecoveryCode(userID, pkg_token.HashToken(normalizeRecoveryCode(code)))
+		if err != nil {
+			u.Logger.ErrorLog.Printf("Failed to use recovery code: %v", err)
+			return errors.New("failed to verify code")
+		}
+	}
+	if !ok {
+		recordThrottleFailure(u.Logger, u.loginAttemptRepository, targets)
+		u.Logger.ErrorLog.Println("Invalid second factor code")
+		return errors.New("invalid code")
+	}
+
+	// 成功したら失敗回数をリセット
+	if err := u.loginAttemptRepository.ResetLoginAttempts([]string{targets[0].key}); err != nil {
+		u.Logger.ErrorLog.Printf("Failed to reset second factor attempts: %v", err)
+	}
+
+	u.Logger.InfoLog.Printf("Second factor verified for user: %s", userID)
+	return nil
+}
+
+// シークレットを復号してTOTPのコードを検証
+func (u *TOTPUsecase) validateCode(totp domain_auth.UserTOTP, code string) (int64, bool, error) {
+	secret, err := u.secretBox.Decrypt(totp.Secret)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to decrypt totp secret: %v", err)
+		return 0, false, err
+	}
+	step, ok := pkg_totp.Validate(secret, code, time.Now())
+	return step, ok, nil
+}
+
+// TOTPのコード(6桁の数字)かどうか
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// リカバリーコードを生成(xxxx-xxxx-xxxx-xxxx形式)
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	s := hex.EncodeToString(b)
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// リカバリーコードを正規化(区切り文字・大文字小文字の違いを無視)
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
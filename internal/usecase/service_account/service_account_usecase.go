@@ -0,0 +1,245 @@
+package usecase_service_account
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_service_account "backend/internal/domain/service_account"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_token "backend/internal/pkg/token"
+	repository_service_account "backend/internal/repository/service_account"
+	"errors"
+	"strings"
+	"time"
+)
+
+// 最終使用日時を更新する最小間隔(リクエストごとの書き込みを避ける)
+const lastUsedUpdateInterval = time.Minute
+
+// サービスアカウントユースケース(IF)
+type IServiceAccountUsecase interface {
+	// サービスアカウントを作成
+	CreateServiceAccount(name string, description string) (domain_service_account.ServiceAccount, error)
+	// 全てのサービスアカウントを取得
+	GetAllServiceAccounts() ([]domain_service_account.ServiceAccount, error)
+	// APIキーを発行(キー本体はこの時のみ返却する)
+	CreateAPIKey(serviceAccountId string, scopes []string, ttl time.Duration) (string, domain_service_account.APIKey, error)
+	// サービスアカウントのAPIキーを取得
+	GetAPIKeys(serviceAccountId string) ([]domain_service_account.APIKey, error)
+	// APIキーを失効
+	RevokeAPIKey(id string) error
+	// APIキーを検証
+	AuthenticateAPIKey(key string) (domain_service_account.APIKey, error)
+}
+
+// サービスアカウントユースケース(Impl)
+type ServiceAccountUsecase struct {
+	Logger                   *pkg_logger.AppLogger
+	serviceAccountRepository repository_service_account.IServiceAccountRepository
+}
+
+// サービスアカウントユースケースのインスタンス化
+func NewServiceAccountUsecase(l *pkg_logger.AppLogger, sar repository_service_account.IServiceAccountRepository) IServiceAccountUsecase {
+	return &ServiceAccountUsecase{
+		Logger:                   l,
+		serviceAccountRepository: sar,
+	}
+}
+
+// サービスアカウントを作成
+func (u *ServiceAccountUsecase) CreateServiceAccount(name string, description string) (domain_service_account.ServiceAccount, error) {
+	u.Logger.InfoLog.Println("CreateServiceAccount called")
+
+	name = strings.TrimSpace(name)
+
+	// バリデーション
+	if name == "" {
+		u.Logger.ErrorLog.Println("name is empty")
+		return domain_service_account.ServiceAccount{}, errors.New("name is empty")
+	}
+	if !domain_service_account.IsValidName(name) {
+		u.Logger.ErrorLog.Println("Invalid name format")
+		return domain_service_account.ServiceAccount{}, errors.New("invalid name format")
+	}
+
+	// サービスアカウントリポジトリからサービスアカウントを作成(repository層)
+	created, err := u.serviceAccountRepository.CreateServiceAccount(domain_service_account.ServiceAccount{
+		Name:        name,
+		Description: strings.TrimSpace(description),
+	})
+	if err != nil {
+		if errors.Is(err, repository_service_account.ErrServiceAccountAlreadyExists) {
+			u.Logger.ErrorLog.Println("Service account already exists")
+			return domain_service_account.ServiceAccount{}, errors.New("service account already exists")
+		}
+		u.Logger.ErrorLog.Printf("Failed to create service account: %v", err)
+		return domain_service_account.ServiceAccount{}, errors.New("failed to create service account")
+	}
+
+	u.Logger.InfoLog.Printf("Created service account: %s", created.ID)
+	return created, nil
+}
+
+// 全てのサービスアカウントを取得
+func (u *ServiceAccountUsecase) GetAllServiceAccounts() ([]domain_service_account.ServiceAccount, error) {
+	u.Logger.InfoLog.Println("GetAllServiceAccounts called")
+
+	// サービスアカウントリポジトリから全てのサービスアカウントを取得(repository層)
+	serviceAccounts, err := u.serviceAccountRepository.GetAllServiceAccounts()
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to get service accounts: %v", err)
+		return nil, err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d service accounts", len(serviceAccounts))
+	return serviceAccounts, nil
+}
+
+// APIキーを発行
+// ttlが0の場合は無期限のキーを発行する。
+func (u *ServiceAccountUsecase) CreateAPIKey(serviceAccountId string, scopes []string, ttl time.Duration) (string, domain_service_account.APIKey, error) {
+	u.Logger.InfoLog.Println("CreateAPIKey called")
+
+	// バリデーション
+	if serviceAccountId == "" {
+		u.Logger.ErrorLog.Println("service_account_id is empty")
+		return "", domain_service_account.APIKey{}, errors.New("service_account_id is empty")
+	}
+	if len(scopes) == 0 {
+		u.Logger.ErrorLog.Println("scopes is empty")
+		return "", domain_service_account.APIKey{}, errors.New("scopes is empty")
+	}
+	for _, scope := range scopes {
+		if !domain_auth.IsValidAPIKeyScope(scope) {
+			u.Logger.ErrorLog.Printf("Invalid scope: %s", scope)
+			return "", domain_service_account.APIKey{}, errors.New("invalid scope")
+		}
+	}
+	if ttl < 0 {
+		u.Logger.ErrorLog.Println("Invalid ttl")
+		return "", domain_service_account.APIKey{}, errors.New("invalid expiration")
+	}
+
+	// サービスアカウントの存在確認(repository層)
+	_, err := u.serviceAccountRepository.GetServiceAccountById(serviceAccountId)
+	if err != nil {
+		if errors.Is(err, repository_service_account.ErrServiceAccountNotFound) {
+			return "", domain_service_account.APIKey{}, errors.New("service account not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get service account: %v", err)
+		return "", domain_service_account.APIKey{}, errors.New("failed to create api key")
+	}
+
+	// キーを生成
+	secret, err := pkg_token.GenerateOpaqueToken(32)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to generate api key: %v", err)
+		return "", domain_service_account.APIKey{}, errors.New("failed to create api key")
+	}
+	plain := domain_service_account.APIKeyPrefix + secret
+
+	key := domain_service_account.APIKey{
+		ServiceAccountID: serviceAccountId,
+		Prefix:           domain_service_account.DisplayPrefix(plain),
+		KeyHash:          pkg_token.HashToken(plain),
+		Scopes:           scopes,
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		key.ExpiresAt = &expiresAt
+	}
+
+	// サービスアカウントリポジトリからAPIキーを作成(repository層)
+	created, err := u.serviceAccountRepository.CreateAPIKey(key)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to create api key: %v", err)
+		return "", domain_service_account.APIKey{}, errors.New("failed to create api key")
+	}
+
+	u.Logger.InfoLog.Printf("Created api key %s for service account: %s", created.ID, serviceAccountId)
+	return plain, created, nil
+}
+
+// サービスアカウントのAPIキーを取得
+func (u *ServiceAccountUsecase) GetAPIKeys(serviceAccountId string) ([]domain_service_account.APIKey, error) {
+	u.Logger.InfoLog.Println("GetAPIKeys called")
+
+	// バリデーション
+	if serviceAccountId == "" {
+		u.Logger.ErrorLog.Println("service_account_id is empty")
+		return nil, errors.New("service_account_id is empty")
+	}
+
+	// サービスアカウントリポジトリからAPIキーを取得(repository層)
+	keys, err := u.serviceAccountRepository.GetAPIKeysByServiceAccountId(serviceAccountId)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to get api keys: %v", err)
+		return nil, err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d api keys", len(keys))
+	return keys, nil
+}
+
+// APIキーを失効
+func (u *ServiceAccountUsecase) RevokeAPIKey(id string) error {
+	u.Logger.InfoLog.Println("RevokeAPIKey called")
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return errors.New("id is empty")
+	}
+
+	// サービスアカウントリポジトリからAPIキーを失効(repository層)
+	err := u.serviceAccountRepository.RevokeAPIKey(id)
+	if err != nil {
+		if errors.Is(err, repository_service_account.ErrAPIKeyNotFound) {
+			return errors.New("api key not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to revoke api key: %v", err)
+		return errors.New("failed to revoke api key")
+	}
+
+	u.Logger.InfoLog.Printf("Revoked api key: %s", id)
+	return nil
+}
+
+// APIキーを検証
+// 有効なキーの場合は最終使用日時を記録する(lastUsedUpdateInterval以内の再記録は省略)。
+func (u *ServiceAccountUsecase) AuthenticateAPIKey(key string) (domain_service_account.APIKey, error) {
+	if !strings.HasPrefix(key, domain_service_account.APIKeyPrefix) {
+		u.Logger.ErrorLog.Println("Invalid api key format")
+		return domain_service_account.APIKey{}, errors.New("invalid api key")
+	}
+
+	// サービスアカウントリポジトリからAPIキーを取得(repository層)
+	apiKey, err := u.serviceAccountRepository.GetAPIKeyByHash(pkg_token.HashToken(key))
+	if err != nil {
+		if errors.Is(err, repository_service_account.ErrAPIKeyNotFound) {
+			u.Logger.ErrorLog.Println("Api key not found")
+			return domain_service_account.APIKey{}, errors.New("invalid api key")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get api key: %v", err)
+		return domain_service_account.APIKey{}, errors.New("failed to verify api key")
+	}
+
+	now := time.Now()
+	if apiKey.IsRevoked() {
+		u.Logger.ErrorLog.Printf("Api key revoked: %s", apiKey.ID)
+		return domain_service_account.APIKey{}, errors.New("invalid api key")
+	}
+	if apiKey.IsExpired(now) {
+		u.Logger.ErrorLog.Printf("Api key expired: %s", apiKey.ID)
+		return domain_service_account.APIKey{}, errors.New("invalid api key")
+	}
+
+	// 最終使用日時を記録(リクエストの応答を遅らせないよう非同期で行う)
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedUpdateInterval {
+		go func(id string) {
+			if err := u.serviceAccountRepository.UpdateAPIKeyLastUsedAt(id, now); err != nil {
+				u.Logger.ErrorLog.Printf("Failed to record api key usage: %v", err)
+			}
+		}(apiKey.ID)
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,159 @@
+package usecase_todo
+
+import (
+	domain_todo "backend/internal/domain/todo"
+	pkg_pagination "backend/internal/pkg/pagination"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ページトークンの対象(取得条件が前のページと異なる場合はトークンを無効とする)
+const (
+	// 全てのTodo
+	todoPageScopeAll = "all"
+	// 特定のユーザーのTodo(後ろにユーザーIDを付ける)
+	todoPageScopeUser = "user:"
+	// 条件を指定したTodo(後ろに条件のハッシュ値を付ける)
+	todoPageScopeList = "list:"
+	// 検索結果(後ろに検索条件のハッシュ値を付ける)
+	todoPageScopeSearch = "search:"
+	// 期限切れのTodo(後ろにユーザーIDを付ける)
+	todoPageScopeOverdue = "overdue:"
+)
+
+// 検索結果を取得できる最大件数(関連度順はキーセットで辿れないため、読み飛ばす件数を制限する)
+const maxTodoSearchResults = 1000
+
+// Todo一覧のページトークン
+type todoPageToken struct {
+	domain_todo.TodoCursor
+	Scope string `json:"s"`
+}
+
+// 検索結果のページトークン
+// 関連度は浮動小数点数で同値の判定が不安定なため、キーセットではなく読み飛ばす件数を保持する。
+type todoSearchPageToken struct {
+	Offset int    `json:"o"`
+	Scope  string `json:"s"`
+}
+
+// 絞り込み条件・並び順からページトークンの対象を作成
+func todoListScope(filter domain_todo.TodoFilter, orders []domain_todo.TodoOrder) (string, error) {
+	b, err := json.Marshal(struct {
+		Filter  domain_todo.TodoFilter
+		OrderBy string
+	}{filter, domain_todo.FormatTodoOrderBy(orders)})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return todoPageScopeList + base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
+
+// 検索条件からページトークンの対象を作成
+func todoSearchScope(search domain_todo.TodoSearchQuery) (string, error) {
+	b, err := json.Marshal(struct {
+		Query  string
+		Mode   string
+		UserID string
+	}{search.Query, search.Mode, search.UserID})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return todoPageScopeSearch + base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
+
+// ページサイズ・ページトークンから取得条件を作成
+// 次のページの有無を判定するため、ページサイズより1件多く取得する。
+func (u *TodoUsecase) todoPageQuery(pageSize int32, pageToken string, scope string, orders []domain_todo.TodoOrder) (domain_todo.TodoPageQuery, error) {
+	limit, err := pkg_pagination.PageSize(pageSize)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Invalid page_size: %d", pageSize)
+		return domain_todo.TodoPageQuery{}, errors.New("invalid page_size")
+	}
+
+	page := domain_todo.TodoPageQuery{OrderBy: orders, Limit: limit + 1}
+	if pageToken != "" {
+		var token todoPageToken
+		if err := pkg_pagination.DecodeToken(pageToken, &token); err != nil || token.Scope != scope ||
+			!token.TodoCursor.HasValuesFor(orders) || !pkg_pagination.IsValidID(token.TodoCursor.ID) {
+			u.Logger.ErrorLog.Println("Invalid page token")
+			return domain_todo.TodoPageQuery{}, errors.New("invalid page_token")
+		}
+		page.After = &token.TodoCursor
+	}
+	return page, nil
+}
+
+// 取得したTodoをページサイズに切り詰め、次のページのトークンを作成
+// 最後のページの場合はトークンを空にする。
+func (u *TodoUsecase) nextTodoPage(todos []domain_todo.Todo, page domain_todo.TodoPageQuery, scope string) ([]domain_todo.Todo, string, error) {
+	limit := page.Limit - 1
+	if len(todos) <= limit {
+		return todos, "", nil
+	}
+
+	todos = todos[:limit]
+	last := todos[len(todos)-1]
+	nextPageToken, err := pkg_pagination.EncodeToken(todoPageToken{
+		TodoCursor: domain_todo.NewTodoCursor(last, page.OrderBy),
+		Scope:      scope,
+	})
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to encode page token: %v", err)
+		return nil, "", err
+	}
+	return todos, nextPageToken, nil
+}
+
+// ページサイズ・ページトークンから検索の取得範囲を設定
+// 次のページの有無を判定するため、ページサイズより1件多く取得する。
+func (u *TodoUsecase) todoSearchPageQuery(search domain_todo.TodoSearchQuery, pageSize int32, pageToken string, scope string) (domain_todo.TodoSearchQuery, error) {
+	limit, err := pkg_pagination.PageSize(pageSize)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Invalid page_size: %d", pageSize)
+		return domain_todo.TodoSearchQuery{}, errors.New("invalid page_size")
+	}
+
+	search.Limit = limit + 1
+	if pageToken != "" {
+		var token todoSearchPageToken
+		if err := pkg_pagination.DecodeToken(pageToken, &token); err != nil || token.Scope != scope ||
+			token.Offset <= 0 || token.Offset >= maxTodoSearchResults {
+			u.Logger.ErrorLog.Println("Invalid page token")
+			return domain_todo.TodoSearchQuery{}, errors.New("invalid page_token")
+		}
+		search.Offset = token.Offset
+	}
+	return search, nil
+}
+
+// 検索結果をページサイズに切り詰め、次のページのトークンを作成
+// 最後のページ、または最大件数に達した場合はトークンを空にする。
+func (u *TodoUsecase) nextTodoSearchPage(results []domain_todo.TodoSearchResult, search domain_todo.TodoSearchQuery, scope string) ([]domain_todo.TodoSearchResult, string, error) {
+	limit := search.Limit - 1
+	if search.Offset+limit > maxTodoSearchResults {
+		limit = maxTodoSearchResults - search.Offset
+	}
+	if len(results) <= limit {
+		return results, "", nil
+	}
+
+	results = results[:limit]
+	offset := search.Offset + limit
+	if offset >= maxTodoSearchResults {
+		return results, "", nil
+	}
+	nextPageToken, err := pkg_pagination.EncodeToken(todoSearchPageToken{
+		Offset: offset,
+		Scope:  scope,
+	})
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to encode page token: %v", err)
+		return nil, "", err
+	}
+	return results, nextPageToken, nil
+}
@@ -0,0 +1,164 @@
+package usecase_todo
+
+import (
+	domain_todo "backend/internal/domain/todo"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_pagination "backend/internal/pkg/pagination"
+	"io"
+	"testing"
+	"time"
+)
+
+const testTodoID = "6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b"
+
+// ログを出力しないTodoユースケース
+func newTestTodoUsecase() *TodoUsecase {
+	l := pkg_logger.NewAppLogger()
+	l.InfoLog.SetOutput(io.Discard)
+	l.ErrorLog.SetOutput(io.Discard)
+	l.WarnLog.SetOutput(io.Discard)
+	l.DebugLog.SetOutput(io.Discard)
+	return &TodoUsecase{Logger: l}
+}
+
+// テスト用のTodo一覧のページトークンを作成
+func encodeTestTodoPageToken(t *testing.T, token interface{}) string {
+	t.Helper()
+	encoded, err := pkg_pagination.EncodeToken(token)
+	if err != nil {
+		t.Fatalf("EncodeToken() error = %v", err)
+	}
+	return encoded
+}
+
+func TestTodoPageQueryRoundTrip(t *testing.T) {
+	u := newTestTodoUsecase()
+	orders := []domain_todo.TodoOrder{{Field: domain_todo.TodoOrderPriority, Descending: true}, {Field: domain_todo.TodoOrderCreatedAt}}
+	scope := todoPageScopeUser + "user-1"
+
+	// 1件多く取得した結果から次のページのトークンを作成する
+	page, err := u.todoPageQuery(2, "", scope, orders)
+	if err != nil {
+		t.Fatalf("todoPageQuery() error = %v", err)
+	}
+	if page.Limit != 3 || page.After != nil {
+		t.Fatalf("todoPageQuery() = %+v, want Limit 3 and no cursor", page)
+	}
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	todos := []domain_todo.Todo{
+		{ID: "a", CreatedAt: createdAt, Priority: 3},
+		{ID: testTodoID, CreatedAt: createdAt, Priority: 2},
+		{ID: "c", CreatedAt: createdAt, Priority: 1},
+	}
+	got, next, err := u.nextTodoPage(todos, page, scope)
+	if err != nil {
+		t.Fatalf("nextTodoPage() error = %v", err)
+	}
+	if len(got) != 2 || next == "" {
+		t.Fatalf("nextTodoPage() = %d todos, token %q, want 2 todos and a token", len(got), next)
+	}
+
+	// 次のページは最後の行より後ろから取得する
+	page, err = u.todoPageQuery(2, next, scope, orders)
+	if err != nil {
+		t.Fatalf("todoPageQuery(next) error = %v", err)
+	}
+	if page.After == nil || page.After.ID != testTodoID || page.After.Priority == nil || *page.After.Priority != 2 ||
+		!page.After.CreatedAt.Equal(createdAt) {
+		t.Errorf("todoPageQuery(next).After = %+v, want cursor of %s", page.After, testTodoID)
+	}
+
+	// 最後のページはトークンを空にする
+	_, next, err = u.nextTodoPage(todos[:2], page, scope)
+	if err != nil {
+		t.Fatalf("nextTodoPage(last) error = %v", err)
+	}
+	if next != "" {
+		t.Errorf("nextTodoPage(last) token = %q, want empty", next)
+	}
+}
+
+func TestTodoPageQueryInvalid(t *testing.T) {
+	u := newTestTodoUsecase()
+	scope := todoPageScopeUser + "user-1"
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	priority := int32(2)
+	valid := todoPageToken{TodoCursor: domain_todo.TodoCursor{CreatedAt: createdAt, ID: testTodoID}, Scope: scope}
+
+	tests := []struct {
+		name     string
+		pageSize int32
+		token    string
+		orders   []domain_todo.TodoOrder
+		wantErr  string
+	}{
+		{"負のページサイズ", -1, "", domain_todo.DefaultTodoOrder, "invalid page_size"},
+		{"base64でない", 0, "!!!", domain_todo.DefaultTodoOrder, "invalid page_token"},
+		{"別のユーザーのトークン", 0, encodeTestTodoPageToken(t, todoPageToken{TodoCursor: valid.TodoCursor, Scope: todoPageScopeUser + "user-2"}), domain_todo.DefaultTodoOrder, "invalid page_token"},
+		{"別のメソッドのトークン", 0, encodeTestTodoPageToken(t, todoPageToken{TodoCursor: valid.TodoCursor, Scope: todoPageScopeAll}), domain_todo.DefaultTodoOrder, "invalid page_token"},
+		{"IDがない", 0, encodeTestTodoPageToken(t, todoPageToken{TodoCursor: domain_todo.TodoCursor{CreatedAt: createdAt}, Scope: scope}), domain_todo.DefaultTodoOrder, "invalid page_token"},
+		{"IDがUUIDでない", 0, encodeTestTodoPageToken(t, todoPageToken{TodoCursor: domain_todo.TodoCursor{CreatedAt: createdAt, ID: "not-a-uuid"}, Scope: scope}), domain_todo.DefaultTodoOrder, "invalid page_token"},
+		{"並び順の項目の値がない", 0, encodeTestTodoPageToken(t, valid), []domain_todo.TodoOrder{{Field: domain_todo.TodoOrderPriority}}, "invalid page_token"},
+		{"並び順の項目の値がある", 0, encodeTestTodoPageToken(t, todoPageToken{TodoCursor: domain_todo.TodoCursor{CreatedAt: createdAt, Priority: &priority, ID: testTodoID}, Scope: scope}), []domain_todo.TodoOrder{{Field: domain_todo.TodoOrderPriority}}, ""},
+		{"有効なトークン", 0, encodeTestTodoPageToken(t, valid), domain_todo.DefaultTodoOrder, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.todoPageQuery(tt.pageSize, tt.token, scope, tt.orders)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("todoPageQuery() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("todoPageQuery() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTodoSearchPageQuery(t *testing.T) {
+	u := newTestTodoUsecase()
+	search := domain_todo.TodoSearchQuery{Query: "milk", UserID: "user-1"}
+	scope, err := todoSearchScope(search)
+	if err != nil {
+		t.Fatalf("todoSearchScope() error = %v", err)
+	}
+	otherScope, err := todoSearchScope(domain_todo.TodoSearchQuery{Query: "eggs", UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("todoSearchScope() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantOffset int
+		wantErr    bool
+	}{
+		{"先頭のページ", "", 0, false},
+		{"次のページ", encodeTestTodoPageToken(t, todoSearchPageToken{Offset: 20, Scope: scope}), 20, false},
+		{"別の検索条件のトークン", encodeTestTodoPageToken(t, todoSearchPageToken{Offset: 20, Scope: otherScope}), 0, true},
+		{"読み飛ばす件数が0", encodeTestTodoPageToken(t, todoSearchPageToken{Offset: 0, Scope: scope}), 0, true},
+		{"読み飛ばす件数が負", encodeTestTodoPageToken(t, todoSearchPageToken{Offset: -1, Scope: scope}), 0, true},
+		{"最大件数に到達", encodeTestTodoPageToken(t, todoSearchPageToken{Offset: maxTodoSearchResults, Scope: scope}), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := u.todoSearchPageQuery(search, 0, tt.token, scope)
+			if tt.wantErr {
+				if err == nil || err.Error() != "invalid page_token" {
+					t.Errorf("todoSearchPageQuery() error = %v, want invalid page_token", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("todoSearchPageQuery() error = %v", err)
+			}
+			if got.Offset != tt.wantOffset || got.Limit != pkg_pagination.DefaultPageSize+1 {
+				t.Errorf("todoSearchPageQuery() = offset %d limit %d, want offset %d limit %d",
+					got.Offset, got.Limit, tt.wantOffset, pkg_pagination.DefaultPageSize+1)
+			}
+		})
+	}
+}
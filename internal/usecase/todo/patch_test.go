@@ -0,0 +1,40 @@
+package usecase_todo
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_todo "backend/internal/domain/todo"
+	"testing"
+)
+
+// FieldMaskが不正な場合はリポジトリを呼び出さずにエラーを返す(ハンドラーでINVALID_ARGUMENTになる)
+func TestPatchTodoInvalidMask(t *testing.T) {
+	u := newTestTodoUsecase()
+	principal := &domain_auth.Principal{UserID: "user-1"}
+	todo := domain_todo.Todo{ID: testTodoID, Version: 1, Description: "buy milk"}
+
+	tests := []struct {
+		name    string
+		todo    domain_todo.Todo
+		mask    []string
+		wantErr string
+	}{
+		{"空のFieldMask", todo, nil, "update_mask is empty"},
+		{"未知の項目", todo, []string{"title"}, "invalid update_mask"},
+		{"作成日時", todo, []string{"created_at"}, "immutable field in update_mask"},
+		{"更新日時", todo, []string{"updatedAt"}, "immutable field in update_mask"},
+		{"空の説明", domain_todo.Todo{ID: testTodoID, Version: 1}, []string{"description"}, "description is empty"},
+		{"空の所有者", todo, []string{"user_id"}, "user_id is empty"},
+		{"範囲外の優先度", domain_todo.Todo{ID: testTodoID, Version: 1, Priority: 99}, []string{"priority"}, "invalid priority"},
+		{"自分自身を親にする", domain_todo.Todo{ID: testTodoID, Version: 1, ParentID: func() *string { id := testTodoID; return &id }()}, []string{"parent_id"}, "parent_id creates a cycle"},
+		{"idがない", domain_todo.Todo{Version: 1}, []string{"completed"}, "id is empty"},
+		{"versionがない", domain_todo.Todo{ID: testTodoID}, []string{"completed"}, "version is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.PatchTodo(principal, tt.todo, tt.mask)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("PatchTodo(%v) error = %v, want %q", tt.mask, err, tt.wantErr)
+			}
+		})
+	}
+}
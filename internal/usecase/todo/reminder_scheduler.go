@@ -0,0 +1,120 @@
+package usecase_todo
+
+import (
+	domain_todo "backend/internal/domain/todo"
+	pkg_logger "backend/internal/pkg/logger"
+	pkg_notifier "backend/internal/pkg/notifier"
+	repository_todo "backend/internal/repository/todo"
+	"sync"
+	"time"
+)
+
+// リマインダーの送信に失敗した場合の再試行回数の上限
+const maxReminderAttempts = 5
+
+// 確保したリマインダー1件あたりの送信猶予(通知先のタイムアウトより十分長くする)
+// 1回分の確保期限はbatchSize件分となり、期限内に結果を記録できなかったリマインダーは再度送信される。
+const reminderLeasePerTodo = 30 * time.Second
+
+// リマインダースケジューラー(IF)
+type IReminderScheduler interface {
+	// 定期的にリマインダーの送信を開始
+	Start()
+	// リマインダーの送信を停止(処理中のリマインダーの送信が終わるまで待つ)
+	Stop()
+	// 通知日時を過ぎたリマインダーを1回分送信(送信数・失敗数を返す)
+	RunOnce() (int, int, error)
+}
+
+// リマインダースケジューラー(Impl)
+// 一定間隔で通知日時を過ぎたリマインダーを取得し、通知を送信する。
+// 取得した行は送信中として確保されるため、複数のサーバーで起動しても同じリマインダーを重複して送信しない。
+type ReminderScheduler struct {
+	Logger         *pkg_logger.AppLogger
+	todoRepository repository_todo.ITodoRepository
+	notifier       pkg_notifier.INotifier
+	interval       time.Duration // 確認間隔
+	batchSize      int           // 1回に送信する最大件数
+	stop           chan struct{}
+	wg             sync.WaitGroup
+	once           sync.Once
+}
+
+// リマインダースケジューラーのインスタンス化
+func NewReminderScheduler(l *pkg_logger.AppLogger, tr repository_todo.ITodoRepository, n pkg_notifier.INotifier, interval time.Duration, batchSize int) IReminderScheduler {
+	return &ReminderScheduler{
+		Logger:         l,
+		todoRepository: tr,
+		notifier:       n,
+		interval:       interval,
+		batchSize:      batchSize,
+		stop:           make(chan struct{}),
+	}
+}
+
+// 定期的にリマインダーの送信を開始
+func (s *ReminderScheduler) Start() {
+	s.Logger.InfoLog.Printf("Starting reminder scheduler (interval: %v)", s.interval)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				// 全てbatchSize件送信できた場合は、残りがなくなるまで続けて送信する
+				// (失敗があった場合は送信先の障害を考慮し、次の確認まで待つ)
+				for {
+					sent, failed, err := s.RunOnce()
+					if err != nil || failed > 0 || sent < s.batchSize {
+						break
+					}
+					select {
+					case <-s.stop:
+						return
+					default:
+					}
+				}
+			}
+		}
+	}()
+}
+
+// リマインダーの送信を停止
+func (s *ReminderScheduler) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	s.wg.Wait()
+	s.Logger.InfoLog.Println("Reminder scheduler stopped")
+}
+
+// 通知日時を過ぎたリマインダーを1回分送信
+func (s *ReminderScheduler) RunOnce() (int, int, error) {
+	// Todoリポジトリからリマインダーを取得して送信(repository層)
+	sent, failed, err := s.todoRepository.ProcessDueReminders(s.batchSize, maxReminderAttempts, time.Duration(s.batchSize)*reminderLeasePerTodo, s.dispatch)
+	if err != nil {
+		s.Logger.ErrorLog.Printf("Failed to process reminders: %v", err)
+		return 0, 0, err
+	}
+	return sent, failed, nil
+}
+
+// Todoのリマインダーを通知
+func (s *ReminderScheduler) dispatch(todo domain_todo.Todo) error {
+	notification := pkg_notifier.Notification{
+		Event:       pkg_notifier.EventTodoReminder,
+		UserID:      todo.UserId,
+		TodoID:      todo.ID,
+		Description: todo.Description,
+		DueAt:       todo.DueAt,
+	}
+	if todo.RemindAt != nil {
+		notification.RemindAt = *todo.RemindAt
+	}
+	return s.notifier.Notify(notification)
+}
@@ -0,0 +1,241 @@
+package usecase_todo
+
+import (
+	domain_auth "backend/internal/domain/auth"
+	domain_todo "backend/internal/domain/todo"
+	pkg_logger "backend/internal/pkg/logger"
+	repository_todo "backend/internal/repository/todo"
+	"errors"
+)
+
+// タグユースケース(IF)
+// タグはユーザーごとに管理し、所有者以外の操作を拒否する。todo:admin権限を持つ主体は全てのユーザーのタグを操作できる。
+type ITagUsecase interface {
+	// 特定のユーザーのタグを名前順に取得(userIdが未指定の場合は実行者のタグ)
+	ListTags(principal *domain_auth.Principal, userId string) ([]domain_todo.Tag, error)
+	// 新しいタグを作成(tag.UserIDが未指定の場合は実行者のタグ)
+	CreateTag(principal *domain_auth.Principal, tag domain_todo.Tag) (domain_todo.Tag, error)
+	// タグの名前・色を更新
+	UpdateTag(principal *domain_auth.Principal, tag domain_todo.Tag) (domain_todo.Tag, error)
+	// タグを削除(付いていたTodoからも外れる)
+	DeleteTag(principal *domain_auth.Principal, id string) error
+}
+
+// タグユースケース(Impl)
+type TagUsecase struct {
+	Logger        *pkg_logger.AppLogger
+	tagRepository repository_todo.ITagRepository
+}
+
+// タグユースケースのインスタンス化
+func NewTagUsecase(l *pkg_logger.AppLogger, tr repository_todo.ITagRepository) ITagUsecase {
+	return &TagUsecase{
+		Logger:        l,
+		tagRepository: tr,
+	}
+}
+
+// 特定のユーザーのタグを名前順に取得
+// ユーザーに紐づかない主体(サービスアカウント)はuserIdの指定が必要で、todo:admin権限がない場合はpermission deniedを返す。
+func (u *TagUsecase) ListTags(principal *domain_auth.Principal, userId string) ([]domain_todo.Tag, error) {
+	u.Logger.InfoLog.Println("ListTags called")
+
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return nil, errors.New("unauthenticated")
+	}
+	if userId == "" {
+		userId = principal.UserID
+	}
+	if userId == "" {
+		if principal.HasPermission(domain_auth.PermissionTodoAdmin) {
+			u.Logger.ErrorLog.Println("user_id is empty")
+			return nil, errors.New("user_id is empty")
+		}
+		u.Logger.ErrorLog.Printf("Service account %s cannot list tags without todo:admin", principal.ServiceAccountID)
+		return nil, errors.New("permission denied")
+	}
+	if err := u.authorizeUser(principal, userId); err != nil {
+		return nil, err
+	}
+
+	// タグリポジトリからユーザーのタグを取得(repository層)
+	tags, err := u.tagRepository.GetTagsByUserId(userId)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to list tags: %v", err)
+		return nil, err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d tags", len(tags))
+	return tags, nil
+}
+
+// 新しいタグを作成
+func (u *TagUsecase) CreateTag(principal *domain_auth.Principal, tag domain_todo.Tag) (domain_todo.Tag, error) {
+	u.Logger.InfoLog.Println("CreateTag called")
+
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return domain_todo.Tag{}, errors.New("unauthenticated")
+	}
+	if tag.UserID == "" {
+		tag.UserID = principal.UserID
+	}
+
+	// バリデーション
+	tag.Name = domain_todo.NormalizeTagName(tag.Name)
+	if err := u.validateTag(tag); err != nil {
+		return domain_todo.Tag{}, err
+	}
+	if tag.UserID == "" {
+		u.Logger.ErrorLog.Println("user_id is empty")
+		return domain_todo.Tag{}, errors.New("user_id is empty")
+	}
+	if err := u.authorizeUser(principal, tag.UserID); err != nil {
+		return domain_todo.Tag{}, err
+	}
+
+	// タグリポジトリから新しいタグを作成(repository層)
+	createdTag, err := u.tagRepository.CreateTag(tag)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_todo.ErrTagAlreadyExists):
+			u.Logger.ErrorLog.Printf("Tag already exists: %s", tag.Name)
+			return domain_todo.Tag{}, errors.New("tag already exists")
+		case errors.Is(err, repository_todo.ErrTagOwnerNotFound):
+			u.Logger.ErrorLog.Printf("User not found: %s", tag.UserID)
+			return domain_todo.Tag{}, errors.New("user not found")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to create tag: %v", err)
+			return domain_todo.Tag{}, err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Created tag: %v", createdTag)
+	return createdTag, nil
+}
+
+// タグの名前・色を更新
+// 所有者は変更できない。
+func (u *TagUsecase) UpdateTag(principal *domain_auth.Principal, tag domain_todo.Tag) (domain_todo.Tag, error) {
+	u.Logger.InfoLog.Println("UpdateTag called")
+
+	// バリデーション
+	if tag.ID == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return domain_todo.Tag{}, errors.New("id is empty")
+	}
+	tag.Name = domain_todo.NormalizeTagName(tag.Name)
+	if err := u.validateTag(tag); err != nil {
+		return domain_todo.Tag{}, err
+	}
+
+	// 所有者を確認
+	current, err := u.getOwnedTag(principal, tag.ID)
+	if err != nil {
+		return domain_todo.Tag{}, err
+	}
+	tag.UserID = current.UserID
+
+	// タグリポジトリから指定されたidのタグを更新(repository層)
+	updatedTag, err := u.tagRepository.UpdateTag(tag)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_todo.ErrTagNotFound):
+			u.Logger.ErrorLog.Printf("Tag not found: %s", tag.ID)
+			return domain_todo.Tag{}, errors.New("tag not found")
+		case errors.Is(err, repository_todo.ErrTagAlreadyExists):
+			u.Logger.ErrorLog.Printf("Tag already exists: %s", tag.Name)
+			return domain_todo.Tag{}, errors.New("tag already exists")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to update tag: %v", err)
+			return domain_todo.Tag{}, err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Updated tag: %v", updatedTag)
+	return updatedTag, nil
+}
+
+// タグを削除
+func (u *TagUsecase) DeleteTag(principal *domain_auth.Principal, id string) error {
+	u.Logger.InfoLog.Println("DeleteTag called")
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return errors.New("id is empty")
+	}
+
+	// 所有者を確認
+	if _, err := u.getOwnedTag(principal, id); err != nil {
+		return err
+	}
+
+	// タグリポジトリから指定されたidのタグを削除(repository層)
+	err := u.tagRepository.DeleteTag(id)
+	if err != nil {
+		if errors.Is(err, repository_todo.ErrTagNotFound) {
+			u.Logger.ErrorLog.Printf("Tag not found: %s", id)
+			return errors.New("tag not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to delete tag: %v", err)
+		return err
+	}
+
+	u.Logger.InfoLog.Printf("Deleted tag: %v", id)
+	return nil
+}
+
+// タグの名前・色を検証
+func (u *TagUsecase) validateTag(tag domain_todo.Tag) error {
+	if tag.Name == "" {
+		u.Logger.ErrorLog.Println("name is empty")
+		return errors.New("name is empty")
+	}
+	if len([]rune(tag.Name)) > domain_todo.MaxTagNameLength {
+		u.Logger.ErrorLog.Println("name is too long")
+		return errors.New("name is too long")
+	}
+	if !domain_todo.IsValidTagColor(tag.Color) {
+		u.Logger.ErrorLog.Printf("Invalid color: %s", tag.Color)
+		return errors.New("invalid color")
+	}
+	return nil
+}
+
+// 実行者が所有するタグを取得
+// 他のユーザーのタグは存在を推測されないよう、存在しない場合と同じエラーを返す。
+func (u *TagUsecase) getOwnedTag(principal *domain_auth.Principal, id string) (domain_todo.Tag, error) {
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return domain_todo.Tag{}, errors.New("unauthenticated")
+	}
+
+	// タグリポジトリから指定されたidのタグを取得(repository層)
+	tag, err := u.tagRepository.GetTagById(id)
+	if err != nil {
+		if errors.Is(err, repository_todo.ErrTagNotFound) {
+			u.Logger.ErrorLog.Printf("Tag not found: %s", id)
+			return domain_todo.Tag{}, errors.New("tag not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get tag by id: %v", err)
+		return domain_todo.Tag{}, err
+	}
+
+	if !principal.IsUser(tag.UserID) && !principal.HasPermission(domain_auth.PermissionTodoAdmin) {
+		u.Logger.ErrorLog.Printf("Tag %s is not owned by user: %s", id, principal.UserID)
+		return domain_todo.Tag{}, errors.New("tag not found")
+	}
+
+	return tag, nil
+}
+
+// 指定したユーザーのタグを操作できるか確認
+func (u *TagUsecase) authorizeUser(principal *domain_auth.Principal, userId string) error {
+	if !principal.IsUser(userId) && !principal.HasPermission(domain_auth.PermissionTodoAdmin) {
+		u.Logger.ErrorLog.Printf("User %s cannot access tags of user: %s", principal.UserID, userId)
+		return errors.New("permission denied")
+	}
+	return nil
+}
@@ -1,59 +1,339 @@
 package usecase_todo
 
 import (
+	domain_auth "backend/internal/domain/auth"
 	domain_todo "backend/internal/domain/todo"
 	pkg_logger "backend/internal/pkg/logger"
 	repository_todo "backend/internal/repository/todo"
 	"errors"
+	"strings"
+	"time"
 )
 
+// Todoの説明の絞り込み文字列・検索文字列の最大文字数
+const maxTodoSearchLength = 100
+
 // Todoユースケース(IF)
+// 全てのメソッドは認証済みの主体(principal)を受け取り、所有者以外の操作を拒否する。
+// todo:admin権限を持つ主体は全てのユーザーのTodoを操作できる。
 type ITodoUsecase interface {
-	// 全てのTodoを取得
-	GetAllTodos() ([]domain_todo.Todo, error)
+	// 全てのTodoをページ単位で取得(todo:admin権限がない場合は自分のTodoのみ。次ページのトークンを返す)
+	GetAllTodos(principal *domain_auth.Principal, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error)
+	// 条件を指定してTodoをページ単位で取得(次ページのトークンを返す)
+	ListTodos(principal *domain_auth.Principal, filter domain_todo.TodoFilter, orderBy string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error)
+	// 期限切れの未完了のTodoを期限の昇順にページ単位で取得(次ページのトークンを返す)
+	ListOverdueTodos(principal *domain_auth.Principal, userId string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error)
+	// 指定したタイムゾーンで今日が期限の未完了のTodoを期限の昇順にページ単位で取得(次ページのトークンを返す)
+	ListDueToday(principal *domain_auth.Principal, timeZone string, userId string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error)
+	// Todoの説明を検索し、関連度の高い順にページ単位で取得(次ページのトークンを返す)
+	SearchTodos(principal *domain_auth.Principal, query string, mode string, userId string, pageSize int32, pageToken string) ([]domain_todo.TodoSearchResult, string, error)
 	// idを指定してTodoを取得
-	GetTodoById(id string) (domain_todo.Todo, error)
-	// 特定のユーザーのTodoを取得
-	GetTodoByUserId(userId string) ([]domain_todo.Todo, error)
+	GetTodoById(principal *domain_auth.Principal, id string) (domain_todo.Todo, error)
+	// idを指定してTodoとそのサブタスクを階層で取得
+	GetTodoTree(principal *domain_auth.Principal, id string) (domain_todo.TodoTree, error)
+	// 特定のユーザーのTodoをページ単位で取得(次ページのトークンを返す)
+	GetTodoByUserId(principal *domain_auth.Principal, userId string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error)
 	// 新しいTodoを作成
-	CreateTodo(todo domain_todo.Todo) (domain_todo.Todo, error)
-	// Todoを更新
-	UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo, error)
-	// Todoを削除
-	DeleteTodo(id string) error
+	CreateTodo(principal *domain_auth.Principal, todo domain_todo.Todo) (domain_todo.Todo, error)
+	// Todoを更新(todo.Versionが一致しない場合は*domain_todo.VersionConflictError)
+	UpdateTodo(principal *domain_auth.Principal, todo domain_todo.Todo) (domain_todo.Todo, error)
+	// Todoの指定した項目(updateMask)のみを更新(todo.Versionが一致しない場合は*domain_todo.VersionConflictError)
+	PatchTodo(principal *domain_auth.Principal, todo domain_todo.Todo, updateMask []string) (domain_todo.Todo, error)
+	// Todoを削除(versionが一致しない場合は*domain_todo.VersionConflictError)
+	DeleteTodo(principal *domain_auth.Principal, id string, version int64) error
 }
 
 // Todoユースケース(Impl)
 type TodoUsecase struct {
-	Logger         *pkg_logger.AppLogger
-	todoRepository repository_todo.ITodoRepository
+	Logger           *pkg_logger.AppLogger
+	todoRepository   repository_todo.ITodoRepository
+	defaultLocation  *time.Location                   // タイムゾーンが未指定の場合に「今日」の判定に使用するタイムゾーン
+	parentCompletion domain_todo.ParentCompletionRule // サブタスクの完了に合わせた親のTodoの完了状態の扱い
 }
 
 // Todoユースケースのインスタンス化
-func NewTodoUsecase(l *pkg_logger.AppLogger, tr repository_todo.ITodoRepository) ITodoUsecase {
+func NewTodoUsecase(l *pkg_logger.AppLogger, tr repository_todo.ITodoRepository, defaultLocation *time.Location, parentCompletion domain_todo.ParentCompletionRule) ITodoUsecase {
 	return &TodoUsecase{
-		Logger:         l,
-		todoRepository: tr,
+		Logger:           l,
+		todoRepository:   tr,
+		defaultLocation:  defaultLocation,
+		parentCompletion: parentCompletion,
 	}
 }
 
-// 全てのTodoを取得
-func (u *TodoUsecase) GetAllTodos() ([]domain_todo.Todo, error) {
+// 全てのTodoをページ単位で取得
+func (u *TodoUsecase) GetAllTodos(principal *domain_auth.Principal, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error) {
 	u.Logger.InfoLog.Println("GetAllTodos called")
 
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return nil, "", errors.New("unauthenticated")
+	}
+
+	// 管理者以外は自分のTodoのみ取得する
+	if !principal.HasPermission(domain_auth.PermissionTodoAdmin) {
+		// ユーザーに紐づかない主体(サービスアカウント)は所有するTodoがない
+		if principal.UserID == "" {
+			u.Logger.ErrorLog.Printf("Service account %s cannot list todos without todo:admin", principal.ServiceAccountID)
+			return nil, "", errors.New("permission denied")
+		}
+		return u.GetTodoByUserId(principal, principal.UserID, pageSize, pageToken)
+	}
+
+	page, err := u.todoPageQuery(pageSize, pageToken, todoPageScopeAll, domain_todo.DefaultTodoOrder)
+	if err != nil {
+		return nil, "", err
+	}
+
 	// Todoリポジトリから全てのTodoを取得(repository層)
-	todos, err := u.todoRepository.GetAllTodos()
+	todos, err := u.todoRepository.GetAllTodos(page)
 	if err != nil {
 		u.Logger.ErrorLog.Printf("Failed to get all todos: %v", err)
-		return nil, err
+		return nil, "", err
+	}
+
+	todos, nextPageToken, err := u.nextTodoPage(todos, page, todoPageScopeAll)
+	if err != nil {
+		return nil, "", err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d todos", len(todos))
+	return todos, nextPageToken, nil
+}
+
+// 条件を指定してTodoをページ単位で取得
+// todo:admin権限がない場合は自分のTodoのみを対象とする(他のユーザーを指定した場合はpermission denied)。
+func (u *TodoUsecase) ListTodos(principal *domain_auth.Principal, filter domain_todo.TodoFilter, orderBy string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error) {
+	u.Logger.InfoLog.Println("ListTodos called")
+
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return nil, "", errors.New("unauthenticated")
+	}
+
+	// バリデーション
+	filter.Description = strings.TrimSpace(filter.Description)
+	if len([]rune(filter.Description)) > maxTodoSearchLength {
+		u.Logger.ErrorLog.Println("Description filter is too long")
+		return nil, "", errors.New("description filter is too long")
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		u.Logger.ErrorLog.Println("Invalid created_at range")
+		return nil, "", errors.New("invalid created_at range")
+	}
+	if filter.UpdatedFrom != nil && filter.UpdatedTo != nil && !filter.UpdatedFrom.Before(*filter.UpdatedTo) {
+		u.Logger.ErrorLog.Println("Invalid updated_at range")
+		return nil, "", errors.New("invalid updated_at range")
+	}
+	if filter.Priority != nil {
+		if err := u.validatePriority(*filter.Priority); err != nil {
+			return nil, "", err
+		}
+	}
+	switch filter.TagMatch {
+	case "", domain_todo.TagMatchAny:
+		filter.TagMatch = domain_todo.TagMatchAny
+	case domain_todo.TagMatchAll:
+	default:
+		u.Logger.ErrorLog.Printf("Invalid tag_match: %s", filter.TagMatch)
+		return nil, "", errors.New("invalid tag_match")
+	}
+	tagIDs, err := u.uniqueTagIDs(filter.TagIDs)
+	if err != nil {
+		return nil, "", err
+	}
+	filter.TagIDs = tagIDs
+	orders, err := domain_todo.ParseTodoOrderBy(orderBy)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Invalid order_by: %s", orderBy)
+		return nil, "", errors.New("invalid order_by")
+	}
+
+	// 管理者以外は自分のTodoのみ取得する
+	userId, err := u.ownerScope(principal, filter.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+	filter.UserID = userId
+
+	scope, err := todoListScope(filter, orders)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to create page scope: %v", err)
+		return nil, "", err
+	}
+	page, err := u.todoPageQuery(pageSize, pageToken, scope, orders)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Todoリポジトリから条件に一致するTodoを取得(repository層)
+	todos, err := u.todoRepository.ListTodos(filter, page)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to list todos: %v", err)
+		return nil, "", err
+	}
+
+	todos, nextPageToken, err := u.nextTodoPage(todos, page, scope)
+	if err != nil {
+		return nil, "", err
 	}
 
 	u.Logger.InfoLog.Printf("Fetched %d todos", len(todos))
-	return todos, nil
+	return todos, nextPageToken, nil
+}
+
+// 期限切れの未完了のTodoを期限の昇順にページ単位で取得
+// todo:admin権限がない場合は自分のTodoのみを対象とする(他のユーザーを指定した場合はpermission denied)。
+func (u *TodoUsecase) ListOverdueTodos(principal *domain_auth.Principal, userId string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error) {
+	u.Logger.InfoLog.Println("ListOverdueTodos called")
+
+	// 管理者以外は自分のTodoのみ取得する
+	userId, err := u.ownerScope(principal, userId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 現在日時はページごとに変わるため、ページトークンの対象は所有者のみとする
+	// (期限の昇順に辿るため、途中で期限切れになったTodoは後のページに含まれる)
+	now := time.Now()
+	completed := false
+	filter := domain_todo.TodoFilter{UserID: userId, Completed: &completed, DueTo: &now}
+	scope := todoPageScopeOverdue + userId
+	page, err := u.todoPageQuery(pageSize, pageToken, scope, domain_todo.DueTodoOrder)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Todoリポジトリから期限切れのTodoを取得(repository層)
+	todos, err := u.todoRepository.ListTodos(filter, page)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to list overdue todos: %v", err)
+		return nil, "", err
+	}
+
+	todos, nextPageToken, err := u.nextTodoPage(todos, page, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d overdue todos", len(todos))
+	return todos, nextPageToken, nil
+}
+
+// 今日が期限の未完了のTodoを期限の昇順にページ単位で取得
+// 「今日」はtimeZone(IANAのタイムゾーン名。未指定の場合は既定のタイムゾーン)の0時から翌日の0時までとする。
+// todo:admin権限がない場合は自分のTodoのみを対象とする(他のユーザーを指定した場合はpermission denied)。
+func (u *TodoUsecase) ListDueToday(principal *domain_auth.Principal, timeZone string, userId string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error) {
+	u.Logger.InfoLog.Println("ListDueToday called")
+
+	// バリデーション
+	loc := u.defaultLocation
+	if timeZone = strings.TrimSpace(timeZone); timeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(timeZone)
+		if err != nil {
+			u.Logger.ErrorLog.Printf("Invalid time_zone: %s", timeZone)
+			return nil, "", errors.New("invalid time_zone")
+		}
+	}
+
+	// 管理者以外は自分のTodoのみ取得する
+	userId, err := u.ownerScope(principal, userId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 日付が変わった場合は以前のページトークンを無効とする
+	start, end := domain_todo.DayRange(time.Now(), loc)
+	completed := false
+	filter := domain_todo.TodoFilter{UserID: userId, Completed: &completed, DueFrom: &start, DueTo: &end}
+	scope, err := todoListScope(filter, domain_todo.DueTodoOrder)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to create page scope: %v", err)
+		return nil, "", err
+	}
+	page, err := u.todoPageQuery(pageSize, pageToken, scope, domain_todo.DueTodoOrder)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Todoリポジトリから今日が期限のTodoを取得(repository層)
+	todos, err := u.todoRepository.ListTodos(filter, page)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to list todos due today: %v", err)
+		return nil, "", err
+	}
+
+	todos, nextPageToken, err := u.nextTodoPage(todos, page, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d todos due today", len(todos))
+	return todos, nextPageToken, nil
+}
+
+// Todoの説明を検索し、関連度の高い順にページ単位で取得
+// todo:admin権限がない場合は自分のTodoのみを対象とする(他のユーザーを指定した場合はpermission denied)。
+func (u *TodoUsecase) SearchTodos(principal *domain_auth.Principal, query string, mode string, userId string, pageSize int32, pageToken string) ([]domain_todo.TodoSearchResult, string, error) {
+	u.Logger.InfoLog.Println("SearchTodos called")
+
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return nil, "", errors.New("unauthenticated")
+	}
+
+	// バリデーション
+	query = strings.TrimSpace(query)
+	if query == "" {
+		u.Logger.ErrorLog.Println("query is empty")
+		return nil, "", errors.New("query is empty")
+	}
+	if len([]rune(query)) > maxTodoSearchLength {
+		u.Logger.ErrorLog.Println("query is too long")
+		return nil, "", errors.New("query is too long")
+	}
+	mode, ok := domain_todo.ResolveTodoSearchMode(strings.ToLower(strings.TrimSpace(mode)), query)
+	if !ok {
+		u.Logger.ErrorLog.Printf("Invalid search mode: %s", mode)
+		return nil, "", errors.New("invalid mode")
+	}
+
+	// 管理者以外は自分のTodoのみ検索する
+	userId, err := u.ownerScope(principal, userId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	search := domain_todo.TodoSearchQuery{Query: query, Mode: mode, UserID: userId}
+	scope, err := todoSearchScope(search)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to create page scope: %v", err)
+		return nil, "", err
+	}
+	search, err = u.todoSearchPageQuery(search, pageSize, pageToken, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Todoリポジトリから説明を検索(repository層)
+	results, err := u.todoRepository.SearchTodos(search)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to search todos: %v", err)
+		return nil, "", err
+	}
+
+	results, nextPageToken, err := u.nextTodoSearchPage(results, search, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	u.Logger.InfoLog.Printf("Found %d todos", len(results))
+	return results, nextPageToken, nil
 }
 
 // idを指定してTodoを取得
-func (u *TodoUsecase) GetTodoById(id string) (domain_todo.Todo, error) {
+func (u *TodoUsecase) GetTodoById(principal *domain_auth.Principal, id string) (domain_todo.Todo, error) {
 	u.Logger.InfoLog.Println("GetTodoById called")
 
 	// バリデーション
@@ -62,10 +342,9 @@ func (u *TodoUsecase) GetTodoById(id string) (domain_todo.Todo, error) {
 		return domain_todo.Todo{}, errors.New("id is empty")
 	}
 
-	// Todoリポジトリから指定されたidのTodoを取得(repository層)
-	todo, err := u.todoRepository.GetTodoById(id)
+	// 所有者を確認してTodoを取得
+	todo, err := u.getOwnedTodo(principal, id)
 	if err != nil {
-		u.Logger.ErrorLog.Printf("Failed to get todo by id: %v", err)
 		return domain_todo.Todo{}, err
 	}
 
@@ -73,31 +352,90 @@ func (u *TodoUsecase) GetTodoById(id string) (domain_todo.Todo, error) {
 	return todo, nil
 }
 
-// 特定のユーザーのTodoを取得
-func (u *TodoUsecase) GetTodoByUserId(userId string) ([]domain_todo.Todo, error) {
+// idを指定してTodoとそのサブタスクを階層で取得
+func (u *TodoUsecase) GetTodoTree(principal *domain_auth.Principal, id string) (domain_todo.TodoTree, error) {
+	u.Logger.InfoLog.Println("GetTodoTree called")
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return domain_todo.TodoTree{}, errors.New("id is empty")
+	}
+
+	// 所有者を確認
+	if _, err := u.getOwnedTodo(principal, id); err != nil {
+		return domain_todo.TodoTree{}, err
+	}
+
+	// TodoリポジトリからTodoとその子孫を取得(repository層)
+	todos, err := u.todoRepository.GetTodoTree(id)
+	if err != nil {
+		if errors.Is(err, repository_todo.ErrTodoNotFound) {
+			u.Logger.ErrorLog.Printf("Todo not found: %s", id)
+			return domain_todo.TodoTree{}, errors.New("todo not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get todo tree: %v", err)
+		return domain_todo.TodoTree{}, err
+	}
+
+	tree, ok := domain_todo.BuildTodoTree(id, todos)
+	if !ok {
+		// 確認後に削除された場合
+		u.Logger.ErrorLog.Printf("Todo not found: %s", id)
+		return domain_todo.TodoTree{}, errors.New("todo not found")
+	}
+
+	u.Logger.InfoLog.Printf("Fetched todo tree: %d todos", len(todos))
+	return tree, nil
+}
+
+// 特定のユーザーのTodoをページ単位で取得
+func (u *TodoUsecase) GetTodoByUserId(principal *domain_auth.Principal, userId string, pageSize int32, pageToken string) ([]domain_todo.Todo, string, error) {
 	u.Logger.InfoLog.Println("GetTodoByUserId called")
 
 	// バリデーション
 	if userId == "" {
 		u.Logger.ErrorLog.Println("user_id is empty")
-		return nil, errors.New("user_id is empty")
+		return nil, "", errors.New("user_id is empty")
+	}
+	if err := u.authorizeUser(principal, userId); err != nil {
+		return nil, "", err
+	}
+	scope := todoPageScopeUser + userId
+	page, err := u.todoPageQuery(pageSize, pageToken, scope, domain_todo.DefaultTodoOrder)
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Todoリポジトリから特定のユーザーのTodoを取得(repository層)
-	todos, err := u.todoRepository.GetTodoByUserId(userId)
+	todos, err := u.todoRepository.GetTodoByUserId(userId, page)
 	if err != nil {
 		u.Logger.ErrorLog.Printf("Failed to get todo by user_id: %v", err)
-		return nil, err
+		return nil, "", err
+	}
+
+	todos, nextPageToken, err := u.nextTodoPage(todos, page, scope)
+	if err != nil {
+		return nil, "", err
 	}
 
 	u.Logger.InfoLog.Printf("Fetched %d todos", len(todos))
-	return todos, nil
+	return todos, nextPageToken, nil
 }
 
 // 新しいTodoを作成
-func (u *TodoUsecase) CreateTodo(todo domain_todo.Todo) (domain_todo.Todo, error) {
+// user_idが未指定の場合は実行者のTodoとして作成する。
+func (u *TodoUsecase) CreateTodo(principal *domain_auth.Principal, todo domain_todo.Todo) (domain_todo.Todo, error) {
 	u.Logger.InfoLog.Println("CreateTodo called")
 
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return domain_todo.Todo{}, errors.New("unauthenticated")
+	}
+	if todo.UserId == "" {
+		todo.UserId = principal.UserID
+	}
+
 	// バリデーション
 	if todo.Description == "" {
 		u.Logger.ErrorLog.Println("description is empty")
@@ -107,10 +445,32 @@ func (u *TodoUsecase) CreateTodo(todo domain_todo.Todo) (domain_todo.Todo, error
 		u.Logger.ErrorLog.Println("user_id is empty")
 		return domain_todo.Todo{}, errors.New("user_id is empty")
 	}
+	if err := u.validatePriority(todo.Priority); err != nil {
+		return domain_todo.Todo{}, err
+	}
+	tags, err := u.uniqueTags(todo.Tags)
+	if err != nil {
+		return domain_todo.Todo{}, err
+	}
+	todo.Tags = tags
+	if err := u.authorizeUser(principal, todo.UserId); err != nil {
+		return domain_todo.Todo{}, err
+	}
 
 	// Todoリポジトリから新しいTodoを作成(repository層)
-	createdTodo, err := u.todoRepository.CreateTodo(todo)
+	createdTodo, err := u.todoRepository.CreateTodo(todo, u.parentCompletion)
 	if err != nil {
+		if treeErr := u.todoTreeError(err, todo.ID); treeErr != nil {
+			return domain_todo.Todo{}, treeErr
+		}
+		if errors.Is(err, repository_todo.ErrTodoOwnerNotFound) {
+			u.Logger.ErrorLog.Printf("User not found: %s", todo.UserId)
+			return domain_todo.Todo{}, errors.New("user not found")
+		}
+		if errors.Is(err, repository_todo.ErrTagNotFound) {
+			u.Logger.ErrorLog.Printf("Tag not found: %v", domain_todo.TagIDs(todo.Tags))
+			return domain_todo.Todo{}, errors.New("tag not found")
+		}
 		u.Logger.ErrorLog.Printf("Failed to create todo: %v", err)
 		return domain_todo.Todo{}, err
 	}
@@ -120,7 +480,8 @@ func (u *TodoUsecase) CreateTodo(todo domain_todo.Todo) (domain_todo.Todo, error
 }
 
 // Todoを更新
-func (u *TodoUsecase) UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo, error) {
+// user_idが未指定の場合は所有者を変更しない。
+func (u *TodoUsecase) UpdateTodo(principal *domain_auth.Principal, todo domain_todo.Todo) (domain_todo.Todo, error) {
 	u.Logger.InfoLog.Println("UpdateTodo called")
 
 	// バリデーション
@@ -132,14 +493,59 @@ func (u *TodoUsecase) UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo, error
 		u.Logger.ErrorLog.Println("description is empty")
 		return domain_todo.Todo{}, errors.New("description is empty")
 	}
+	if todo.Version <= 0 {
+		u.Logger.ErrorLog.Println("version is empty")
+		return domain_todo.Todo{}, errors.New("version is empty")
+	}
+	if err := u.validatePriority(todo.Priority); err != nil {
+		return domain_todo.Todo{}, err
+	}
+	tags, err := u.uniqueTags(todo.Tags)
+	if err != nil {
+		return domain_todo.Todo{}, err
+	}
+	todo.Tags = tags
+	if todo.ParentID != nil && *todo.ParentID == todo.ID {
+		u.Logger.ErrorLog.Printf("Todo parent cycle: %s", todo.ID)
+		return domain_todo.Todo{}, errors.New("parent_id creates a cycle")
+	}
+
+	// 所有者を確認
+	current, err := u.getOwnedTodo(principal, todo.ID)
+	if err != nil {
+		return domain_todo.Todo{}, err
+	}
 	if todo.UserId == "" {
-		u.Logger.ErrorLog.Println("user_id is empty")
-		return domain_todo.Todo{}, errors.New("user_id is empty")
+		todo.UserId = current.UserId
+	}
+	// 他のユーザーへの付け替えは管理者のみ
+	if todo.UserId != current.UserId {
+		if err := u.authorizeUser(principal, todo.UserId); err != nil {
+			return domain_todo.Todo{}, err
+		}
 	}
 
 	// Todoリポジトリから指定されたidのTodoを更新(repository層)
-	updatedTodo, err := u.todoRepository.UpdateTodo(todo)
+	updatedTodo, err := u.todoRepository.UpdateTodo(todo, u.parentCompletion)
 	if err != nil {
+		if treeErr := u.todoTreeError(err, todo.ID); treeErr != nil {
+			return domain_todo.Todo{}, treeErr
+		}
+		if errors.Is(err, repository_todo.ErrTodoVersionConflict) {
+			return domain_todo.Todo{}, u.versionConflict(todo.ID)
+		}
+		if errors.Is(err, repository_todo.ErrTodoNotFound) {
+			u.Logger.ErrorLog.Printf("Todo not found: %s", todo.ID)
+			return domain_todo.Todo{}, errors.New("todo not found")
+		}
+		if errors.Is(err, repository_todo.ErrTodoOwnerNotFound) {
+			u.Logger.ErrorLog.Printf("User not found: %s", todo.UserId)
+			return domain_todo.Todo{}, errors.New("user not found")
+		}
+		if errors.Is(err, repository_todo.ErrTagNotFound) {
+			u.Logger.ErrorLog.Printf("Tag not found: %v", domain_todo.TagIDs(todo.Tags))
+			return domain_todo.Todo{}, errors.New("tag not found")
+		}
 		u.Logger.ErrorLog.Printf("Failed to update todo: %v", err)
 		return domain_todo.Todo{}, err
 	}
@@ -148,8 +554,101 @@ func (u *TodoUsecase) UpdateTodo(todo domain_todo.Todo) (domain_todo.Todo, error
 	return updatedTodo, nil
 }
 
+// Todoの指定した項目のみを更新
+// updateMaskに含まれる項目のみtodoの値で更新する。作成日時は変更できず、更新日時はサーバーで設定する。
+func (u *TodoUsecase) PatchTodo(principal *domain_auth.Principal, todo domain_todo.Todo, updateMask []string) (domain_todo.Todo, error) {
+	u.Logger.InfoLog.Println("PatchTodo called")
+
+	// バリデーション
+	if todo.ID == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return domain_todo.Todo{}, errors.New("id is empty")
+	}
+	if todo.Version <= 0 {
+		u.Logger.ErrorLog.Println("version is empty")
+		return domain_todo.Todo{}, errors.New("version is empty")
+	}
+	patch, err := domain_todo.NewTodoPatch(updateMask, todo)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain_todo.ErrEmptyTodoUpdateMask):
+			u.Logger.ErrorLog.Println("update_mask is empty")
+			return domain_todo.Todo{}, errors.New("update_mask is empty")
+		case errors.Is(err, domain_todo.ErrImmutableTodoField):
+			u.Logger.ErrorLog.Printf("Immutable field in update_mask: %v", updateMask)
+			return domain_todo.Todo{}, errors.New("immutable field in update_mask")
+		default:
+			u.Logger.ErrorLog.Printf("Invalid update_mask: %v", updateMask)
+			return domain_todo.Todo{}, errors.New("invalid update_mask")
+		}
+	}
+	if patch.Description != nil && *patch.Description == "" {
+		u.Logger.ErrorLog.Println("description is empty")
+		return domain_todo.Todo{}, errors.New("description is empty")
+	}
+	if patch.UserID != nil && *patch.UserID == "" {
+		u.Logger.ErrorLog.Println("user_id is empty")
+		return domain_todo.Todo{}, errors.New("user_id is empty")
+	}
+	if patch.Priority != nil {
+		if err := u.validatePriority(*patch.Priority); err != nil {
+			return domain_todo.Todo{}, err
+		}
+	}
+	if patch.UpdateTags {
+		patch.TagIDs, err = u.uniqueTagIDs(patch.TagIDs)
+		if err != nil {
+			return domain_todo.Todo{}, err
+		}
+	}
+	if patch.UpdateParent && patch.ParentID != nil && *patch.ParentID == todo.ID {
+		u.Logger.ErrorLog.Printf("Todo parent cycle: %s", todo.ID)
+		return domain_todo.Todo{}, errors.New("parent_id creates a cycle")
+	}
+
+	// 所有者を確認
+	current, err := u.getOwnedTodo(principal, todo.ID)
+	if err != nil {
+		return domain_todo.Todo{}, err
+	}
+	// 他のユーザーへの付け替えは管理者のみ
+	if patch.UserID != nil && *patch.UserID != current.UserId {
+		if err := u.authorizeUser(principal, *patch.UserID); err != nil {
+			return domain_todo.Todo{}, err
+		}
+	}
+
+	// Todoリポジトリから指定されたidのTodoの項目を更新(repository層)
+	patchedTodo, err := u.todoRepository.PatchTodo(todo.ID, todo.Version, patch, u.parentCompletion)
+	if err != nil {
+		if treeErr := u.todoTreeError(err, todo.ID); treeErr != nil {
+			return domain_todo.Todo{}, treeErr
+		}
+		if errors.Is(err, repository_todo.ErrTodoVersionConflict) {
+			return domain_todo.Todo{}, u.versionConflict(todo.ID)
+		}
+		if errors.Is(err, repository_todo.ErrTodoNotFound) {
+			u.Logger.ErrorLog.Printf("Todo not found: %s", todo.ID)
+			return domain_todo.Todo{}, errors.New("todo not found")
+		}
+		if errors.Is(err, repository_todo.ErrTodoOwnerNotFound) {
+			u.Logger.ErrorLog.Printf("User not found for todo: %s", todo.ID)
+			return domain_todo.Todo{}, errors.New("user not found")
+		}
+		if errors.Is(err, repository_todo.ErrTagNotFound) {
+			u.Logger.ErrorLog.Printf("Tag not found: %v", patch.TagIDs)
+			return domain_todo.Todo{}, errors.New("tag not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to patch todo: %v", err)
+		return domain_todo.Todo{}, err
+	}
+
+	u.Logger.InfoLog.Printf("Patched todo: %v", patchedTodo)
+	return patchedTodo, nil
+}
+
 // Todoを削除
-func (u *TodoUsecase) DeleteTodo(id string) error {
+func (u *TodoUsecase) DeleteTodo(principal *domain_auth.Principal, id string, version int64) error {
 	u.Logger.InfoLog.Println("DeleteTodo called")
 
 	// バリデーション
@@ -157,10 +656,26 @@ func (u *TodoUsecase) DeleteTodo(id string) error {
 		u.Logger.ErrorLog.Println("id is empty")
 		return errors.New("id is empty")
 	}
+	if version <= 0 {
+		u.Logger.ErrorLog.Println("version is empty")
+		return errors.New("version is empty")
+	}
+
+	// 所有者を確認
+	if _, err := u.getOwnedTodo(principal, id); err != nil {
+		return err
+	}
 
 	// Todoリポジトリから指定されたidのTodoを削除(repository層)
-	err := u.todoRepository.DeleteTodo(id)
+	err := u.todoRepository.DeleteTodo(id, version, u.parentCompletion)
 	if err != nil {
+		if errors.Is(err, repository_todo.ErrTodoVersionConflict) {
+			return u.versionConflict(id)
+		}
+		if errors.Is(err, repository_todo.ErrTodoNotFound) {
+			u.Logger.ErrorLog.Printf("Todo not found: %s", id)
+			return errors.New("todo not found")
+		}
 		u.Logger.ErrorLog.Printf("Failed to delete todo: %v", err)
 		return err
 	}
@@ -168,3 +683,157 @@ func (u *TodoUsecase) DeleteTodo(id string) error {
 	u.Logger.InfoLog.Printf("Deleted todo: %v", id)
 	return nil
 }
+
+// Todoの階層に関するリポジトリのエラーをユースケースのエラーに変換(該当しない場合はnil)
+func (u *TodoUsecase) todoTreeError(err error, id string) error {
+	switch {
+	case errors.Is(err, repository_todo.ErrTodoParentNotFound):
+		u.Logger.ErrorLog.Printf("Parent todo not found: %s", id)
+		return errors.New("parent not found")
+	case errors.Is(err, repository_todo.ErrTodoParentCycle):
+		u.Logger.ErrorLog.Printf("Todo parent cycle: %s", id)
+		return errors.New("parent_id creates a cycle")
+	case errors.Is(err, repository_todo.ErrTodoTreeTooDeep):
+		u.Logger.ErrorLog.Printf("Todo tree too deep: %s", id)
+		return errors.New("subtask depth limit exceeded")
+	case errors.Is(err, repository_todo.ErrTodoHasOpenSubtasks):
+		u.Logger.ErrorLog.Printf("Todo has open subtasks: %s", id)
+		return errors.New("todo has open subtasks")
+	case errors.Is(err, repository_todo.ErrTodoParentCompleted):
+		u.Logger.ErrorLog.Printf("Parent todo is completed: %s", id)
+		return errors.New("parent is completed")
+	case errors.Is(err, repository_todo.ErrTodoSubtaskOwnerMismatch):
+		u.Logger.ErrorLog.Printf("Todo has subtasks: %s", id)
+		return errors.New("cannot change owner of todo with subtasks")
+	default:
+		return nil
+	}
+}
+
+// 優先度を検証
+func (u *TodoUsecase) validatePriority(priority int32) error {
+	if !domain_todo.IsValidPriority(priority) {
+		u.Logger.ErrorLog.Printf("Invalid priority: %d", priority)
+		return errors.New("invalid priority")
+	}
+	return nil
+}
+
+// タグのIDを検証し、重複を除く(順序は最初に現れた順)
+func (u *TodoUsecase) uniqueTagIDs(tagIDs []string) ([]string, error) {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, id := range tagIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			u.Logger.ErrorLog.Println("tag_id is empty")
+			return nil, errors.New("tag_id is empty")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) > domain_todo.MaxTagsPerTodo {
+		u.Logger.ErrorLog.Printf("Too many tags: %d", len(unique))
+		return nil, errors.New("too many tags")
+	}
+	return unique, nil
+}
+
+// Todoに付けるタグを検証し、重複を除く(IDのみ使用する)
+func (u *TodoUsecase) uniqueTags(tags []domain_todo.Tag) ([]domain_todo.Tag, error) {
+	tagIDs, err := u.uniqueTagIDs(domain_todo.TagIDs(tags))
+	if err != nil {
+		return nil, err
+	}
+	unique := make([]domain_todo.Tag, len(tagIDs))
+	for i, id := range tagIDs {
+		unique[i] = domain_todo.Tag{ID: id}
+	}
+	return unique, nil
+}
+
+// バージョンが一致しない場合のエラーを作成
+// クライアントがマージできるよう、サーバーの現在のTodoを取得してエラーに含める。
+func (u *TodoUsecase) versionConflict(id string) error {
+	u.Logger.ErrorLog.Printf("Todo version conflict: %s", id)
+
+	// Todoリポジトリから現在のTodoを取得(repository層)
+	current, err := u.todoRepository.GetTodoById(id)
+	if err != nil {
+		if errors.Is(err, repository_todo.ErrTodoNotFound) {
+			// 確認後に削除された場合
+			u.Logger.ErrorLog.Printf("Todo not found: %s", id)
+			return errors.New("todo not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get todo by id: %v", err)
+		return err
+	}
+	return &domain_todo.VersionConflictError{Current: current}
+}
+
+// 実行者が所有するTodoを取得
+// 他のユーザーのTodoは存在を推測されないよう、存在しない場合と同じエラーを返す。
+func (u *TodoUsecase) getOwnedTodo(principal *domain_auth.Principal, id string) (domain_todo.Todo, error) {
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return domain_todo.Todo{}, errors.New("unauthenticated")
+	}
+
+	// Todoリポジトリから指定されたidのTodoを取得(repository層)
+	todo, err := u.todoRepository.GetTodoById(id)
+	if err != nil {
+		if errors.Is(err, repository_todo.ErrTodoNotFound) {
+			u.Logger.ErrorLog.Printf("Todo not found: %s", id)
+			return domain_todo.Todo{}, errors.New("todo not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get todo by id: %v", err)
+		return domain_todo.Todo{}, err
+	}
+
+	if !principal.IsUser(todo.UserId) && !principal.HasPermission(domain_auth.PermissionTodoAdmin) {
+		u.Logger.ErrorLog.Printf("Todo %s is not owned by user: %s", id, principal.UserID)
+		return domain_todo.Todo{}, errors.New("todo not found")
+	}
+
+	return todo, nil
+}
+
+// 一覧・検索の対象とする所有者を決定
+// todo:admin権限がない場合は自分のみを対象とし(userIdが未指定なら自分、他のユーザーならpermission denied)、
+// ユーザーに紐づかない主体(サービスアカウント)は所有するTodoがないため、permission deniedを返す。
+func (u *TodoUsecase) ownerScope(principal *domain_auth.Principal, userId string) (string, error) {
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return "", errors.New("unauthenticated")
+	}
+	if principal.HasPermission(domain_auth.PermissionTodoAdmin) {
+		return userId, nil
+	}
+	if principal.UserID == "" {
+		u.Logger.ErrorLog.Printf("Service account %s cannot list todos without todo:admin", principal.ServiceAccountID)
+		return "", errors.New("permission denied")
+	}
+	if userId == "" {
+		userId = principal.UserID
+	}
+	if err := u.authorizeUser(principal, userId); err != nil {
+		return "", err
+	}
+	return userId, nil
+}
+
+// 指定したユーザーのTodoを操作できるか確認
+func (u *TodoUsecase) authorizeUser(principal *domain_auth.Principal, userId string) error {
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return errors.New("unauthenticated")
+	}
+	if !principal.IsUser(userId) && !principal.HasPermission(domain_auth.PermissionTodoAdmin) {
+		u.Logger.ErrorLog.Printf("User %s cannot access todos of user: %s", principal.UserID, userId)
+		return errors.New("permission denied")
+	}
+	return nil
+}
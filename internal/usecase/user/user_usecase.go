@@ -1,28 +1,63 @@
 package usecase_user
 
 import (
+	domain_auth "backend/internal/domain/auth"
 	domain_user "backend/internal/domain/user"
 	pkg_logger "backend/internal/pkg/logger"
+	pkg_pagination "backend/internal/pkg/pagination"
 	repository_user "backend/internal/repository/user"
+	"errors"
+	"strings"
 )
 
+// ユーザー検索文字列の最大文字数
+const maxUserSearchLength = 100
+
+// ユーザー一覧のページトークン
+// 検索条件・並び順が前のページと異なる場合はトークンを無効とする。
+type userPageToken struct {
+	domain_user.UserCursor
+	Search string `json:"q"`
+	Order  string `json:"o"`
+}
+
 // ユーザーユースケース(IF)
 type IUserUsecase interface {
 	// 全てのユーザーを取得
 	GetAllUsers() ([]domain_user.Users, error)
+	// ユーザー一覧をページ単位で取得(次ページのトークンを返す)
+	ListUsers(principal *domain_auth.Principal, search string, orderBy string, pageSize int32, pageToken string) ([]domain_user.Users, string, error)
+	// idを指定してユーザーを取得(本人またはuser:list権限が必要)
+	GetUserById(principal *domain_auth.Principal, id string) (domain_user.Users, error)
+	// 実行者自身のユーザーを取得
+	GetMe(principal *domain_auth.Principal) (domain_user.Users, error)
+	// ユーザー名・メールアドレスを更新(本人またはuser:manage権限が必要)
+	UpdateProfile(principal *domain_auth.Principal, id string, username string, email string) (domain_user.Users, error)
+	// ユーザーを無効化(本人またはuser:manage権限が必要)
+	DeleteUser(principal *domain_auth.Principal, id string) error
+	// 無効化したユーザーを再有効化(user:manage権限が必要)
+	ReactivateUser(principal *domain_auth.Principal, id string) error
+	// 無効化済みのユーザーを完全に削除(管理者のみ)
+	PurgeUser(principal *domain_auth.Principal, id string, todoPolicy string, reassignTo string) (domain_user.PurgeResult, error)
 }
 
 // ユーザーユースケース(Impl)
 type UserUsecase struct {
 	Logger         *pkg_logger.AppLogger
 	userRepository repository_user.IUserRepository
+	// パージ時のTodoの扱いのデフォルト
+	defaultTodoPolicy domain_user.TodoCascadePolicy
+	// デフォルトの扱いがreassignの場合の付け替え先のユーザーID
+	defaultReassignTo string
 }
 
 // ユーザーユースケースのインスタンス化
-func NewUserUsecase(l *pkg_logger.AppLogger, u repository_user.IUserRepository) IUserUsecase {
+func NewUserUsecase(l *pkg_logger.AppLogger, u repository_user.IUserRepository, defaultTodoPolicy domain_user.TodoCascadePolicy, defaultReassignTo string) IUserUsecase {
 	return &UserUsecase{
-		Logger:         l,
-		userRepository: u,
+		Logger:            l,
+		userRepository:    u,
+		defaultTodoPolicy: defaultTodoPolicy,
+		defaultReassignTo: defaultReassignTo,
 	}
 }
 
@@ -40,3 +75,349 @@ func (u *UserUsecase) GetAllUsers() ([]domain_user.Users, error) {
 	u.Logger.InfoLog.Printf("Fetched %d users", len(users))
 	return users, nil
 }
+
+// ユーザー一覧をページ単位で取得
+// 作成日時の順に並べ、次のページがある場合はページトークンを返す。
+// adminロールのみ呼び出せる(auth_policyで制限する)ため、メールアドレスを含めて返し、メールアドレスでも検索する。
+func (u *UserUsecase) ListUsers(principal *domain_auth.Principal, search string, orderBy string, pageSize int32, pageToken string) ([]domain_user.Users, string, error) {
+	u.Logger.InfoLog.Println("ListUsers called")
+
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return nil, "", errors.New("unauthenticated")
+	}
+
+	// バリデーション
+	search = strings.TrimSpace(search)
+	if len([]rune(search)) > maxUserSearchLength {
+		u.Logger.ErrorLog.Println("Search query is too long")
+		return nil, "", errors.New("query is too long")
+	}
+	order, ok := domain_user.NormalizeUserOrder(strings.ToLower(strings.TrimSpace(orderBy)))
+	if !ok {
+		u.Logger.ErrorLog.Printf("Invalid order_by: %s", orderBy)
+		return nil, "", errors.New("invalid order_by")
+	}
+	limit, err := pkg_pagination.PageSize(pageSize)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Invalid page_size: %d", pageSize)
+		return nil, "", errors.New("invalid page_size")
+	}
+
+	query := domain_user.ListUsersQuery{
+		Search:     search,
+		Descending: order == domain_user.UserOrderCreatedAtDesc,
+		// 次のページの有無を判定するため1件多く取得する
+		Limit: limit + 1,
+	}
+	if pageToken != "" {
+		var token userPageToken
+		if err := pkg_pagination.DecodeToken(pageToken, &token); err != nil || !pkg_pagination.IsValidID(token.ID) ||
+			token.Search != search || token.Order != order {
+			u.Logger.ErrorLog.Println("Invalid page token")
+			return nil, "", errors.New("invalid page_token")
+		}
+		query.After = &token.UserCursor
+	}
+
+	// ユーザーリポジトリからユーザーを取得(repository層)
+	users, err := u.userRepository.ListUsers(query)
+	if err != nil {
+		u.Logger.ErrorLog.Printf("Failed to list users: %v", err)
+		return nil, "", err
+	}
+
+	// 次のページのトークンを作成
+	nextPageToken := ""
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[len(users)-1]
+		nextPageToken, err = pkg_pagination.EncodeToken(userPageToken{
+			UserCursor: domain_user.UserCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+			Search:     search,
+			Order:      order,
+		})
+		if err != nil {
+			u.Logger.ErrorLog.Printf("Failed to encode page token: %v", err)
+			return nil, "", err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Fetched %d users", len(users))
+	return users, nextPageToken, nil
+}
+
+// idを指定してユーザーを取得
+// 権限がない場合は存在を推測されないよう、存在しない場合と同じエラーを返す。
+func (u *UserUsecase) GetUserById(principal *domain_auth.Principal, id string) (domain_user.Users, error) {
+	u.Logger.InfoLog.Println("GetUserById called")
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return domain_user.Users{}, errors.New("id is empty")
+	}
+	if err := u.authorizeUser(principal, id, domain_auth.PermissionUserList); err != nil {
+		return domain_user.Users{}, err
+	}
+
+	// ユーザーリポジトリからユーザーを取得(repository層)
+	user, err := u.userRepository.GetUserById(id)
+	if err != nil {
+		if errors.Is(err, repository_user.ErrUserNotFound) {
+			u.Logger.ErrorLog.Printf("User not found: %s", id)
+			return domain_user.Users{}, errors.New("user not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user by id: %v", err)
+		return domain_user.Users{}, err
+	}
+
+	u.Logger.InfoLog.Printf("Fetched user: %s", user.ID)
+	return user, nil
+}
+
+// 実行者自身のユーザーを取得
+func (u *UserUsecase) GetMe(principal *domain_auth.Principal) (domain_user.Users, error) {
+	u.Logger.InfoLog.Println("GetMe called")
+
+	// ユーザーに紐づかない主体(サービスアカウント)は対象外
+	if principal == nil || principal.UserID == "" {
+		u.Logger.ErrorLog.Println("principal is not a user")
+		return domain_user.Users{}, errors.New("unauthenticated")
+	}
+
+	return u.GetUserById(principal, principal.UserID)
+}
+
+// ユーザー名・メールアドレスを更新
+// 空の項目は変更しない。メールアドレスを変更した場合は確認状態が未確認に戻る。
+func (u *UserUsecase) UpdateProfile(principal *domain_auth.Principal, id string, username string, email string) (domain_user.Users, error) {
+	u.Logger.InfoLog.Println("UpdateProfile called")
+
+	email = domain_user.NormalizeEmail(email)
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return domain_user.Users{}, errors.New("id is empty")
+	}
+	if username == "" && email == "" {
+		u.Logger.ErrorLog.Println("Nothing to update")
+		return domain_user.Users{}, errors.New("username or email is required")
+	}
+	if username != "" && !domain_user.IsValidUsername(username) {
+		u.Logger.ErrorLog.Println("Invalid username format")
+		return domain_user.Users{}, errors.New("invalid username format")
+	}
+	// Emailの形式チェック
+	if email != "" && !domain_user.IsValidEmail(email) {
+		u.Logger.ErrorLog.Println("Invalid email format")
+		return domain_user.Users{}, errors.New("invalid email format")
+	}
+	if err := u.authorizeUser(principal, id, domain_auth.PermissionUserManage); err != nil {
+		return domain_user.Users{}, err
+	}
+
+	// ユーザーリポジトリから現在のユーザーを取得(repository層)
+	user, err := u.userRepository.GetUserById(id)
+	if err != nil {
+		if errors.Is(err, repository_user.ErrUserNotFound) {
+			u.Logger.ErrorLog.Printf("User not found: %s", id)
+			return domain_user.Users{}, errors.New("user not found")
+		}
+		u.Logger.ErrorLog.Printf("Failed to get user by id: %v", err)
+		return domain_user.Users{}, err
+	}
+	if username != "" {
+		user.Username = username
+	}
+	if email != "" {
+		user.Email = email
+	}
+
+	// ユーザーリポジトリからユーザーを更新(repository層)
+	updated, err := u.userRepository.UpdateUser(user)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_user.ErrUserNotFound):
+			u.Logger.ErrorLog.Printf("User not found: %s", id)
+			return domain_user.Users{}, errors.New("user not found")
+		case errors.Is(err, repository_user.ErrUserAlreadyExists):
+			u.Logger.ErrorLog.Println("User already exists")
+			return domain_user.Users{}, errors.New("user already exists")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to update user: %v", err)
+			return domain_user.Users{}, err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Updated user: %s", updated.ID)
+	return updated, nil
+}
+
+// ユーザーを無効化(論理削除)
+// 無効化したユーザーはログインできず、発行済みのセッションも失効する。データの完全な削除はPurgeUserで行う。
+func (u *UserUsecase) DeleteUser(principal *domain_auth.Principal, id string) error {
+	u.Logger.InfoLog.Println("DeleteUser called")
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return errors.New("id is empty")
+	}
+	if err := u.authorizeUser(principal, id, domain_auth.PermissionUserManage); err != nil {
+		return err
+	}
+
+	// ユーザーリポジトリからユーザーを無効化(repository層)
+	err := u.userRepository.DeactivateUser(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_user.ErrUserNotFound):
+			u.Logger.ErrorLog.Printf("User not found: %s", id)
+			return errors.New("user not found")
+		case errors.Is(err, repository_user.ErrUserAlreadyDeactivated):
+			u.Logger.ErrorLog.Printf("User already deactivated: %s", id)
+			return errors.New("user already deactivated")
+		case errors.Is(err, repository_user.ErrLastAdmin):
+			u.Logger.ErrorLog.Printf("Cannot deactivate the last admin: %s", id)
+			return errors.New("cannot remove the last admin")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to deactivate user: %v", err)
+			return err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Deactivated user: %s", id)
+	return nil
+}
+
+// 無効化したユーザーを再有効化
+func (u *UserUsecase) ReactivateUser(principal *domain_auth.Principal, id string) error {
+	u.Logger.InfoLog.Println("ReactivateUser called")
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return errors.New("id is empty")
+	}
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return errors.New("unauthenticated")
+	}
+	if !principal.HasPermission(domain_auth.PermissionUserManage) {
+		u.Logger.ErrorLog.Printf("User %s cannot reactivate user: %s", principal.UserID, id)
+		return errors.New("permission denied")
+	}
+
+	// ユーザーリポジトリからユーザーを再有効化(repository層)
+	err := u.userRepository.ReactivateUser(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_user.ErrUserNotFound):
+			u.Logger.ErrorLog.Printf("User not found: %s", id)
+			return errors.New("user not found")
+		case errors.Is(err, repository_user.ErrUserNotDeactivated):
+			u.Logger.ErrorLog.Printf("User is not deactivated: %s", id)
+			return errors.New("user is not deactivated")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to reactivate user: %v", err)
+			return err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Reactivated user: %s", id)
+	return nil
+}
+
+// 無効化済みのユーザーを完全に削除
+// todoPolicyが空の場合は設定のデフォルトを使用する。削除は監査ログに記録される。
+func (u *UserUsecase) PurgeUser(principal *domain_auth.Principal, id string, todoPolicy string, reassignTo string) (domain_user.PurgeResult, error) {
+	u.Logger.InfoLog.Println("PurgeUser called")
+
+	// バリデーション
+	if id == "" {
+		u.Logger.ErrorLog.Println("id is empty")
+		return domain_user.PurgeResult{}, errors.New("id is empty")
+	}
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return domain_user.PurgeResult{}, errors.New("unauthenticated")
+	}
+	if !principal.HasRole(domain_auth.RoleAdmin) || !principal.HasPermission(domain_auth.PermissionUserManage) {
+		u.Logger.ErrorLog.Printf("User %s cannot purge user: %s", principal.UserID, id)
+		return domain_user.PurgeResult{}, errors.New("permission denied")
+	}
+	if principal.IsUser(id) {
+		u.Logger.ErrorLog.Printf("User %s tried to purge itself", id)
+		return domain_user.PurgeResult{}, errors.New("cannot purge yourself")
+	}
+
+	// Todoの扱いを決定
+	policy := u.defaultTodoPolicy
+	if todoPolicy != "" {
+		p, ok := domain_user.ParseTodoCascadePolicy(strings.ToLower(strings.TrimSpace(todoPolicy)))
+		if !ok {
+			u.Logger.ErrorLog.Printf("Invalid todo_policy: %s", todoPolicy)
+			return domain_user.PurgeResult{}, errors.New("invalid todo_policy")
+		}
+		policy = p
+	}
+	if policy == domain_user.TodoCascadeReassign {
+		if reassignTo == "" && todoPolicy == "" {
+			reassignTo = u.defaultReassignTo
+		}
+		if reassignTo == "" {
+			u.Logger.ErrorLog.Println("reassign_to is empty")
+			return domain_user.PurgeResult{}, errors.New("reassign_to is required")
+		}
+		if reassignTo == id {
+			u.Logger.ErrorLog.Println("reassign_to is the purged user")
+			return domain_user.PurgeResult{}, errors.New("reassign target not found")
+		}
+	} else {
+		reassignTo = ""
+	}
+
+	// ユーザーリポジトリからユーザーを完全に削除(repository層)
+	result, err := u.userRepository.PurgeUser(id, policy, reassignTo, principal.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository_user.ErrUserNotFound):
+			u.Logger.ErrorLog.Printf("User not found: %s", id)
+			return domain_user.PurgeResult{}, errors.New("user not found")
+		case errors.Is(err, repository_user.ErrUserNotDeactivated):
+			u.Logger.ErrorLog.Printf("User is not deactivated: %s", id)
+			return domain_user.PurgeResult{}, errors.New("user is not deactivated")
+		case errors.Is(err, repository_user.ErrReassignTargetNotFound):
+			u.Logger.ErrorLog.Printf("Reassign target not found: %s", reassignTo)
+			return domain_user.PurgeResult{}, errors.New("reassign target not found")
+		case errors.Is(err, repository_user.ErrUserInUse):
+			u.Logger.ErrorLog.Printf("User is in use: %s", id)
+			return domain_user.PurgeResult{}, errors.New("user is in use")
+		case errors.Is(err, repository_user.ErrLastAdmin):
+			u.Logger.ErrorLog.Printf("Cannot purge the last admin: %s", id)
+			return domain_user.PurgeResult{}, errors.New("cannot remove the last admin")
+		default:
+			u.Logger.ErrorLog.Printf("Failed to purge user: %v", err)
+			return domain_user.PurgeResult{}, err
+		}
+	}
+
+	u.Logger.InfoLog.Printf("Purged user: %s (todo policy: %s, todos: %d)", id, policy, result.TodosAffected)
+	return result, nil
+}
+
+// 指定したユーザーを操作できるか確認
+// 本人以外はpermissionが必要。権限がない場合は存在を推測されないよう"user not found"を返す。
+func (u *UserUsecase) authorizeUser(principal *domain_auth.Principal, id string, permission string) error {
+	if principal == nil {
+		u.Logger.ErrorLog.Println("principal is nil")
+		return errors.New("unauthenticated")
+	}
+	if !principal.IsUser(id) && !principal.HasPermission(permission) {
+		u.Logger.ErrorLog.Printf("User %s cannot access user: %s", principal.UserID, id)
+		return errors.New("user not found")
+	}
+	return nil
+}
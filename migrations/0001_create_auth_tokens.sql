@@ -0,0 +1,26 @@
+-- リフレッシュトークン
+-- トークン本体は保存せず、SHA-256ハッシュのみ保存する。
+-- family_idはローテーションで発行されたトークンの系列を表し、再利用検知時に系列ごと失効させる。
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id          uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id   uuid        NOT NULL,
+    token_hash  text        NOT NULL UNIQUE,
+    expires_at  timestamptz NOT NULL,
+    revoked_at  timestamptz,
+    replaced_by uuid,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+
+-- 失効済みアクセストークン(jti)
+-- expires_atを過ぎた行はトークン自体が無効になるため削除してよい。
+CREATE TABLE IF NOT EXISTS revoked_access_tokens (
+    jti        text        PRIMARY KEY,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_access_tokens_expires_at ON revoked_access_tokens (expires_at);
@@ -0,0 +1,5 @@
+-- ユーザー名・メールアドレスの一意制約
+-- メールアドレスは大文字小文字を区別せずに一意とする。
+-- 既存データに重複がある場合は作成に失敗するため、事前に解消すること。
+CREATE UNIQUE INDEX IF NOT EXISTS uq_users_username ON users (username);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_users_email_lower ON users (lower(email));
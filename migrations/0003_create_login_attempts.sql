@@ -0,0 +1,9 @@
+-- ログイン失敗の記録
+-- attempt_keyは "email:<メールアドレス>" または "ip:<クライアントアドレス>" の形式。
+-- locked_untilまではログインを受け付けない。
+CREATE TABLE IF NOT EXISTS login_attempts (
+    attempt_key    text        PRIMARY KEY,
+    failure_count  integer     NOT NULL DEFAULT 0,
+    last_failed_at timestamptz NOT NULL DEFAULT now(),
+    locked_until   timestamptz
+);
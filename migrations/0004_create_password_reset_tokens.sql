@@ -0,0 +1,12 @@
+-- パスワード再設定トークン
+-- トークン本体は保存せず、SHA-256ハッシュのみ保存する。使用済み(used_at)または期限切れのトークンは無効。
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id         uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash text        NOT NULL UNIQUE,
+    expires_at timestamptz NOT NULL,
+    used_at    timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
@@ -0,0 +1,23 @@
+-- TOTPによる二要素認証の設定
+-- secretはアプリケーション側でAES-GCMにより暗号化した値を保存する。
+-- enabledは登録確認(ConfirmTotp)が完了するまでfalse。
+-- last_used_stepは最後に使用したコードの時間ステップで、同じコードの再利用を防ぐ。
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id        uuid        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret         text        NOT NULL,
+    enabled        boolean     NOT NULL DEFAULT false,
+    last_used_step bigint,
+    created_at     timestamptz NOT NULL DEFAULT now(),
+    updated_at     timestamptz NOT NULL DEFAULT now()
+);
+
+-- 二要素認証のリカバリーコード
+-- コード本体は保存せず、SHA-256ハッシュのみ保存する。各コードは1回のみ使用可能。
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id         uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  text        NOT NULL,
+    used_at    timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (user_id, code_hash)
+);
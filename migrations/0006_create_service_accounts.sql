@@ -0,0 +1,25 @@
+-- サービスアカウント(バッチ・外部連携などの機械クライアント)
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id          uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        text        NOT NULL UNIQUE,
+    description text        NOT NULL DEFAULT '',
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now()
+);
+
+-- サービスアカウントのAPIキー
+-- キー本体は保存せず、SHA-256ハッシュのみ保存する。prefixは一覧表示での識別用。
+-- scopesはキーに付与する権限(例: todo:read)。
+CREATE TABLE IF NOT EXISTS api_keys (
+    id                 uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_account_id uuid        NOT NULL REFERENCES service_accounts(id) ON DELETE CASCADE,
+    prefix             text        NOT NULL,
+    key_hash           text        NOT NULL UNIQUE,
+    scopes             text[]      NOT NULL DEFAULT '{}',
+    expires_at         timestamptz,
+    last_used_at       timestamptz,
+    revoked_at         timestamptz,
+    created_at         timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_service_account_id ON api_keys (service_account_id);
@@ -0,0 +1,19 @@
+-- ログインセッション
+-- ログインごとに1行作成し、アクセストークン(sidクレーム)とリフレッシュトークンを紐づける。
+-- revoked_atが設定されたセッションのトークンは全て無効。
+CREATE TABLE IF NOT EXISTS sessions (
+    id           uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent   text        NOT NULL DEFAULT '',
+    ip_address   text        NOT NULL DEFAULT '',
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    last_seen_at timestamptz NOT NULL DEFAULT now(),
+    revoked_at   timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+
+-- リフレッシュトークンをセッションに紐づける(既存のトークンはNULL)
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS session_id uuid REFERENCES sessions(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
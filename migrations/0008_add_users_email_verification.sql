@@ -0,0 +1,19 @@
+-- メールアドレスの確認状態
+-- unverified(未確認) → verification_sent(確認メール送信済み) → verified(確認済み)
+-- 列の追加前に登録済みのユーザーは確認済みとして扱う(REQUIRE_VERIFIED_EMAIL=true にしてもログインできるようにする)。
+-- 追加後に登録したユーザーは未確認から始まる。
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verification_status text NOT NULL DEFAULT 'verified';
+ALTER TABLE users ALTER COLUMN email_verification_status SET DEFAULT 'unverified';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verification_sent_at timestamptz;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at timestamptz;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'chk_users_email_verification_status'
+    ) THEN
+        ALTER TABLE users ADD CONSTRAINT chk_users_email_verification_status
+            CHECK (email_verification_status IN ('unverified', 'verification_sent', 'verified'));
+    END IF;
+END
+$$;
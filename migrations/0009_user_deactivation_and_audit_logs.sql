@@ -0,0 +1,32 @@
+-- ユーザーの無効化(論理削除)
+-- deactivated_atが設定されたユーザーはログインできない。完全削除(パージ)は無効化済みのユーザーのみ行える。
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at timestamptz;
+
+-- パージしたユーザーのTodoの保管先(todo_policy=archive)
+-- ユーザーは削除されるため、user_idに外部キーは設定しない。
+CREATE TABLE IF NOT EXISTS archived_todos (
+    id          uuid        PRIMARY KEY,
+    description text        NOT NULL,
+    completed   boolean     NOT NULL,
+    user_id     uuid        NOT NULL,
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL,
+    archived_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_todos_user_id ON archived_todos (user_id);
+
+-- 監査ログ
+-- 対象が削除されても残るよう、actor_id・target_idに外部キーは設定しない。
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id          uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id    text        NOT NULL,
+    action      text        NOT NULL,
+    target_type text        NOT NULL,
+    target_id   text        NOT NULL,
+    details     jsonb       NOT NULL DEFAULT '{}'::jsonb,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
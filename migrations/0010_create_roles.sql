@@ -0,0 +1,31 @@
+-- ロール
+-- ロールごとの権限はアプリケーション(domain_auth)で定義する。ここではユーザーに付与できるロールを管理する。
+CREATE TABLE IF NOT EXISTS roles (
+    name        text        PRIMARY KEY,
+    description text        NOT NULL DEFAULT '',
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', '管理者'),
+    ('user', '一般ユーザー')
+ON CONFLICT (name) DO NOTHING;
+
+-- ユーザーに付与したロール
+-- granted_byは付与した主体(ユーザーID、またはbootstrap・migration)。
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id    uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role       text        NOT NULL REFERENCES roles(name),
+    granted_by text        NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_role ON user_roles (role);
+
+-- 既存のユーザーには一般ユーザーのロールを付与する
+-- (これまでROLE_USERで全ユーザーに同じロールを付与していたため、管理者は make bootstrap で改めて作成する)
+INSERT INTO user_roles (user_id, role, granted_by)
+SELECT id, 'user', 'migration'
+FROM users
+ON CONFLICT (user_id, role) DO NOTHING;
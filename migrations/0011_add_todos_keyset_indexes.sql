@@ -0,0 +1,4 @@
+-- Todo一覧のキーセットページネーション用のインデックス
+-- (created_at, id)の順に並べ、前ページの最後の行より後ろを取得する。
+CREATE INDEX IF NOT EXISTS idx_todos_created_at_id ON todos (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_todos_user_id_created_at_id ON todos (user_id, created_at, id);
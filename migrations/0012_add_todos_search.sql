@@ -0,0 +1,11 @@
+-- Todoの説明の全文検索
+-- description_tsvは生成列のため、Todoの作成・更新時にPostgreSQLが自動で更新する。
+-- 分かち書きのない日本語はsimple構成では単語に分割できないため、pg_trgmのトライグラム検索を併用する。
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS description_tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', coalesce(description, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_todos_description_tsv ON todos USING GIN (description_tsv);
+CREATE INDEX IF NOT EXISTS idx_todos_description_trgm ON todos USING GIN (description gin_trgm_ops);
@@ -0,0 +1,3 @@
+-- Todoの楽観的排他制御用のバージョン
+-- 更新のたびに1ずつ増やし、更新・削除時にクライアントが取得したバージョンと一致するか確認する。
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
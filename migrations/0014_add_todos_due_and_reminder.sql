@@ -0,0 +1,15 @@
+-- Todoの期限・リマインダー
+-- 日時は全てtimestamptz(UTC)で保持し、「今日」の判定などのタイムゾーンの解釈はアプリケーションで行う。
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS due_at timestamptz;
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS remind_at timestamptz;
+-- リマインダーの送信日時(remind_atを変更した場合はNULLに戻す)
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS reminded_at timestamptz;
+-- リマインダーの送信に失敗した回数(上限に達したものは送信しない)
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS reminder_attempts integer NOT NULL DEFAULT 0;
+
+-- 期限切れ・今日が期限のTodoの一覧(期限の昇順)用のインデックス
+CREATE INDEX IF NOT EXISTS idx_todos_due_at_id ON todos (due_at, id) WHERE due_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_todos_user_id_due_at_id ON todos (user_id, due_at, id) WHERE due_at IS NOT NULL;
+-- 送信待ちのリマインダーの取得用のインデックス
+CREATE INDEX IF NOT EXISTS idx_todos_pending_reminders ON todos (remind_at)
+    WHERE remind_at IS NOT NULL AND reminded_at IS NULL AND completed = false;
@@ -0,0 +1,26 @@
+-- Todoの優先度(0: 未設定, 1: 低, 2: 中, 3: 高)
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS priority smallint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_todos_priority_id ON todos (priority, id);
+
+-- タグ
+-- タグはユーザーごとに管理し、同じユーザーのタグ名は大文字・小文字を区別せず一意とする。
+CREATE TABLE IF NOT EXISTS tags (
+    id         uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name       text        NOT NULL,
+    color      text        NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_id_lower_name ON tags (user_id, lower(name));
+
+-- Todoとタグの関連(多対多)
+-- Todo・タグのどちらを削除しても関連は削除される。
+CREATE TABLE IF NOT EXISTS todo_tags (
+    todo_id uuid NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    tag_id  uuid NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (todo_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_tags_tag_id ON todo_tags (tag_id, todo_id);
@@ -0,0 +1,6 @@
+-- Todoの親子関係(サブタスク)
+-- 親のTodoを削除した場合はサブタスクも削除する。親子は同じ所有者とし、階層の深さ・循環はアプリケーションで検証する。
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS parent_id uuid REFERENCES todos(id) ON DELETE CASCADE;
+
+-- 子のTodoの取得(GetTodoTree・親の完了状態の判定)用のインデックス
+CREATE INDEX IF NOT EXISTS idx_todos_parent_id ON todos (parent_id, created_at, id) WHERE parent_id IS NOT NULL;
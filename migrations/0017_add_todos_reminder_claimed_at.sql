@@ -0,0 +1,4 @@
+-- リマインダーの送信中の確保日時
+-- 送信対象の行を短いトランザクションで確保してから通知するため、通知中に行ロックを保持しない。
+-- 結果を記録するとNULLに戻し、一定時間を過ぎても残っている確保は失効したものとして再度取得する。
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS reminder_claimed_at timestamptz;
@@ -0,0 +1,9 @@
+-- パージしたユーザーのTodoの保管先に、Todoに追加した列を保管する
+-- タグはユーザーと共に削除されるため、名前の配列として保管する。
+-- parent_idは親のTodoも保管されるとは限らないため、外部キーは設定しない。
+ALTER TABLE archived_todos ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE archived_todos ADD COLUMN IF NOT EXISTS due_at timestamptz;
+ALTER TABLE archived_todos ADD COLUMN IF NOT EXISTS remind_at timestamptz;
+ALTER TABLE archived_todos ADD COLUMN IF NOT EXISTS priority smallint NOT NULL DEFAULT 0;
+ALTER TABLE archived_todos ADD COLUMN IF NOT EXISTS parent_id uuid;
+ALTER TABLE archived_todos ADD COLUMN IF NOT EXISTS tag_names text[] NOT NULL DEFAULT '{}';
@@ -20,24 +20,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TodoService_GetAllTodos_FullMethodName     = "/pb.TodoService/GetAllTodos"
-	TodoService_GetTodoById_FullMethodName     = "/pb.TodoService/GetTodoById"
-	TodoService_GetTodoByUserId_FullMethodName = "/pb.TodoService/GetTodoByUserId"
-	TodoService_CreateTodo_FullMethodName      = "/pb.TodoService/CreateTodo"
-	TodoService_UpdateTodo_FullMethodName      = "/pb.TodoService/UpdateTodo"
-	TodoService_DeleteTodo_FullMethodName      = "/pb.TodoService/DeleteTodo"
+	TodoService_GetAllTodos_FullMethodName      = "/pb.TodoService/GetAllTodos"
+	TodoService_ListTodos_FullMethodName        = "/pb.TodoService/ListTodos"
+	TodoService_SearchTodos_FullMethodName      = "/pb.TodoService/SearchTodos"
+	TodoService_ListOverdueTodos_FullMethodName = "/pb.TodoService/ListOverdueTodos"
+	TodoService_ListDueToday_FullMethodName     = "/pb.TodoService/ListDueToday"
+	TodoService_GetTodoById_FullMethodName      = "/pb.TodoService/GetTodoById"
+	TodoService_GetTodoTree_FullMethodName      = "/pb.TodoService/GetTodoTree"
+	TodoService_GetTodoByUserId_FullMethodName  = "/pb.TodoService/GetTodoByUserId"
+	TodoService_CreateTodo_FullMethodName       = "/pb.TodoService/CreateTodo"
+	TodoService_UpdateTodo_FullMethodName       = "/pb.TodoService/UpdateTodo"
+	TodoService_PatchTodo_FullMethodName        = "/pb.TodoService/PatchTodo"
+	TodoService_DeleteTodo_FullMethodName       = "/pb.TodoService/DeleteTodo"
+	TodoService_ListTags_FullMethodName         = "/pb.TodoService/ListTags"
+	TodoService_CreateTag_FullMethodName        = "/pb.TodoService/CreateTag"
+	TodoService_UpdateTag_FullMethodName        = "/pb.TodoService/UpdateTag"
+	TodoService_DeleteTag_FullMethodName        = "/pb.TodoService/DeleteTag"
 )
 
 // TodoServiceClient is the client API for TodoService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TodoServiceClient interface {
-	GetAllTodos(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TodoList, error)
+	GetAllTodos(ctx context.Context, in *GetAllTodosRequest, opts ...grpc.CallOption) (*TodoList, error)
+	ListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*TodoList, error)
+	SearchTodos(ctx context.Context, in *SearchTodosRequest, opts ...grpc.CallOption) (*SearchTodosResponse, error)
+	ListOverdueTodos(ctx context.Context, in *ListOverdueTodosRequest, opts ...grpc.CallOption) (*TodoList, error)
+	ListDueToday(ctx context.Context, in *ListDueTodayRequest, opts ...grpc.CallOption) (*TodoList, error)
 	GetTodoById(ctx context.Context, in *GetTodoByIdRequest, opts ...grpc.CallOption) (*Todo, error)
+	GetTodoTree(ctx context.Context, in *GetTodoTreeRequest, opts ...grpc.CallOption) (*TodoTree, error)
 	GetTodoByUserId(ctx context.Context, in *GetTodoByUserIdRequest, opts ...grpc.CallOption) (*TodoList, error)
 	CreateTodo(ctx context.Context, in *CreateTodoRequest, opts ...grpc.CallOption) (*Todo, error)
 	UpdateTodo(ctx context.Context, in *UpdateTodoRequest, opts ...grpc.CallOption) (*Todo, error)
+	PatchTodo(ctx context.Context, in *PatchTodoRequest, opts ...grpc.CallOption) (*Todo, error)
 	DeleteTodo(ctx context.Context, in *DeleteTodoRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*TagList, error)
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*Tag, error)
+	UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*Tag, error)
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type todoServiceClient struct {
@@ -48,7 +68,7 @@ func NewTodoServiceClient(cc grpc.ClientConnInterface) TodoServiceClient {
 	return &todoServiceClient{cc}
 }
 
-func (c *todoServiceClient) GetAllTodos(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TodoList, error) {
+func (c *todoServiceClient) GetAllTodos(ctx context.Context, in *GetAllTodosRequest, opts ...grpc.CallOption) (*TodoList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TodoList)
 	err := c.cc.Invoke(ctx, TodoService_GetAllTodos_FullMethodName, in, out, cOpts...)
@@ -58,6 +78,46 @@ func (c *todoServiceClient) GetAllTodos(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
+func (c *todoServiceClient) ListTodos(ctx context.Context, in *ListTodosRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoService_ListTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) SearchTodos(ctx context.Context, in *SearchTodosRequest, opts ...grpc.CallOption) (*SearchTodosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTodosResponse)
+	err := c.cc.Invoke(ctx, TodoService_SearchTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListOverdueTodos(ctx context.Context, in *ListOverdueTodosRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoService_ListOverdueTodos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) ListDueToday(ctx context.Context, in *ListDueTodayRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoService_ListDueToday_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *todoServiceClient) GetTodoById(ctx context.Context, in *GetTodoByIdRequest, opts ...grpc.CallOption) (*Todo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Todo)
@@ -68,6 +128,16 @@ func (c *todoServiceClient) GetTodoById(ctx context.Context, in *GetTodoByIdRequ
 	return out, nil
 }
 
+func (c *todoServiceClient) GetTodoTree(ctx context.Context, in *GetTodoTreeRequest, opts ...grpc.CallOption) (*TodoTree, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoTree)
+	err := c.cc.Invoke(ctx, TodoService_GetTodoTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *todoServiceClient) GetTodoByUserId(ctx context.Context, in *GetTodoByUserIdRequest, opts ...grpc.CallOption) (*TodoList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TodoList)
@@ -98,6 +168,16 @@ func (c *todoServiceClient) UpdateTodo(ctx context.Context, in *UpdateTodoReques
 	return out, nil
 }
 
+func (c *todoServiceClient) PatchTodo(ctx context.Context, in *PatchTodoRequest, opts ...grpc.CallOption) (*Todo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Todo)
+	err := c.cc.Invoke(ctx, TodoService_PatchTodo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *todoServiceClient) DeleteTodo(ctx context.Context, in *DeleteTodoRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -108,16 +188,66 @@ func (c *todoServiceClient) DeleteTodo(ctx context.Context, in *DeleteTodoReques
 	return out, nil
 }
 
+func (c *todoServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*TagList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagList)
+	err := c.cc.Invoke(ctx, TodoService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, TodoService_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, TodoService_UpdateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TodoService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TodoServiceServer is the server API for TodoService service.
 // All implementations must embed UnimplementedTodoServiceServer
 // for forward compatibility.
 type TodoServiceServer interface {
-	GetAllTodos(context.Context, *emptypb.Empty) (*TodoList, error)
+	GetAllTodos(context.Context, *GetAllTodosRequest) (*TodoList, error)
+	ListTodos(context.Context, *ListTodosRequest) (*TodoList, error)
+	SearchTodos(context.Context, *SearchTodosRequest) (*SearchTodosResponse, error)
+	ListOverdueTodos(context.Context, *ListOverdueTodosRequest) (*TodoList, error)
+	ListDueToday(context.Context, *ListDueTodayRequest) (*TodoList, error)
 	GetTodoById(context.Context, *GetTodoByIdRequest) (*Todo, error)
+	GetTodoTree(context.Context, *GetTodoTreeRequest) (*TodoTree, error)
 	GetTodoByUserId(context.Context, *GetTodoByUserIdRequest) (*TodoList, error)
 	CreateTodo(context.Context, *CreateTodoRequest) (*Todo, error)
 	UpdateTodo(context.Context, *UpdateTodoRequest) (*Todo, error)
+	PatchTodo(context.Context, *PatchTodoRequest) (*Todo, error)
 	DeleteTodo(context.Context, *DeleteTodoRequest) (*emptypb.Empty, error)
+	ListTags(context.Context, *ListTagsRequest) (*TagList, error)
+	CreateTag(context.Context, *CreateTagRequest) (*Tag, error)
+	UpdateTag(context.Context, *UpdateTagRequest) (*Tag, error)
+	DeleteTag(context.Context, *DeleteTagRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedTodoServiceServer()
 }
 
@@ -128,12 +258,27 @@ type TodoServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedTodoServiceServer struct{}
 
-func (UnimplementedTodoServiceServer) GetAllTodos(context.Context, *emptypb.Empty) (*TodoList, error) {
+func (UnimplementedTodoServiceServer) GetAllTodos(context.Context, *GetAllTodosRequest) (*TodoList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllTodos not implemented")
 }
+func (UnimplementedTodoServiceServer) ListTodos(context.Context, *ListTodosRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) SearchTodos(context.Context, *SearchTodosRequest) (*SearchTodosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) ListOverdueTodos(context.Context, *ListOverdueTodosRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdueTodos not implemented")
+}
+func (UnimplementedTodoServiceServer) ListDueToday(context.Context, *ListDueTodayRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDueToday not implemented")
+}
 func (UnimplementedTodoServiceServer) GetTodoById(context.Context, *GetTodoByIdRequest) (*Todo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTodoById not implemented")
 }
+func (UnimplementedTodoServiceServer) GetTodoTree(context.Context, *GetTodoTreeRequest) (*TodoTree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTodoTree not implemented")
+}
 func (UnimplementedTodoServiceServer) GetTodoByUserId(context.Context, *GetTodoByUserIdRequest) (*TodoList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTodoByUserId not implemented")
 }
@@ -143,9 +288,24 @@ func (UnimplementedTodoServiceServer) CreateTodo(context.Context, *CreateTodoReq
 func (UnimplementedTodoServiceServer) UpdateTodo(context.Context, *UpdateTodoRequest) (*Todo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTodo not implemented")
 }
+func (UnimplementedTodoServiceServer) PatchTodo(context.Context, *PatchTodoRequest) (*Todo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchTodo not implemented")
+}
 func (UnimplementedTodoServiceServer) DeleteTodo(context.Context, *DeleteTodoRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTodo not implemented")
 }
+func (UnimplementedTodoServiceServer) ListTags(context.Context, *ListTagsRequest) (*TagList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedTodoServiceServer) CreateTag(context.Context, *CreateTagRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedTodoServiceServer) UpdateTag(context.Context, *UpdateTagRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTag not implemented")
+}
+func (UnimplementedTodoServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
 func (UnimplementedTodoServiceServer) mustEmbedUnimplementedTodoServiceServer() {}
 func (UnimplementedTodoServiceServer) testEmbeddedByValue()                     {}
 
@@ -168,7 +328,7 @@ func RegisterTodoServiceServer(s grpc.ServiceRegistrar, srv TodoServiceServer) {
 }
 
 func _TodoService_GetAllTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(GetAllTodosRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -180,7 +340,79 @@ func _TodoService_GetAllTodos_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: TodoService_GetAllTodos_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TodoServiceServer).GetAllTodos(ctx, req.(*emptypb.Empty))
+		return srv.(TodoServiceServer).GetAllTodos(ctx, req.(*GetAllTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListTodos(ctx, req.(*ListTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_SearchTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).SearchTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_SearchTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).SearchTodos(ctx, req.(*SearchTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListOverdueTodos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdueTodosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListOverdueTodos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListOverdueTodos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListOverdueTodos(ctx, req.(*ListOverdueTodosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_ListDueToday_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDueTodayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListDueToday(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListDueToday_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListDueToday(ctx, req.(*ListDueTodayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -203,6 +435,24 @@ func _TodoService_GetTodoById_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TodoService_GetTodoTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTodoTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).GetTodoTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_GetTodoTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).GetTodoTree(ctx, req.(*GetTodoTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TodoService_GetTodoByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTodoByUserIdRequest)
 	if err := dec(in); err != nil {
@@ -257,6 +507,24 @@ func _TodoService_UpdateTodo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TodoService_PatchTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchTodoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).PatchTodo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_PatchTodo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).PatchTodo(ctx, req.(*PatchTodoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TodoService_DeleteTodo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteTodoRequest)
 	if err := dec(in); err != nil {
@@ -275,6 +543,78 @@ func _TodoService_DeleteTodo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TodoService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).UpdateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_UpdateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).UpdateTag(ctx, req.(*UpdateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TodoService_ServiceDesc is the grpc.ServiceDesc for TodoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -286,10 +626,30 @@ var TodoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllTodos",
 			Handler:    _TodoService_GetAllTodos_Handler,
 		},
+		{
+			MethodName: "ListTodos",
+			Handler:    _TodoService_ListTodos_Handler,
+		},
+		{
+			MethodName: "SearchTodos",
+			Handler:    _TodoService_SearchTodos_Handler,
+		},
+		{
+			MethodName: "ListOverdueTodos",
+			Handler:    _TodoService_ListOverdueTodos_Handler,
+		},
+		{
+			MethodName: "ListDueToday",
+			Handler:    _TodoService_ListDueToday_Handler,
+		},
 		{
 			MethodName: "GetTodoById",
 			Handler:    _TodoService_GetTodoById_Handler,
 		},
+		{
+			MethodName: "GetTodoTree",
+			Handler:    _TodoService_GetTodoTree_Handler,
+		},
 		{
 			MethodName: "GetTodoByUserId",
 			Handler:    _TodoService_GetTodoByUserId_Handler,
@@ -302,10 +662,30 @@ var TodoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateTodo",
 			Handler:    _TodoService_UpdateTodo_Handler,
 		},
+		{
+			MethodName: "PatchTodo",
+			Handler:    _TodoService_PatchTodo_Handler,
+		},
 		{
 			MethodName: "DeleteTodo",
 			Handler:    _TodoService_DeleteTodo_Handler,
 		},
+		{
+			MethodName: "ListTags",
+			Handler:    _TodoService_ListTags_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _TodoService_CreateTag_Handler,
+		},
+		{
+			MethodName: "UpdateTag",
+			Handler:    _TodoService_UpdateTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _TodoService_DeleteTag_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/interfaces/todo/todo.proto",